@@ -17,31 +17,146 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
-// BackupSpec defines the desired state of Backup
 // BackupSpec defines the desired state of Backup
 type BackupSpec struct {
-	// PolicyRef references the BackupPolicy that created this backup
-	// +kubebuilder:validation:Required
-	PolicyRef string `json:"policyRef"`
+	// PolicyRef references the BackupPolicy that created this backup.
+	// Empty for ad-hoc backups created outside a policy.
+	// +optional
+	PolicyRef string `json:"policyRef,omitempty"`
 
 	// Target defines what to backup (copied from BackupPolicy)
 	// +kubebuilder:validation:Required
 	Target BackupTarget `json:"target"`
+
+	// TTL is how long the backup is kept after it finishes.
+	// The Backup is deleted once it expires, whether or not it belongs to a policy.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// ExpiresAt is an absolute expiry time for the backup. It takes precedence over TTL.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// JobTemplate customizes the mover Job (copied from BackupPolicy)
+	// +optional
+	JobTemplate *MoverJobTemplate `json:"jobTemplate,omitempty"`
+
+	// Throttle limits the IO rate of the backup Job (copied from BackupPolicy)
+	// +optional
+	Throttle *ThrottleSpec `json:"throttle,omitempty"`
+
+	// Priority orders the operator's queue (copied from BackupPolicy). Backups
+	// with a higher priority run first and are preempted last.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Retry controls how failed backup Jobs are retried (copied from BackupPolicy)
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Timeout is how long a single backup attempt may run (copied from BackupPolicy)
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// StorageLocation is the BackupStorageLocation holding the archive (copied
+	// from BackupPolicy). Defaults to the operator's storage PVC.
+	// +optional
+	StorageLocation string `json:"storageLocation,omitempty"`
+
+	// Compression of the archive (copied from BackupPolicy). Restores and
+	// verifications read it to decompress the archive. Defaults to gzip.
+	// +optional
+	Compression *CompressionSpec `json:"compression,omitempty"`
+
+	// Replicas are the BackupStorageLocations the archive is copied to once it
+	// is complete (copied from BackupPolicy)
+	// +optional
+	Replicas []string `json:"replicas,omitempty"`
+
+	// Group is the ID of the consistency group this backup is a member of.
+	// Members of a group run the group's pre-hook once and start together.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// PreHook runs once per group before any member starts (copied from BackupPolicy)
+	// +optional
+	PreHook *BackupHook `json:"preHook,omitempty"`
+
+	// Hold protects the backup from retention, expiry and deletion until it is cleared.
+	// Setting the hold annotation to "true" has the same effect.
+	// +optional
+	Hold bool `json:"hold,omitempty"`
+
+	// Cancel stops a pending or running backup. Its Job is deleted, the partial
+	// archive is removed from storage and the backup ends in the Cancelled phase.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
 }
 
+const (
+	// HoldAnnotation places a Backup on hold when set to "true"
+	HoldAnnotation = "backup.manuchim.dev/hold"
+
+	// HoldFinalizer keeps a held Backup from being deleted until the hold is cleared
+	HoldFinalizer = "backup.manuchim.dev/hold"
+
+	// VerifyAnnotation requests a test restore of a completed Backup. Its value is
+	// the RFC3339 request time; a request newer than the last verification is run.
+	VerifyAnnotation = "backup.manuchim.dev/verify"
+
+	// PolicyLabel holds the name of the BackupPolicy that created a Backup.
+	// It is set on the Backup and on its Jobs.
+	PolicyLabel = "backup.manuchim.dev/policy"
+
+	// TickLabel holds the schedule tick a scheduled Backup was created for,
+	// in UTC as 20060102T150405Z
+	TickLabel = "backup.manuchim.dev/tick"
+
+	// TargetLabel holds the name of the PVC a Backup copies
+	TargetLabel = "backup.manuchim.dev/target"
+
+	// GroupLabel holds the consistency group of a Backup. It is set on the
+	// Backup, on its Jobs and on the group's pre-hook Job.
+	GroupLabel = "backup.manuchim.dev/group"
+
+	// MoverLabel marks backup and restore Jobs, their pods and the ConfigMaps
+	// they report progress to with the kind of mover ("backup" or "restore"),
+	// so running movers can be counted
+	MoverLabel = "backup.manuchim.dev/mover"
+
+	// StorageLabel holds the storage PVC a backup or restore Job reads or writes
+	StorageLabel = "backup.manuchim.dev/storage"
+
+	// PruneReplicasAnnotation lists, comma separated, the replica locations whose
+	// copy of the archive should be removed. The policy sets it when a location's
+	// keepLast no longer selects the copy.
+	PruneReplicasAnnotation = "backup.manuchim.dev/prune-replicas"
+)
+
 // BackupStatus defines the observed state of Backup
 type BackupStatus struct {
+	// ObservedGeneration is the spec generation this status was computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Phase represents the current phase of the backup
-	// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+	// +kubebuilder:validation:Enum=Pending;Queued;Running;Completed;Failed;Cancelled
 	// +optional
 	Phase BackupPhase `json:"phase,omitempty"`
 
+	// QueuePosition is the place of a Queued backup in the operator's queue,
+	// starting at 1 for the next backup to run
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
 	// StartTime is when the backup started
 	// +optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
@@ -54,6 +169,50 @@ type BackupStatus struct {
 	// +optional
 	BackupLocation string `json:"backupLocation,omitempty"`
 
+	// Size is the size of the archive, as reported by the backup Job
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// DataSize is the size of the backed-up files before compression.
+	// Restores use it to report their progress.
+	// +optional
+	DataSize *resource.Quantity `json:"dataSize,omitempty"`
+
+	// FileCount is the number of files and directories in the archive
+	// +optional
+	FileCount int64 `json:"fileCount,omitempty"`
+
+	// SourcePVC is the spec of the backed-up PVC, recorded when the backup Job
+	// is created. Migration restores create their PVC from it.
+	// +optional
+	SourcePVC *SourcePVCSpec `json:"sourcePVC,omitempty"`
+
+	// Progress of the running backup Job
+	// +optional
+	Progress *Progress `json:"progress,omitempty"`
+
+	// Attempts is the number of backup Jobs started so far
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// FailureReason explains why the last attempt failed, taken from the Job's Failed condition
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// ExpirationTime is when the backup will be garbage collected, derived from TTL or ExpiresAt
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// LastVerificationTime is when the backup was last test-restored
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+
+	// Replicas is the state of the archive's copy in each replica location
+	// +listType=map
+	// +listMapKey=storageLocation
+	// +optional
+	Replicas []ReplicaStatus `json:"replicas,omitempty"`
+
 	// conditions represent the current state of the Backup resource
 	// +listType=map
 	// +listMapKey=type
@@ -61,26 +220,89 @@ type BackupStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// SourcePVCSpec is the part of a PVC's spec needed to provision a copy of it
+type SourcePVCSpec struct {
+	// StorageClassName of the PVC
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// AccessModes of the PVC
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// Size requested by the PVC
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
 // BackupPhase represents the phase of a backup
-// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+// +kubebuilder:validation:Enum=Pending;Queued;Running;Completed;Failed;Cancelled
 type BackupPhase string
 
 const (
 	BackupPhasePending   BackupPhase = "Pending"
+	BackupPhaseQueued    BackupPhase = "Queued"
 	BackupPhaseRunning   BackupPhase = "Running"
 	BackupPhaseCompleted BackupPhase = "Completed"
 	BackupPhaseFailed    BackupPhase = "Failed"
+	BackupPhaseCancelled BackupPhase = "Cancelled"
+)
+
+// ReplicaStatus is the state of an archive's copy in a replica location
+type ReplicaStatus struct {
+	// StorageLocation is the BackupStorageLocation holding the copy
+	StorageLocation string `json:"storageLocation"`
+
+	// Phase of the copy
+	// +optional
+	Phase ReplicaPhase `json:"phase,omitempty"`
+
+	// Attempts is the number of copy Jobs started so far
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// PruneAttempts is the number of Jobs started so far to remove the copy
+	// +optional
+	PruneAttempts int32 `json:"pruneAttempts,omitempty"`
+
+	// CompletionTime is when the copy finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message explains the last failure or removal of the copy
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ReplicaPhase represents the phase of an archive's copy in a replica location
+// +kubebuilder:validation:Enum=Pending;Replicating;Completed;Failed;Pruned;PruneFailed
+type ReplicaPhase string
+
+const (
+	ReplicaPhasePending     ReplicaPhase = "Pending"
+	ReplicaPhaseReplicating ReplicaPhase = "Replicating"
+	ReplicaPhaseCompleted   ReplicaPhase = "Completed"
+	ReplicaPhaseFailed      ReplicaPhase = "Failed"
+	ReplicaPhasePruned      ReplicaPhase = "Pruned"
+	// ReplicaPhasePruneFailed means the copy is still in the location because
+	// every attempt to remove it failed
+	ReplicaPhasePruneFailed ReplicaPhase = "PruneFailed"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.policyRef`
+// +kubebuilder:printcolumn:name="Queue",type=integer,JSONPath=`.status.queuePosition`,priority=1
+// +kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.group`,priority=1
+// +kubebuilder:printcolumn:name="Progress",type=integer,JSONPath=`.status.progress.percentage`
+// +kubebuilder:printcolumn:name="Size",type=string,JSONPath=`.status.size`
+// +kubebuilder:printcolumn:name="Attempts",type=integer,JSONPath=`.status.attempts`,priority=1
+// +kubebuilder:printcolumn:name="Replicated",type=string,JSONPath=`.status.conditions[?(@.type=="Replicated")].status`,priority=1
+// +kubebuilder:printcolumn:name="Verified",type=string,JSONPath=`.status.conditions[?(@.type=="Verified")].status`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expirationTime`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
-// +kubebuilder:object:root=true
-// +kubebuilder:subresource:status
-
 // Backup is the Schema for the backups API
 type Backup struct {
 	metav1.TypeMeta `json:",inline"`
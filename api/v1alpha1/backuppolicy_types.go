@@ -36,8 +36,169 @@ type BackupPolicySpec struct {
 	// Retention defines how many backups to keep
 	// +optional
 	Retention *RetentionPolicy `json:"retention,omitempty"`
+
+	// Suspend pauses scheduled backups without deleting the policy.
+	// On-demand backups requested through the trigger-now annotation still run.
+	// Schedule ticks that pass while suspended are skipped, not caught up on resume.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// StartingDeadlineSeconds is how late a missed schedule tick may still be
+	// run, for example after the operator was down. Only the most recent
+	// missed tick is run; without a deadline it is run however late it is.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Verification test-restores backups into a scratch volume to prove they are usable
+	// +optional
+	Verification *VerificationSpec `json:"verification,omitempty"`
+
+	// JobTemplate customizes the mover Jobs of backups created by this policy
+	// +optional
+	JobTemplate *MoverJobTemplate `json:"jobTemplate,omitempty"`
+
+	// Throttle limits the IO rate of the backup Jobs of this policy
+	// +optional
+	Throttle *ThrottleSpec `json:"throttle,omitempty"`
+
+	// Priority of this policy's backups in the operator's queue. When the
+	// operator caps running backups, higher priorities run first and are
+	// preempted by restores last. Defaults to 0; may be negative.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Retry controls how failed backup Jobs are retried. Without it a backup is attempted once.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Timeout is how long a single backup attempt may run before it is failed
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// StorageLocation is the BackupStorageLocation archives are written to.
+	// Defaults to the operator's storage PVC.
+	// +optional
+	StorageLocation string `json:"storageLocation,omitempty"`
+
+	// Compression selects how archives are compressed. Defaults to gzip.
+	// +optional
+	Compression *CompressionSpec `json:"compression,omitempty"`
+
+	// Replicas are secondary storage locations every completed archive is
+	// copied to, along with its checksum and metadata manifest
+	// +optional
+	Replicas []ReplicaLocation `json:"replicas,omitempty"`
+
+	// Group backs up further PVCs together with the target as one consistency
+	// group. Every run creates one Backup per PVC, sharing a group ID.
+	// +optional
+	Group *BackupGroupSpec `json:"group,omitempty"`
 }
 
+// CompressionSpec selects the compression of backup archives
+type CompressionSpec struct {
+	// Algorithm compresses the tar archive. gzip runs the mover image's gzip;
+	// zstd and lz4 run the operator's mover binary, which mover pods copy from
+	// the operator image, so they work with any mover image.
+	// +kubebuilder:validation:Enum=none;gzip;zstd;lz4
+	// +kubebuilder:default=gzip
+	Algorithm CompressionAlgorithm `json:"algorithm"`
+
+	// Level trades speed for size: 1-9 for gzip, 1-19 for zstd and 1-12 for lz4.
+	// Defaults to the algorithm's own default.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=19
+	// +optional
+	Level int32 `json:"level,omitempty"`
+}
+
+// CompressionAlgorithm is a compression of backup archives
+// +kubebuilder:validation:Enum=none;gzip;zstd;lz4
+type CompressionAlgorithm string
+
+const (
+	CompressionNone CompressionAlgorithm = "none"
+	CompressionGzip CompressionAlgorithm = "gzip"
+	CompressionZstd CompressionAlgorithm = "zstd"
+	CompressionLZ4  CompressionAlgorithm = "lz4"
+)
+
+// BackupGroupSpec defines a consistency group: PVCs of one application that
+// are only recoverable when captured at the same point in time
+type BackupGroupSpec struct {
+	// PVCNames are the PVCs backed up along with the target PVC, in the target's namespace
+	// +kubebuilder:validation:MinItems=1
+	PVCNames []string `json:"pvcNames"`
+
+	// PreHook runs once per group before any member starts, e.g. to checkpoint
+	// or quiesce the application. Members start together once it succeeds; if
+	// it fails, every member fails.
+	// +optional
+	PreHook *BackupHook `json:"preHook,omitempty"`
+}
+
+// BackupHook runs a command in a Job
+type BackupHook struct {
+	// Image runs Command (defaults to the mover image)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Command to run; a non-zero exit code fails the hook
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Timeout is how long the hook may run before it is failed
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ReplicaLocation is a secondary storage location archives are copied to
+type ReplicaLocation struct {
+	// StorageLocation is the BackupStorageLocation that receives the copies
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	StorageLocation string `json:"storageLocation"`
+
+	// KeepLast is the number of most recent copies kept in this location, independently
+	// of the policy's retention. Older copies are removed from the location, and a Backup
+	// is not deleted while a location still keeps its copy. Without it, copies are kept
+	// for as long as their Backup.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepLast *int `json:"keepLast,omitempty"`
+}
+
+// VerificationSpec defines when and how backups are verified.
+// At least one of Schedule or EveryNthBackup should be set.
+type VerificationSpec struct {
+	// Schedule in cron format for verifying the most recent completed backup
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// EveryNthBackup verifies every Nth backup created by the policy
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	EveryNthBackup *int `json:"everyNthBackup,omitempty"`
+
+	// Image runs Command against the restored data (defaults to busybox)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Command is an optional integrity check run against the restored data,
+	// which is mounted at /verify. A non-zero exit code fails verification.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// StorageClassName of the scratch volume (defaults to the cluster default)
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// TriggerNowAnnotation requests an immediate, out-of-schedule backup when set
+// on a BackupPolicy. Each distinct value is handled exactly once.
+const TriggerNowAnnotation = "backup.manuchim.dev/trigger-now"
+
 // BackupTarget defines the resource to backup
 type BackupTarget struct {
 	// PVCName is the name of the PersistentVolumeClaim to backup
@@ -49,12 +210,53 @@ type BackupTarget struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
-// RetentionPolicy defines backup retention rules
+// RetentionPolicy defines backup retention rules.
+// Rules are combined: a backup is kept if any rule selects it.
 type RetentionPolicy struct {
 	// KeepLast is the number of most recent backups to retain
 	// +kubebuilder:validation:Minimum=1
 	// +optional
 	KeepLast *int `json:"keepLast,omitempty"`
+
+	// KeepHourly keeps the newest backup of each of the last N hours that have backups
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepHourly *int `json:"keepHourly,omitempty"`
+
+	// KeepDaily keeps the newest backup of each of the last N days that have backups
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepDaily *int `json:"keepDaily,omitempty"`
+
+	// KeepWeekly keeps the newest backup of each of the last N ISO weeks that have backups
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepWeekly *int `json:"keepWeekly,omitempty"`
+
+	// KeepMonthly keeps the newest backup of each of the last N months that have backups
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepMonthly *int `json:"keepMonthly,omitempty"`
+
+	// KeepYearly keeps the newest backup of each of the last N years that have backups
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepYearly *int `json:"keepYearly,omitempty"`
+
+	// KeepWithin keeps every backup created within this duration of the newest backup (e.g. "72h")
+	// +optional
+	KeepWithin *metav1.Duration `json:"keepWithin,omitempty"`
+
+	// FailedBackupsHistoryLimit is the number of most recent failed backups to retain.
+	// Older failed backups and their Jobs are deleted. Failed backups are kept forever when unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedBackupsHistoryLimit *int `json:"failedBackupsHistoryLimit,omitempty"`
+
+	// DryRun reports the backups retention would delete in status and events
+	// without deleting anything
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // BackupPolicyStatus defines the observed state of BackupPolicy.
@@ -62,14 +264,40 @@ type BackupPolicyStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// ObservedGeneration is the spec generation this status was computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// LastBackupTime is when the last backup was created
 	// +optional
 	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
 
+	// LastScheduleTime is the schedule tick most recently handled, whether a
+	// backup was created for it or it was skipped, or when scheduled backups
+	// were resumed. Ticks up to it are never run.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
 	// NextScheduledBackup is when the next backup will be created
 	// +optional
 	NextScheduledBackup *metav1.Time `json:"nextScheduledBackup,omitempty"`
 
+	// LastTriggerToken is the last trigger-now annotation value that was handled
+	// +optional
+	LastTriggerToken string `json:"lastTriggerToken,omitempty"`
+
+	// PlannedDeletions lists the backups retention would delete, populated in dry-run mode
+	// +optional
+	PlannedDeletions []string `json:"plannedDeletions,omitempty"`
+
+	// BackupCount is the number of backups this policy has created
+	// +optional
+	BackupCount int64 `json:"backupCount,omitempty"`
+
+	// LastVerificationTime is when a scheduled verification was last requested
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+
 	// For Kubernetes API conventions, see:
 	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
 
@@ -77,7 +305,7 @@ type BackupPolicyStatus struct {
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
 	// Standard condition types include:
-	// - "Available": the resource is fully functional
+	// - "Ready": the policy is scheduling backups
 	// - "Progressing": the resource is being created or updated
 	// - "Degraded": the resource failed to reach or maintain its desired state
 	//
@@ -90,6 +318,10 @@ type BackupPolicyStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Last Backup",type=date,JSONPath=`.status.lastBackupTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // BackupPolicy is the Schema for the backuppolicies API
 type BackupPolicy struct {
@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupStorageLocationSpec defines the desired state of BackupStorageLocation
+type BackupStorageLocationSpec struct {
+	// PVCName is the PVC, in the location's namespace, that holds the archives
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	PVCName string `json:"pvcName"`
+
+	// SyncInterval is how often the location is scanned for archives without a
+	// Backup, which are then imported. Unset or 0s disables importing; archives
+	// written by this cluster are never imported.
+	// +optional
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+}
+
+const (
+	// OriginLabel records where a Backup came from. Backups imported from a
+	// storage location are labeled OriginImported.
+	OriginLabel = "backup.manuchim.dev/origin"
+
+	// OriginImported marks a read-only Backup recreated from an archive's metadata manifest
+	OriginImported = "imported"
+
+	// ImportedSpecAnnotation holds the spec an imported Backup was created with,
+	// as JSON. The operator reverts any other change to the spec, except for
+	// spec.hold, and puts the origin label back if it is removed.
+	ImportedSpecAnnotation = "backup.manuchim.dev/imported-spec"
+)
+
+// BackupStorageLocationStatus defines the observed state of BackupStorageLocation
+type BackupStorageLocationStatus struct {
+	// ObservedGeneration is the spec generation this status was computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is when the location was last scanned successfully
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ArchiveCount is the number of archives with a metadata manifest found by the last scan
+	// +optional
+	ArchiveCount int32 `json:"archiveCount,omitempty"`
+
+	// ImportedBackups is the number of Backups imported by the last scan
+	// +optional
+	ImportedBackups int32 `json:"importedBackups,omitempty"`
+
+	// Scan is the scan in progress. A scan reads the manifests one page per
+	// sync Job, so no Job prints more than the kubelet keeps of its log.
+	// +optional
+	Scan *LocationScan `json:"scan,omitempty"`
+
+	// conditions represent the current state of the BackupStorageLocation resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// LocationScan is how far a scan of a storage location has got
+type LocationScan struct {
+	// Page is the number of the page being read, starting at 1
+	Page int32 `json:"page"`
+
+	// Offset is the number of manifests read by the previous pages
+	// +optional
+	Offset int32 `json:"offset,omitempty"`
+
+	// Archives is the number of archives found by the previous pages
+	// +optional
+	Archives int32 `json:"archives,omitempty"`
+
+	// Imported is the number of Backups imported by the previous pages
+	// +optional
+	Imported int32 `json:"imported,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=bsl
+// +kubebuilder:printcolumn:name="PVC",type=string,JSONPath=`.spec.pvcName`
+// +kubebuilder:printcolumn:name="Archives",type=integer,JSONPath=`.status.archiveCount`
+// +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BackupStorageLocation is the Schema for the backupstoragelocations API
+type BackupStorageLocation struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of BackupStorageLocation
+	// +required
+	Spec BackupStorageLocationSpec `json:"spec"`
+
+	// status defines the observed state of BackupStorageLocation
+	// +optional
+	Status BackupStorageLocationStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupStorageLocationList contains a list of BackupStorageLocation
+type BackupStorageLocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []BackupStorageLocation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupStorageLocation{}, &BackupStorageLocationList{})
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Condition types shared by BackupPolicy, Backup and Restore. Every kind
+// reports all three so clients can rely on the same vocabulary:
+//
+//   - Ready: the object reached its desired state (a policy is scheduling,
+//     a backup or restore finished successfully)
+//   - Progressing: work is under way (a mover Job is running or waiting to be retried)
+//   - Degraded: something failed (a failed attempt, a failed backup, an invalid spec)
+const (
+	ConditionReady       = "Ready"
+	ConditionProgressing = "Progressing"
+	ConditionDegraded    = "Degraded"
+
+	// ConditionVerified is set on Backups that were test-restored
+	ConditionVerified = "Verified"
+
+	// ConditionReplicated is set on Backups copied to replica storage locations
+	ConditionReplicated = "Replicated"
+)
@@ -0,0 +1,173 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DisasterRecoveryPlanSpec defines the desired state of DisasterRecoveryPlan
+type DisasterRecoveryPlanSpec struct {
+	// Steps are the volumes to restore. Steps run in parallel unless ordered by dependsOn.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Steps []RecoveryStep `json:"steps"`
+}
+
+// RecoveryStep restores one volume and then runs its post-restore actions.
+// Exactly one of BackupName and PolicyRef must be set.
+type RecoveryStep struct {
+	// Name identifies the step within the plan
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// BackupName restores a specific Backup
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// PolicyRef restores the newest completed Backup of a BackupPolicy at the
+	// time the step starts
+	// +optional
+	PolicyRef string `json:"policyRef,omitempty"`
+
+	// TargetPVC is the PVC to restore data into
+	// +kubebuilder:validation:Required
+	TargetPVC string `json:"targetPVC"`
+
+	// TargetNamespace is where the PVC and the Backup live (defaults to the execution's namespace).
+	// The step's Restore is created in the execution's namespace and restores into this one.
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// DependsOn lists the steps that must complete, including their post-restore
+	// actions, before this step starts
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Retry controls how failed restore Jobs of the step are retried
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// PostRestore actions run in order once the volume is restored. A failed
+	// action fails the step.
+	// +optional
+	PostRestore []PostRestoreAction `json:"postRestore,omitempty"`
+}
+
+// PostRestoreAction is one action run after a step's volume is restored.
+// Exactly one of Scale and ReadinessCheck must be set.
+type PostRestoreAction struct {
+	// Scale sets the replicas of a workload and waits until they are ready
+	// +optional
+	Scale *ScaleAction `json:"scale,omitempty"`
+
+	// ReadinessCheck runs a command in a Job; the action succeeds when it exits with 0
+	// +optional
+	ReadinessCheck *ReadinessCheck `json:"readinessCheck,omitempty"`
+
+	// Timeout is how long the action may take before the step fails. Defaults to 10m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ScaleAction scales a Deployment or StatefulSet
+type ScaleAction struct {
+	// Kind of the workload
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet
+	Kind string `json:"kind"`
+
+	// Name of the workload
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the workload (defaults to the step's target namespace)
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Replicas is the number of replicas to scale to
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+}
+
+// ReadinessCheck runs a command, e.g. a database ping, to confirm the
+// recovered application works
+type ReadinessCheck struct {
+	// Image runs Command (defaults to the mover image)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Command to run; a non-zero exit code fails the check
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+}
+
+// DisasterRecoveryPlanStatus defines the observed state of DisasterRecoveryPlan
+type DisasterRecoveryPlanStatus struct {
+	// ObservedGeneration is the spec generation this status was computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// StepOrder lists the steps in an order that respects their dependencies
+	// +optional
+	StepOrder []string `json:"stepOrder,omitempty"`
+
+	// conditions represent the current state of the DisasterRecoveryPlan resource.
+	// Ready is true when the plan is valid and can be executed.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=drp
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DisasterRecoveryPlan is the Schema for the disasterrecoveryplans API
+type DisasterRecoveryPlan struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of DisasterRecoveryPlan
+	// +required
+	Spec DisasterRecoveryPlanSpec `json:"spec"`
+
+	// status defines the observed state of DisasterRecoveryPlan
+	// +optional
+	Status DisasterRecoveryPlanStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// DisasterRecoveryPlanList contains a list of DisasterRecoveryPlan
+type DisasterRecoveryPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []DisasterRecoveryPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DisasterRecoveryPlan{}, &DisasterRecoveryPlanList{})
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DRExecutionSpec defines the desired state of DRExecution
+type DRExecutionSpec struct {
+	// PlanRef is the DisasterRecoveryPlan to run, in the execution's namespace
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	PlanRef string `json:"planRef"`
+}
+
+const (
+	// DRExecutionLabel holds the name of the DRExecution that created a Restore or Job
+	DRExecutionLabel = "backup.manuchim.dev/dr-execution"
+
+	// DRStepLabel holds the plan step a Restore or Job belongs to
+	DRStepLabel = "backup.manuchim.dev/dr-step"
+)
+
+// DRExecutionStatus defines the observed state of DRExecution
+type DRExecutionStatus struct {
+	// ObservedGeneration is the spec generation this status was computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase represents the current phase of the execution
+	// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+	// +optional
+	Phase DRExecutionPhase `json:"phase,omitempty"`
+
+	// StartTime is when the execution started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the execution finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// CompletedSteps is the number of steps that completed
+	// +optional
+	CompletedSteps int32 `json:"completedSteps,omitempty"`
+
+	// TotalSteps is the number of steps in the plan
+	// +optional
+	TotalSteps int32 `json:"totalSteps,omitempty"`
+
+	// Steps is the state of each plan step, in dependency order
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Steps []RecoveryStepStatus `json:"steps,omitempty"`
+
+	// conditions represent the current state of the DRExecution resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// RecoveryStepStatus is the state of one plan step
+type RecoveryStepStatus struct {
+	// Name of the step in the plan
+	Name string `json:"name"`
+
+	// Phase of the step
+	// +optional
+	Phase RecoveryStepPhase `json:"phase,omitempty"`
+
+	// BackupName is the Backup the step restores, resolved when the step starts
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// RestoreName is the Restore created for the step
+	// +optional
+	RestoreName string `json:"restoreName,omitempty"`
+
+	// CurrentAction is the index of the post-restore action being run
+	// +optional
+	CurrentAction int32 `json:"currentAction,omitempty"`
+
+	// ActionStartTime is when the current post-restore action started
+	// +optional
+	ActionStartTime *metav1.Time `json:"actionStartTime,omitempty"`
+
+	// CompletionTime is when the step finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message explains the step's current state
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DRExecutionPhase represents the phase of a DRExecution
+// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+type DRExecutionPhase string
+
+const (
+	DRExecutionPhasePending   DRExecutionPhase = "Pending"
+	DRExecutionPhaseRunning   DRExecutionPhase = "Running"
+	DRExecutionPhaseCompleted DRExecutionPhase = "Completed"
+	DRExecutionPhaseFailed    DRExecutionPhase = "Failed"
+)
+
+// RecoveryStepPhase represents the phase of a plan step
+// +kubebuilder:validation:Enum=Pending;Restoring;PostRestore;Completed;Failed;Skipped
+type RecoveryStepPhase string
+
+const (
+	RecoveryStepPhasePending     RecoveryStepPhase = "Pending"
+	RecoveryStepPhaseRestoring   RecoveryStepPhase = "Restoring"
+	RecoveryStepPhasePostRestore RecoveryStepPhase = "PostRestore"
+	RecoveryStepPhaseCompleted   RecoveryStepPhase = "Completed"
+	RecoveryStepPhaseFailed      RecoveryStepPhase = "Failed"
+	RecoveryStepPhaseSkipped     RecoveryStepPhase = "Skipped"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=drx
+// +kubebuilder:printcolumn:name="Plan",type=string,JSONPath=`.spec.planRef`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Completed",type=integer,JSONPath=`.status.completedSteps`
+// +kubebuilder:printcolumn:name="Steps",type=integer,JSONPath=`.status.totalSteps`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DRExecution is the Schema for the drexecutions API
+type DRExecution struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of DRExecution
+	// +required
+	Spec DRExecutionSpec `json:"spec"`
+
+	// status defines the observed state of DRExecution
+	// +optional
+	Status DRExecutionStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// DRExecutionList contains a list of DRExecution
+type DRExecutionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []DRExecution `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DRExecution{}, &DRExecutionList{})
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MoverJobTemplate customizes the pods of the Jobs that move backup data.
+// Every field that is set replaces the operator default for that field as a whole.
+type MoverJobTemplate struct {
+	// Resources applied to every mover container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector constrains mover pods to matching nodes
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity scheduling rules for mover pods
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations for mover pods
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// PriorityClassName of mover pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// ServiceAccountName mover pods run as
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ImagePullSecrets used to pull mover images
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// PodSecurityContext applied to mover pods
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// SecurityContext applied to every mover container
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+}
+
+// ThrottleSpec limits how fast a mover reads and writes, so a backup or
+// restore does not starve the workloads sharing its disks. Unset rates are unlimited.
+type ThrottleSpec struct {
+	// ReadBytesPerSecond limits reading the source: the PVC of a backup, or the
+	// archive in storage of a restore. At least 1Ki.
+	// +optional
+	ReadBytesPerSecond *resource.Quantity `json:"readBytesPerSecond,omitempty"`
+
+	// UploadBytesPerSecond limits writing the destination: the archive in
+	// storage of a backup, or the files restored into the target PVC. At least 1Ki.
+	// +optional
+	UploadBytesPerSecond *resource.Quantity `json:"uploadBytesPerSecond,omitempty"`
+}
+
+// RetryPolicy controls how often a failed mover Job is retried.
+// Each attempt runs as a separate Job, so every attempt keeps its own pods and logs.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times the mover Job is run before giving up
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// Backoff is the delay before the second attempt; it doubles for every further attempt.
+	// Defaults to 10s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// Progress is how far the running mover Job has got, as reported in its logs.
+// Totals are left unset when they are not known, e.g. when restoring a backup
+// taken before totals were recorded.
+type Progress struct {
+	// BytesDone is the amount of data processed so far
+	// +optional
+	BytesDone int64 `json:"bytesDone,omitempty"`
+
+	// BytesTotal is the amount of data to process
+	// +optional
+	BytesTotal int64 `json:"bytesTotal,omitempty"`
+
+	// FilesDone is the number of files and directories processed so far
+	// +optional
+	FilesDone int64 `json:"filesDone,omitempty"`
+
+	// FilesTotal is the number of files and directories to process
+	// +optional
+	FilesTotal int64 `json:"filesTotal,omitempty"`
+
+	// Percentage is the share of the work done, by bytes when both byte counts
+	// are known and by files otherwise
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// EstimatedCompletionTime extrapolates the rate so far to the remaining work
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+
+	// LastUpdateTime is when the progress was last sampled
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,23 +27,172 @@ import (
 
 // RestoreSpec defines the desired state of Restore
 type RestoreSpec struct {
-	// BackupName is the name of the Backup to restore from
-	// +kubebuilder:validation:Required
-	BackupName string `json:"backupName"`
+	// BackupName is the name of the Backup to restore from.
+	// Exactly one of BackupName and Group must be set.
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
 
-	// TargetPVC is the PVC to restore data into
-	// +kubebuilder:validation:Required
-	TargetPVC string `json:"targetPVC"`
+	// TargetPVC is the PVC to restore data into. Required with BackupName.
+	// +optional
+	TargetPVC string `json:"targetPVC,omitempty"`
+
+	// Group restores every member of a consistency group together, each into
+	// the PVC it was backed up from. The members are restored by one Restore
+	// each, created by and owned by this one.
+	// +optional
+	Group string `json:"group,omitempty"`
 
-	// TargetNamespace is where to create/restore the PVC (defaults to Restore's namespace)
+	// Migration creates TargetPVC from the backed-up PVC's spec, with the given
+	// overrides, instead of restoring into an existing PVC. It turns a
+	// backup and restore into a move to another storage class or size.
+	// +optional
+	Migration *MigrationSpec `json:"migration,omitempty"`
+
+	// TargetNamespace is where to create/restore the PVC (defaults to Restore's namespace).
+	// A namespaceMapping entry for the backup's namespace takes precedence.
 	// +optional
 	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// BackupNamespace is where the Backup, or the Backups of Group, are looked
+	// up (defaults to targetNamespace, then the Restore's namespace)
+	// +optional
+	BackupNamespace string `json:"backupNamespace,omitempty"`
+
+	// Transforms run in order against the restored volume, mounted at
+	// /restore-target, before the restore completes, e.g. to scrub personal
+	// data from a production copy. A failing transform fails the attempt.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Transforms []RestoreTransform `json:"transforms,omitempty"`
+
+	// NamespaceMapping maps the namespace a backup was taken in to the namespace
+	// its PVC is restored into, e.g. production: staging. The restore Job runs in
+	// the mapped namespace, so the backup's storage location must exist there too.
+	// Without an entry for the backup's namespace targetNamespace is used.
+	// +optional
+	NamespaceMapping map[string]string `json:"namespaceMapping,omitempty"`
+
+	// NameTransforms rewrite the target PVC name, applied in order. A group
+	// restore applies them to the PVC of every member.
+	// +optional
+	NameTransforms []NameTransform `json:"nameTransforms,omitempty"`
+
+	// JobTemplate customizes the restore Job
+	// +optional
+	JobTemplate *MoverJobTemplate `json:"jobTemplate,omitempty"`
+
+	// Throttle limits the IO rate of the restore Job
+	// +optional
+	Throttle *ThrottleSpec `json:"throttle,omitempty"`
+
+	// Retry controls how failed restore Jobs are retried. Without it a restore is attempted once.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// Timeout is how long a single restore attempt may run before it is failed
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Cancel stops a pending or running restore. Its Job is deleted and the
+	// restore ends in the Cancelled phase; files already extracted stay in the target PVC.
+	// +optional
+	Cancel bool `json:"cancel,omitempty"`
+}
+
+// RestoreTransform is a command run against the restored volume
+type RestoreTransform struct {
+	// Name identifies the transform; its container is named transform-<name>
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=53
+	Name string `json:"name"`
+
+	// Image runs Command (defaults to the mover image)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Command to run; a non-zero exit code fails the restore attempt
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+}
+
+// NameTransform rewrites a name. Exactly one of Prefix, Suffix and Regex must be set.
+type NameTransform struct {
+	// Prefix is prepended to the name
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix is appended to the name
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// Regex replaces every match in the name
+	// +optional
+	Regex *RegexTransform `json:"regex,omitempty"`
+}
+
+// RegexTransform replaces the matches of a regular expression
+type RegexTransform struct {
+	// Pattern is an RE2 regular expression
+	// +kubebuilder:validation:MinLength=1
+	Pattern string `json:"pattern"`
+
+	// Replacement for each match; $1 and ${name} expand to submatches
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// MigrationSpec overrides the spec of the PVC a migration restore creates.
+// Fields left unset are taken from the source PVC recorded by the backup.
+type MigrationSpec struct {
+	// StorageClassName of the new PVC
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Size requested by the new PVC. It must hold the backup's data.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// AccessModes of the new PVC
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// SwapWorkload points the workload's volume at the new PVC once the data
+	// is restored. Volumes using the source PVC in its pod template are changed;
+	// StatefulSet PVCs created from volumeClaimTemplates cannot be swapped.
+	// +optional
+	SwapWorkload *WorkloadReference `json:"swapWorkload,omitempty"`
+}
+
+// WorkloadReference names a Deployment or StatefulSet in the namespace restored into
+type WorkloadReference struct {
+	// Kind of the workload
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet
+	Kind string `json:"kind"`
+
+	// Name of the workload
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
+const (
+	// RestoreAnnotation holds the name of the Restore that created a PVC or a restore Job
+	RestoreAnnotation = "backup.manuchim.dev/restore"
+
+	// RestoreNamespaceAnnotation holds the namespace of the Restore that created
+	// a restore Job. Jobs in another namespace cannot be owned by their Restore
+	// and are traced back to it by these annotations.
+	RestoreNamespaceAnnotation = "backup.manuchim.dev/restore-namespace"
+)
+
 // RestoreStatus defines the observed state of Restore
 type RestoreStatus struct {
+	// ObservedGeneration is the spec generation this status was computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Phase represents the current phase of the restore
-	// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+	// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed;Cancelled
 	// +optional
 	Phase RestorePhase `json:"phase,omitempty"`
 
@@ -53,10 +204,50 @@ type RestoreStatus struct {
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// Attempts is the number of restore Jobs started so far
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// FailureReason explains why the last attempt failed, taken from the Job's Failed condition
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// Progress of the running restore Job
+	// +optional
+	Progress *Progress `json:"progress,omitempty"`
+
 	// RestoredDataSize is the size of restored data
 	// +optional
 	RestoredDataSize string `json:"restoredDataSize,omitempty"`
 
+	// TargetNamespace is the namespace restored into, after namespaceMapping
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// TargetPVC is the PVC restored into, after nameTransforms
+	// +optional
+	TargetPVC string `json:"targetPVC,omitempty"`
+
+	// Transforms is the output of each transform of the completed attempt
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Transforms []TransformStatus `json:"transforms,omitempty"`
+
+	// ProvisionedPVC is the PVC created by a migration restore
+	// +optional
+	ProvisionedPVC string `json:"provisionedPVC,omitempty"`
+
+	// SwappedWorkload is the workload pointed at the new PVC, as Kind/Name
+	// +optional
+	SwappedWorkload string `json:"swappedWorkload,omitempty"`
+
+	// Members is the state of each member of a group restore
+	// +listType=map
+	// +listMapKey=backupName
+	// +optional
+	Members []RestoreMemberStatus `json:"members,omitempty"`
+
 	// conditions represent the current state of the Restore resource
 	// +listType=map
 	// +listMapKey=type
@@ -64,8 +255,35 @@ type RestoreStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// TransformStatus is the result of one transform
+type TransformStatus struct {
+	// Name of the transform
+	Name string `json:"name"`
+
+	// Output is the end of the transform's log
+	// +optional
+	Output string `json:"output,omitempty"`
+}
+
+// RestoreMemberStatus is the state of one member of a group restore
+type RestoreMemberStatus struct {
+	// BackupName is the member Backup
+	BackupName string `json:"backupName"`
+
+	// TargetPVC is the PVC the member is restored into, after nameTransforms
+	TargetPVC string `json:"targetPVC"`
+
+	// RestoreName is the Restore created for the member
+	// +optional
+	RestoreName string `json:"restoreName,omitempty"`
+
+	// Phase of the member's Restore
+	// +optional
+	Phase RestorePhase `json:"phase,omitempty"`
+}
+
 // RestorePhase represents the phase of a restore operation
-// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed;Cancelled
 type RestorePhase string
 
 const (
@@ -73,13 +291,18 @@ const (
 	RestorePhaseRunning   RestorePhase = "Running"
 	RestorePhaseCompleted RestorePhase = "Completed"
 	RestorePhaseFailed    RestorePhase = "Failed"
+	RestorePhaseCancelled RestorePhase = "Cancelled"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Backup",type=string,JSONPath=`.spec.backupName`
+// +kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.group`,priority=1
 // +kubebuilder:printcolumn:name="Target PVC",type=string,JSONPath=`.spec.targetPVC`
+// +kubebuilder:printcolumn:name="Restored Into",type=string,JSONPath=`.status.targetNamespace`,priority=1
+// +kubebuilder:printcolumn:name="Progress",type=integer,JSONPath=`.status.progress.percentage`
+// +kubebuilder:printcolumn:name="Attempts",type=integer,JSONPath=`.status.attempts`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Restore is the Schema for the restores API
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
@@ -25,10 +26,16 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -36,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
 	"github.com/mxnuchim/k8s-backup-operator/internal/controller"
 	// +kubebuilder:scaffold:imports
 )
@@ -61,6 +69,7 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var configPath string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -79,6 +88,9 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&configPath, "config", "",
+		"Path to the operator configuration file, e.g. a mounted ConfigMap. "+
+			"The file is watched and reloaded on change. Leave empty to use the built-in defaults.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -87,6 +99,17 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	operatorConfig := config.Default()
+	if configPath != "" {
+		loaded, err := config.Load(configPath)
+		if err != nil {
+			setupLog.Error(err, "invalid operator configuration", "path", configPath)
+			os.Exit(1)
+		}
+		operatorConfig = loaded
+	}
+	configStore := config.NewStore(operatorConfig)
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -154,6 +177,14 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
+	// The only ConfigMaps the operator reads are those movers report their
+	// progress to, so the cache holds no others
+	moverRequirement, err := labels.NewRequirement(backupv1alpha1.MoverLabel, selection.Exists, nil)
+	if err != nil {
+		setupLog.Error(err, "unable to select progress ConfigMaps")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
@@ -161,6 +192,11 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "27216528.manuchim.dev",
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.ConfigMap{}: {Label: labels.NewSelector().Add(*moverRequirement)},
+			},
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -178,25 +214,101 @@ func main() {
 		os.Exit(1)
 	}
 
+	if configPath != "" {
+		if err := mgr.Add(&config.Watcher{
+			Path:  configPath,
+			Store: configStore,
+			Log:   ctrl.Log.WithName("config"),
+		}); err != nil {
+			setupLog.Error(err, "unable to set up operator configuration watcher")
+			os.Exit(1)
+		}
+	}
+
+	// The logs and termination messages of mover pods are read directly rather
+	// than through the cache, so the manager only watches pods once
+	// maxConcurrentMovers.perNode needs them
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create Kubernetes clientset")
+		os.Exit(1)
+	}
+
+	// Archive manifests record the cluster that wrote them, so storage
+	// location syncs never import archives of Backups deleted here
+	clusterID, err := controller.ClusterID(context.Background(), clientset.CoreV1())
+	if err != nil {
+		setupLog.Error(err, "unable to read cluster ID")
+		os.Exit(1)
+	}
+
+	// Mover pods copy the mover binary, which handles zstd and lz4 archives and
+	// reports progress, from the operator's own image, which the pod's
+	// downward API env points to
+	var moverToolsImage string
+	if podName, podNamespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE"); podName != "" && podNamespace != "" {
+		moverToolsImage, err = controller.OperatorImage(context.Background(), clientset.CoreV1(), podNamespace, podName)
+		if err != nil {
+			setupLog.Error(err, "unable to read the operator image")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("POD_NAME and POD_NAMESPACE are not set, so zstd and lz4 backups and restores are rejected and movers report no progress")
+	}
+
 	if err := (&controller.BackupPolicyReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		Config: configStore,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BackupPolicy")
 		os.Exit(1)
 	}
 	if err := (&controller.BackupReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Config:          configStore,
+		Pods:            clientset.CoreV1(),
+		ClusterID:       clusterID,
+		MoverToolsImage: moverToolsImage,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Backup")
 		os.Exit(1)
 	}
 	if err := (&controller.RestoreReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Config:          configStore,
+		Pods:            clientset.CoreV1(),
+		MoverToolsImage: moverToolsImage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Restore")
+		os.Exit(1)
+	}
+	if err := (&controller.BackupStorageLocationReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Config:    configStore,
+		Pods:      clientset.CoreV1(),
+		ClusterID: clusterID,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupStorageLocation")
+		os.Exit(1)
+	}
+	if err := (&controller.DisasterRecoveryPlanReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Restore")
+		setupLog.Error(err, "unable to create controller", "controller", "DisasterRecoveryPlan")
+		os.Exit(1)
+	}
+	if err := (&controller.DRExecutionReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Config: configStore,
+		Pods:   clientset.CoreV1(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DRExecution")
 		os.Exit(1)
 	}
 	// +kubebuilder:scaffold:builder
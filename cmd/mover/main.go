@@ -0,0 +1,177 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// mover holds the tools mover pods need beyond their image's shell. It ships
+// in the operator image; an init container of each mover pod copies it into a
+// volume the mover containers share, because the image has no shell to do so.
+//
+//	mover compress -algorithm zstd [-level 19] < archive.tar > archive.tar.zst
+//	mover decompress -algorithm zstd < archive.tar.zst > archive.tar
+//	mover install /mover-tools/mover
+//	echo "progress filesDone=3" | mover report -namespace default -configmap nightly-job-progress
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/compression"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "mover:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: mover compress|decompress|install|report")
+	}
+
+	switch command := args[0]; command {
+	case "compress", "decompress":
+		flags := flag.NewFlagSet(command, flag.ContinueOnError)
+		algorithm := flags.String("algorithm", string(backupv1alpha1.CompressionGzip), "none, gzip, zstd or lz4")
+		level := flags.Int("level", 0, "compression level, 0 for the algorithm's default")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if command == "compress" {
+			return compress(backupv1alpha1.CompressionAlgorithm(*algorithm), *level)
+		}
+		return decompress(backupv1alpha1.CompressionAlgorithm(*algorithm))
+	case "install":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: mover install PATH")
+		}
+		return install(args[1])
+	case "report":
+		flags := flag.NewFlagSet(command, flag.ContinueOnError)
+		namespace := flags.String("namespace", "", "namespace of the ConfigMap")
+		configMap := flags.String("configmap", "", "ConfigMap the progress line is stored in")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *namespace == "" || *configMap == "" {
+			return fmt.Errorf("usage: mover report -namespace NAMESPACE -configmap NAME")
+		}
+		return report(*namespace, *configMap)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// compress compresses stdin to stdout
+func compress(algorithm backupv1alpha1.CompressionAlgorithm, level int) error {
+	out := bufio.NewWriterSize(os.Stdout, 1<<20)
+	writer, err := compression.NewWriter(out, algorithm, level)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(writer, bufio.NewReaderSize(os.Stdin, 1<<20)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// decompress decompresses stdin to stdout
+func decompress(algorithm backupv1alpha1.CompressionAlgorithm) error {
+	reader, err := compression.NewReader(bufio.NewReaderSize(os.Stdin, 1<<20), algorithm)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	out := bufio.NewWriterSize(os.Stdout, 1<<20)
+	if _, err := io.Copy(out, reader); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// install copies this executable to path
+func install(path string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	source, err := os.Open(self)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = source.Close() }()
+
+	target, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(target, source); err != nil {
+		_ = target.Close()
+		return err
+	}
+	return target.Close()
+}
+
+// reportTimeout bounds a progress report, so a slow API server never holds
+// up the next one
+const reportTimeout = 5 * time.Second
+
+// report copies the progress line on stdin to stdout, which keeps it in the
+// pod logs, and stores it in the progress key of a ConfigMap for the operator
+func report(namespace, configMap string) error {
+	line, err := io.ReadAll(io.LimitReader(os.Stdin, 4096))
+	if err != nil {
+		return err
+	}
+	progress := strings.TrimSpace(string(line))
+	fmt.Println(progress)
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]any{"data": map[string]string{"progress": progress}})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+	defer cancel()
+	_, err = clientset.CoreV1().ConfigMaps(namespace).Patch(ctx, configMap, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
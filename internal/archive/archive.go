@@ -0,0 +1,157 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package archive describes the archives of Backups: their file names and the
+// shell commands that read them, shared by the operator's Jobs and the
+// kubectl plugin. The compression itself is implemented by the mover binary,
+// see internal/compression.
+package archive
+
+import (
+	"fmt"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+const (
+	// ToolsVolume holds the mover binary in pods that need it
+	ToolsVolume = "mover-tools"
+	ToolsDir    = "/mover-tools"
+
+	// Tool is the path of the mover binary in those pods. It implements the
+	// compressions busybox lacks, see cmd/mover.
+	Tool = ToolsDir + "/mover"
+)
+
+// algorithm is what the operator and the plugin need to know of a compression
+type algorithm struct {
+	// extension follows the Backup's name in the archive's file name
+	extension string
+
+	// maxLevel is the highest level the algorithm accepts, 0 if it takes none
+	maxLevel int32
+
+	// moverTool is set for the algorithms busybox lacks, which run the mover binary
+	moverTool bool
+}
+
+// algorithms maps every compression to how its archives are written and read
+var algorithms = map[backupv1alpha1.CompressionAlgorithm]algorithm{
+	backupv1alpha1.CompressionNone: {extension: ".tar"},
+	backupv1alpha1.CompressionGzip: {extension: ".tar.gz", maxLevel: 9},
+	backupv1alpha1.CompressionZstd: {extension: ".tar.zst", maxLevel: 19, moverTool: true},
+	backupv1alpha1.CompressionLZ4:  {extension: ".tar.lz4", maxLevel: 12, moverTool: true},
+}
+
+// Algorithms lists every compression, the most common first
+var Algorithms = []backupv1alpha1.CompressionAlgorithm{
+	backupv1alpha1.CompressionGzip,
+	backupv1alpha1.CompressionZstd,
+	backupv1alpha1.CompressionLZ4,
+	backupv1alpha1.CompressionNone,
+}
+
+// Format is how the archive of a Backup is compressed
+type Format struct {
+	Algorithm backupv1alpha1.CompressionAlgorithm
+	Level     int32
+}
+
+// FormatOf returns the archive format of a Backup. Backups without a
+// compression, including those written before it could be chosen, use gzip.
+func FormatOf(backup *backupv1alpha1.Backup) Format {
+	format := Format{Algorithm: backupv1alpha1.CompressionGzip}
+	if compression := backup.Spec.Compression; compression != nil {
+		if compression.Algorithm != "" {
+			format.Algorithm = compression.Algorithm
+		}
+		format.Level = compression.Level
+	}
+	return format
+}
+
+// Name returns the file name of a Backup's archive in its storage location
+func Name(backup *backupv1alpha1.Backup) string {
+	return backup.Name + FormatOf(backup).Extension()
+}
+
+// Extension follows the Backup's name in the archive's file name
+func (f Format) Extension() string {
+	return algorithms[f.Algorithm].extension
+}
+
+// NeedsMoverTools reports whether the format is written and read by the mover
+// binary instead of a command of the mover image
+func (f Format) NeedsMoverTools() bool {
+	return algorithms[f.Algorithm].moverTool
+}
+
+// Validate checks that the level is within the algorithm's range
+func Validate(compression *backupv1alpha1.CompressionSpec) error {
+	if compression == nil || compression.Level == 0 {
+		return nil
+	}
+	maxLevel := algorithms[compression.Algorithm].maxLevel
+	if maxLevel == 0 {
+		return fmt.Errorf("compression %s does not take a level", compression.Algorithm)
+	}
+	if compression.Level < 1 || compression.Level > maxLevel {
+		return fmt.Errorf("compression level %d is out of range for %s, which accepts 1-%d", compression.Level, compression.Algorithm, maxLevel)
+	}
+	return nil
+}
+
+// Decompressor is the command decompressing an archive from stdin to stdout,
+// empty when the archive is not compressed
+func (f Format) Decompressor() string {
+	switch {
+	case f.Algorithm == backupv1alpha1.CompressionNone:
+		return ""
+	case f.NeedsMoverTools():
+		return Tool + " decompress -algorithm " + string(f.Algorithm)
+	}
+	return "gzip -dc"
+}
+
+// DecompressCommand is a shell command that writes the tar stream of the
+// archive at path to stdout
+func (f Format) DecompressCommand(path string) string {
+	if f.NeedsMoverTools() {
+		// The mover binary only reads stdin
+		return f.Decompressor() + " < " + path
+	}
+	if decompressor := f.Decompressor(); decompressor != "" {
+		return decompressor + " " + path
+	}
+	return "cat " + path
+}
+
+// ListCommand is a shell command that lists the archive at path, with the
+// details of every file if verbose. tar reads gzip and uncompressed archives
+// itself; the others are piped into it.
+func (f Format) ListCommand(path string, verbose bool) string {
+	options := "-t"
+	if f.Algorithm == backupv1alpha1.CompressionGzip {
+		options += "z"
+	}
+	if verbose {
+		options += "v"
+	}
+	if f.Algorithm == backupv1alpha1.CompressionNone || f.Algorithm == backupv1alpha1.CompressionGzip {
+		return "tar " + options + "f " + path
+	}
+	return "set -o pipefail; " + f.DecompressCommand(path) + " | tar " + options + "f -"
+}
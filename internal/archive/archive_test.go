@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression *backupv1alpha1.CompressionSpec
+		wantErr     bool
+	}{
+		{name: "unset"},
+		{name: "default level", compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionNone}},
+		{name: "zstd 19", compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionZstd, Level: 19}},
+		{
+			name:        "gzip above 9",
+			compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionGzip, Level: 12},
+			wantErr:     true,
+		},
+		{
+			name:        "level without compression",
+			compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionNone, Level: 1},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.compression); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListCommand(t *testing.T) {
+	tests := []struct {
+		algorithm backupv1alpha1.CompressionAlgorithm
+		verbose   bool
+		want      string
+	}{
+		{algorithm: "", want: "tar -tzf /backups/nightly.tar.gz"},
+		{algorithm: backupv1alpha1.CompressionGzip, verbose: true, want: "tar -tzvf /backups/nightly.tar.gz"},
+		{algorithm: backupv1alpha1.CompressionNone, want: "tar -tf /backups/nightly.tar"},
+		{
+			algorithm: backupv1alpha1.CompressionLZ4,
+			verbose:   true,
+			want:      "set -o pipefail; /mover-tools/mover decompress -algorithm lz4 < /backups/nightly.tar.lz4 | tar -tvf -",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.algorithm), func(t *testing.T) {
+			backup := &backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly"},
+				Spec:       backupv1alpha1.BackupSpec{Compression: &backupv1alpha1.CompressionSpec{Algorithm: tt.algorithm}},
+			}
+			if got := FormatOf(backup).ListCommand("/backups/"+Name(backup), tt.verbose); got != tt.want {
+				t.Errorf("ListCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
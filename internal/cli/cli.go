@@ -0,0 +1,153 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cli implements the commands of the kubectl-backup plugin.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const usage = `kubectl backup manages backups taken by the backup operator.
+
+Usage:
+  kubectl backup list [POLICY] [-A]                  list backups per policy with size and age
+  kubectl backup now POLICY                          take a backup of a policy now
+  kubectl backup restore BACKUP --to PVC             restore a backup
+  kubectl backup restore --policy POLICY --to PVC [--at TIME]
+                                                     restore the latest backup of a policy,
+                                                     or the latest one taken before TIME
+  kubectl backup describe BACKUP [--contents]        show a backup and optionally its files
+  kubectl backup logs BACKUP|restore/NAME [-f]       print the logs of the latest mover Job
+
+Every command accepts -n/--namespace; the default is the kubeconfig context's namespace.
+`
+
+// ErrUsage is returned when the command line cannot be parsed. The usage has
+// already been printed when it is returned.
+var ErrUsage = errors.New("invalid usage")
+
+// Plugin runs kubectl-backup commands against a cluster
+type Plugin struct {
+	// Client reads and writes the operator's resources and Jobs
+	Client client.Client
+
+	// Pods reads the logs of mover pods
+	Pods corev1client.PodsGetter
+
+	// Namespace is used by commands that are not given --namespace
+	Namespace string
+
+	// Out receives the command output
+	Out io.Writer
+
+	// Clock is used for ages and relative times; nil means the real clock
+	Clock clock.Clock
+
+	// PollInterval is how often `describe --contents` checks on its Job; zero means one second
+	PollInterval time.Duration
+}
+
+// Run executes the command named by the first argument
+func (p *Plugin) Run(ctx context.Context, args []string) error {
+	if p.Clock == nil {
+		p.Clock = clock.RealClock{}
+	}
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(p.Out, usage)
+		return ErrUsage
+	}
+
+	switch args[0] {
+	case "list":
+		return p.list(ctx, args[1:])
+	case "now":
+		return p.now(ctx, args[1:])
+	case "restore":
+		return p.restore(ctx, args[1:])
+	case "describe":
+		return p.describe(ctx, args[1:])
+	case "logs":
+		return p.logs(ctx, args[1:])
+	case "help", "-h", "--help":
+		_, _ = fmt.Fprint(p.Out, usage)
+		return nil
+	}
+	_, _ = fmt.Fprint(p.Out, usage)
+	return fmt.Errorf("unknown command %q: %w", args[0], ErrUsage)
+}
+
+// newFlagSet returns a flag set for a command with the shared namespace flags
+func (p *Plugin) newFlagSet(name string) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet("kubectl backup "+name, flag.ContinueOnError)
+	flags.SetOutput(p.Out)
+	namespace := flags.String("namespace", p.Namespace, "namespace of the resources")
+	flags.StringVar(namespace, "n", p.Namespace, "shorthand for --namespace")
+	return flags, namespace
+}
+
+// parseArgs parses flags that may appear before, between or after the
+// positional arguments, the way kubectl accepts them, and returns the
+// positional arguments
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUsage, err)
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// exactArgs checks the number of positional arguments of a command
+func exactArgs(command string, args []string, n int, names string) error {
+	if len(args) != n {
+		return fmt.Errorf("%w: kubectl backup %s expects %s", ErrUsage, command, names)
+	}
+	return nil
+}
+
+// age formats the time since t the way kubectl does, e.g. "5m" or "3d2h"
+func (p *Plugin) age(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(p.Clock.Since(t.Time))
+}
+
+// orNone replaces an empty value in tables and descriptions
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
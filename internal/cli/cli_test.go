@@ -0,0 +1,421 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// testNow is the fake clock's time in plugin tests
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// newTestPlugin returns a plugin in namespace "default" backed by fake clients holding objs
+func newTestPlugin(t *testing.T, funcs interceptor.Funcs, objs ...client.Object) (*Plugin, *fake.Clientset, *bytes.Buffer) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := backupv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&batchv1.Job{}).
+		WithInterceptorFuncs(funcs).
+		Build()
+
+	clientset := fake.NewClientset()
+	out := &bytes.Buffer{}
+	return &Plugin{
+		Client:    c,
+		Pods:      clientset.CoreV1(),
+		Namespace: "default",
+		Out:       out,
+		Clock:     clocktesting.NewFakeClock(testNow),
+	}, clientset, out
+}
+
+// testBackup returns a Backup of policy created age ago, completed when phase is Completed
+func testBackup(name, policy string, phase backupv1alpha1.BackupPhase, age time.Duration) *backupv1alpha1.Backup {
+	created := metav1.NewTime(testNow.Add(-age))
+	backup := &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID(name + "-uid"),
+			CreationTimestamp: created,
+		},
+		Spec: backupv1alpha1.BackupSpec{
+			PolicyRef: policy,
+			Target:    backupv1alpha1.BackupTarget{PVCName: "data"},
+		},
+		Status: backupv1alpha1.BackupStatus{Phase: phase},
+	}
+	if phase == backupv1alpha1.BackupPhaseCompleted {
+		completed := metav1.NewTime(created.Add(time.Minute))
+		backup.Status.CompletionTime = &completed
+		backup.Status.BackupLocation = "/backups/" + name + ".tar.gz"
+		backup.Status.Size = resource.NewQuantity(3*1024*1024, resource.BinarySI)
+	}
+	return backup
+}
+
+// moverJob returns a Job owned by owner that runs the given container
+func moverJob(name string, owner client.Object, container string, age time.Duration) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(testNow.Add(-age)),
+			OwnerReferences:   []metav1.OwnerReference{{Name: owner.GetName(), UID: owner.GetUID()}},
+		},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: container}},
+		}}},
+	}
+}
+
+// jobPod returns a pod of the given Job
+func jobPod(jobName string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      jobName + "-abcde",
+		Namespace: "default",
+		Labels:    map[string]string{batchv1.JobNameLabel: jobName},
+	}}
+}
+
+func TestList(t *testing.T) {
+	p, _, out := newTestPlugin(t, interceptor.Funcs{},
+		testBackup("nightly-old", "nightly", backupv1alpha1.BackupPhaseCompleted, 48*time.Hour),
+		testBackup("nightly-new", "nightly", backupv1alpha1.BackupPhaseRunning, 5*time.Minute),
+		testBackup("adhoc", "", backupv1alpha1.BackupPhaseFailed, time.Hour),
+		testBackup("hourly-1", "hourly", backupv1alpha1.BackupPhaseCompleted, 30*time.Minute),
+	)
+
+	if err := p.Run(context.Background(), []string{"list"}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := [][]string{
+		{"POLICY", "NAME", "PHASE", "SIZE", "AGE"},
+		{"<none>", "adhoc", "Failed", "-", "60m"},
+		{"hourly", "hourly-1", "Completed", "3Mi", "30m"},
+		{"nightly", "nightly-new", "Running", "-", "5m"},
+		{"nightly", "nightly-old", "Completed", "3Mi", "2d"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out)
+	}
+	for i, line := range lines {
+		if got := strings.Fields(line); strings.Join(got, " ") != strings.Join(want[i], " ") {
+			t.Errorf("line %d = %q, want %q", i, got, want[i])
+		}
+	}
+
+	out.Reset()
+	if err := p.Run(context.Background(), []string{"list", "hourly", "-n", "default"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "nightly") || !strings.Contains(out.String(), "hourly-1") {
+		t.Errorf("list hourly printed:\n%s", out)
+	}
+}
+
+func TestNow(t *testing.T) {
+	policy := &backupv1alpha1.BackupPolicy{ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"}}
+	p, _, out := newTestPlugin(t, interceptor.Funcs{}, policy)
+
+	if err := p.Run(context.Background(), []string{"now", "nightly"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got backupv1alpha1.BackupPolicy
+	if err := p.Client.Get(context.Background(), client.ObjectKeyFromObject(policy), &got); err != nil {
+		t.Fatal(err)
+	}
+	token := got.Annotations[backupv1alpha1.TriggerNowAnnotation]
+	if token == "" || !strings.Contains(out.String(), token) {
+		t.Errorf("trigger token %q not set or not printed: %s", token, out)
+	}
+
+	if err := p.Run(context.Background(), []string{"now", "missing"}); err == nil {
+		t.Error("expected an error for a missing policy")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	objs := []client.Object{
+		testBackup("nightly-1", "nightly", backupv1alpha1.BackupPhaseCompleted, 72*time.Hour),
+		testBackup("nightly-2", "nightly", backupv1alpha1.BackupPhaseCompleted, 48*time.Hour),
+		testBackup("nightly-3", "nightly", backupv1alpha1.BackupPhaseCompleted, 24*time.Hour),
+		testBackup("nightly-4", "nightly", backupv1alpha1.BackupPhaseFailed, time.Hour),
+		testBackup("other-1", "other", backupv1alpha1.BackupPhaseCompleted, time.Minute),
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantBackup string
+		wantErr    string
+	}{
+		{name: "named backup", args: []string{"nightly-1", "--to", "restored"}, wantBackup: "nightly-1"},
+		{name: "latest of policy", args: []string{"--policy", "nightly", "--to", "restored"}, wantBackup: "nightly-3"},
+		{
+			name:       "point in time",
+			args:       []string{"--policy", "nightly", "--at", testNow.Add(-30 * time.Hour).Format(time.RFC3339), "--to", "restored"},
+			wantBackup: "nightly-2",
+		},
+		{name: "relative point in time", args: []string{"--policy", "nightly", "--at", "60h", "--to", "restored"}, wantBackup: "nightly-1"},
+		{name: "before the first backup", args: []string{"--policy", "nightly", "--at", "100h", "--to", "restored"}, wantErr: "no completed backup"},
+		{name: "failed backup", args: []string{"nightly-4", "--to", "restored"}, wantErr: "only completed backups"},
+		{name: "missing target", args: []string{"nightly-1"}, wantErr: "--to is required"},
+		{name: "backup and policy", args: []string{"nightly-1", "--policy", "nightly", "--to", "restored"}, wantErr: "either a BACKUP or --policy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _, out := newTestPlugin(t, interceptor.Funcs{}, objs...)
+			err := p.Run(context.Background(), append([]string{"restore"}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var restores backupv1alpha1.RestoreList
+			if err := p.Client.List(context.Background(), &restores); err != nil {
+				t.Fatal(err)
+			}
+			if len(restores.Items) != 1 {
+				t.Fatalf("got %d Restores, want 1", len(restores.Items))
+			}
+			restore := restores.Items[0]
+			if restore.Spec.BackupName != tt.wantBackup || restore.Spec.TargetPVC != "restored" {
+				t.Errorf("Restore spec = %+v, want backup %s into restored", restore.Spec, tt.wantBackup)
+			}
+			if !strings.HasPrefix(restore.Name, tt.wantBackup+"-restore-") || !strings.Contains(out.String(), restore.Name) {
+				t.Errorf("Restore %s not named after the backup or not printed: %s", restore.Name, out)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	backup := testBackup("nightly-1", "nightly", backupv1alpha1.BackupPhaseCompleted, 2*time.Hour)
+	backup.Status.Attempts = 2
+	backup.Status.Conditions = []metav1.Condition{{
+		Type:               backupv1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "BackupCompleted",
+		Message:            "Backup archive written",
+		LastTransitionTime: metav1.NewTime(testNow.Add(-time.Hour)),
+	}}
+	firstJob := moverJob("nightly-1-job", backup, "backup", 2*time.Hour)
+	firstJob.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded"}}
+
+	var clientset *fake.Clientset
+	// The fake API server has no Job controller: complete listing Jobs as they
+	// are created and give them a pod
+	funcs := interceptor.Funcs{Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+		if err := c.Create(ctx, obj, opts...); err != nil {
+			return err
+		}
+		job, ok := obj.(*batchv1.Job)
+		if !ok {
+			return nil
+		}
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		if err := c.Status().Update(ctx, job); err != nil {
+			return err
+		}
+		return clientset.Tracker().Add(jobPod(job.Name))
+	}}
+	p, clientset, out := newTestPlugin(t, funcs, backup, firstJob, moverJob("nightly-1-job-2", backup, "backup", time.Hour))
+
+	if err := p.Run(context.Background(), []string{"describe", "nightly-1", "--contents"}); err != nil {
+		t.Fatal(err)
+	}
+	// Compare with the column padding collapsed
+	got := strings.Join(strings.Fields(out.String()), " ")
+	for _, want := range []string{
+		"Policy: nightly",
+		"Size: 3Mi",
+		"Attempts: 2",
+		"Ready True BackupCompleted 60m Backup archive written",
+		"nightly-1-job Failed: DeadlineExceeded 120m",
+		"nightly-1-job-2 Running 60m",
+		"Contents: fake logs",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("describe output is missing %q:\n%s", want, out)
+		}
+	}
+
+	// The listing Job is cleaned up
+	var jobs batchv1.JobList
+	if err := p.Client.List(context.Background(), &jobs); err != nil {
+		t.Fatal(err)
+	}
+	for _, job := range jobs.Items {
+		if strings.HasPrefix(job.Name, "backup-contents-") {
+			t.Errorf("listing Job %s was not deleted", job.Name)
+		}
+	}
+
+	running := testBackup("nightly-2", "nightly", backupv1alpha1.BackupPhaseRunning, time.Minute)
+	p, _, _ = newTestPlugin(t, interceptor.Funcs{}, running)
+	if err := p.Run(context.Background(), []string{"describe", "nightly-2", "--contents"}); err == nil {
+		t.Error("expected an error listing a running backup")
+	}
+}
+
+func TestNewContentsJob(t *testing.T) {
+	backup := testBackup("nightly-1", "nightly", backupv1alpha1.BackupPhaseCompleted, time.Hour)
+	job := newContentsJob(backup, "busybox:latest", "", "backup-storage", time.Minute)
+
+	container := job.Spec.Template.Spec.Containers[0]
+	if strings.Join(container.Command, " ") != "sh -c tar -tzvf /backups/nightly-1.tar.gz" {
+		t.Errorf("command = %v", container.Command)
+	}
+	if !container.VolumeMounts[0].ReadOnly || container.VolumeMounts[0].MountPath != contentsMountPath {
+		t.Errorf("storage must be mounted read-only at %s: %+v", contentsMountPath, container.VolumeMounts[0])
+	}
+	if job.Spec.Template.Spec.SecurityContext == nil || container.SecurityContext == nil {
+		t.Error("the restricted mover security contexts should be applied to the listing Job")
+	}
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].UID != backup.UID {
+		t.Errorf("owner references = %+v, want the Backup", job.OwnerReferences)
+	}
+	if len(job.Spec.Template.Spec.InitContainers) != 0 {
+		t.Errorf("a gzip archive needs no mover binary: %+v", job.Spec.Template.Spec.InitContainers)
+	}
+
+	// zstd and lz4 archives are read by the mover binary of the operator image
+	zstd := backup.DeepCopy()
+	zstd.Spec.Compression = &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionZstd}
+	zstd.Status.BackupLocation = "/backups/nightly-1.tar.zst"
+	job = newContentsJob(zstd, "busybox:latest", "operator:v1", "backup-storage", time.Minute)
+	podSpec := job.Spec.Template.Spec
+	want := "set -o pipefail; /mover-tools/mover decompress -algorithm zstd < /backups/nightly-1.tar.zst | tar -tvf -"
+	if got := podSpec.Containers[0].Command[2]; got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+	if len(podSpec.InitContainers) != 1 || podSpec.InitContainers[0].Image != "operator:v1" ||
+		strings.Join(podSpec.InitContainers[0].Command, " ") != "/mover install /mover-tools/mover" {
+		t.Errorf("init containers = %+v, want one installing the mover binary", podSpec.InitContainers)
+	}
+	if image := moverToolsImage([]batchv1.Job{*job}); image != "operator:v1" {
+		t.Errorf("tools image found in the Jobs = %q, want operator:v1", image)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	backup := testBackup("nightly-1", "nightly", backupv1alpha1.BackupPhaseCompleted, 2*time.Hour)
+	restore := &backupv1alpha1.Restore{ObjectMeta: metav1.ObjectMeta{Name: "restore-1", Namespace: "default", UID: "restore-uid"}}
+	objs := []client.Object{
+		backup, restore,
+		moverJob("nightly-1-job", backup, "backup", 2*time.Hour),
+		moverJob("nightly-1-job-2", backup, "backup", time.Hour),
+		moverJob("nightly-1-verify", backup, "verify-command", 30*time.Minute),
+		moverJob("restore-1-job", restore, "restore", time.Minute),
+	}
+
+	tests := []struct {
+		args          []string
+		wantJob       string
+		wantContainer string
+	}{
+		{args: []string{"nightly-1"}, wantJob: "nightly-1-job-2", wantContainer: "backup"},
+		{args: []string{"backup/nightly-1", "--verify"}, wantJob: "nightly-1-verify", wantContainer: "verify-command"},
+		{args: []string{"restore/restore-1", "-f"}, wantJob: "restore-1-job", wantContainer: "restore"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			p, clientset, out := newTestPlugin(t, interceptor.Funcs{}, objs...)
+			for _, job := range []string{"nightly-1-job", "nightly-1-job-2", "nightly-1-verify", "restore-1-job"} {
+				if err := clientset.Tracker().Add(jobPod(job)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := p.Run(context.Background(), append([]string{"logs"}, tt.args...)); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != "fake logs" {
+				t.Errorf("output = %q", out)
+			}
+
+			var listed, container string
+			for _, action := range clientset.Actions() {
+				switch action := action.(type) {
+				case k8stesting.ListAction:
+					listed = action.GetListRestrictions().Labels.String()
+				case k8stesting.GenericAction:
+					container = action.GetValue().(*corev1.PodLogOptions).Container
+				}
+			}
+			if listed != batchv1.JobNameLabel+"="+tt.wantJob || container != tt.wantContainer {
+				t.Errorf("read %s logs of pods %s, want %s logs of Job %s", container, listed, tt.wantContainer, tt.wantJob)
+			}
+		})
+	}
+
+	p, _, _ := newTestPlugin(t, interceptor.Funcs{}, backup)
+	if err := p.Run(context.Background(), []string{"logs", "nightly-1"}); err == nil {
+		t.Error("expected an error for a backup without Jobs")
+	}
+	if err := p.Run(context.Background(), []string{"logs", "pvc/data"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("Run() error = %v, want a usage error", err)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	p, _, out := newTestPlugin(t, interceptor.Funcs{})
+	if err := p.Run(context.Background(), []string{"frobnicate"}); !errors.Is(err, ErrUsage) {
+		t.Errorf("Run() error = %v, want a usage error", err)
+	}
+	if !strings.Contains(out.String(), "Usage:") {
+		t.Errorf("usage not printed: %s", out)
+	}
+}
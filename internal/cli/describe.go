@@ -0,0 +1,367 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+// contentsMountPath is where the listing Job mounts the backup storage.
+// status.backupLocation is reported as a path below it.
+const contentsMountPath = "/backups"
+
+// describe prints a backup's status, conditions and Jobs, and with
+// --contents the files in its archive
+func (p *Plugin) describe(ctx context.Context, args []string) error {
+	defaults := config.Default()
+	flags, namespace := p.newFlagSet("describe")
+	contents := flags.Bool("contents", false, "list the files in the archive by running a short-lived Job")
+	image := flags.String("image", defaults.MoverImage, "image of the listing Job, must provide tar")
+	toolsImage := flags.String("tools-image", "", "operator image for zstd and lz4 archives, "+
+		"found in the backup's Jobs while they exist")
+	storagePVC := flags.String("storage-pvc", defaults.DefaultStoragePVC, "PVC holding the archives, if the backup has no storage location")
+	timeout := flags.Duration("timeout", 2*time.Minute, "how long to wait for the listing Job")
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs("describe", args, 1, "a BACKUP"); err != nil {
+		return err
+	}
+
+	var backup backupv1alpha1.Backup
+	if err := p.Client.Get(ctx, client.ObjectKey{Name: args[0], Namespace: *namespace}, &backup); err != nil {
+		return fmt.Errorf("fetching Backup %s: %w", args[0], err)
+	}
+	jobs, err := p.ownedJobs(ctx, &backup)
+	if err != nil {
+		return err
+	}
+	p.printBackup(&backup, jobs)
+
+	if !*contents {
+		return nil
+	}
+	if backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted || backup.Status.BackupLocation == "" {
+		return fmt.Errorf("backup %s has no archive to list yet", backup.Name)
+	}
+	// Archives of a storage location live on its PVC unless --storage-pvc says otherwise
+	storagePVCSet := false
+	flags.Visit(func(f *flag.Flag) { storagePVCSet = storagePVCSet || f.Name == "storage-pvc" })
+	if backup.Spec.StorageLocation != "" && !storagePVCSet {
+		var location backupv1alpha1.BackupStorageLocation
+		key := client.ObjectKey{Name: backup.Spec.StorageLocation, Namespace: backup.Namespace}
+		if err := p.Client.Get(ctx, key, &location); err != nil {
+			return fmt.Errorf("fetching BackupStorageLocation %s: %w", key.Name, err)
+		}
+		*storagePVC = location.Spec.PVCName
+	}
+	if archive.FormatOf(&backup).NeedsMoverTools() && *toolsImage == "" {
+		if *toolsImage = moverToolsImage(jobs); *toolsImage == "" {
+			return fmt.Errorf("backup %s is compressed with %s; pass --tools-image with the operator image to list it",
+				backup.Name, backup.Spec.Compression.Algorithm)
+		}
+	}
+	_, _ = fmt.Fprintln(p.Out, "\nContents:")
+	return p.listContents(ctx, &backup, *image, *toolsImage, *storagePVC, *timeout)
+}
+
+// printBackup writes the description of a backup
+func (p *Plugin) printBackup(backup *backupv1alpha1.Backup, jobs []batchv1.Job) {
+	status := &backup.Status
+	w := tabwriter.NewWriter(p.Out, 0, 0, 1, ' ', 0)
+	field := func(name, value string) {
+		_, _ = fmt.Fprintf(w, "%s:\t%s\n", name, value)
+	}
+
+	field("Name", backup.Name)
+	field("Namespace", backup.Namespace)
+	field("Policy", orNone(backup.Spec.PolicyRef))
+	if backup.Spec.Group != "" {
+		field("Group", backup.Spec.Group)
+	}
+	target := backup.Spec.Target.PVCName
+	if backup.Spec.Target.Namespace != "" {
+		target += " (namespace " + backup.Spec.Target.Namespace + ")"
+	}
+	field("Target", target)
+	if source := status.SourcePVC; source != nil {
+		size := "<unknown>"
+		if source.Size != nil {
+			size = source.Size.String()
+		}
+		modes := make([]string, 0, len(source.AccessModes))
+		for _, mode := range source.AccessModes {
+			modes = append(modes, string(mode))
+		}
+		field("Source PVC", fmt.Sprintf("%s, class %s, %s", size, orNone(source.StorageClassName), strings.Join(modes, ",")))
+	}
+	field("Phase", orNone(string(status.Phase)))
+	field("Started", p.timestamp(status.StartTime))
+	field("Completed", p.timestamp(status.CompletionTime))
+	field("Size", backupSize(backup))
+	field("Location", orNone(status.BackupLocation))
+	field("Attempts", fmt.Sprint(status.Attempts))
+	field("Expires", p.timestamp(status.ExpirationTime))
+	field("Hold", fmt.Sprint(backup.Spec.Hold || backup.Annotations[backupv1alpha1.HoldAnnotation] == "true"))
+	field("Last Verified", p.timestamp(status.LastVerificationTime))
+	if status.FailureReason != "" {
+		field("Failure", strings.ReplaceAll(status.FailureReason, "\n", "\n\t"))
+	}
+	_ = w.Flush()
+
+	_, _ = fmt.Fprintln(p.Out, "Conditions:")
+	if len(status.Conditions) == 0 {
+		_, _ = fmt.Fprintln(p.Out, "  <none>")
+	} else {
+		w = tabwriter.NewWriter(p.Out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tAGE\tMESSAGE")
+		for _, condition := range status.Conditions {
+			message, _, _ := strings.Cut(condition.Message, "\n")
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n",
+				condition.Type, condition.Status, condition.Reason, p.age(condition.LastTransitionTime), message)
+		}
+		_ = w.Flush()
+	}
+
+	if len(status.Replicas) > 0 {
+		_, _ = fmt.Fprintln(p.Out, "Replicas:")
+		w = tabwriter.NewWriter(p.Out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  LOCATION\tPHASE\tATTEMPTS\tMESSAGE")
+		for _, replica := range status.Replicas {
+			message, _, _ := strings.Cut(replica.Message, "\n")
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%d\t%s\n", replica.StorageLocation, replica.Phase, replica.Attempts, message)
+		}
+		_ = w.Flush()
+	}
+
+	_, _ = fmt.Fprintln(p.Out, "Jobs:")
+	if len(jobs) == 0 {
+		_, _ = fmt.Fprintln(p.Out, "  <none>")
+		return
+	}
+	w = tabwriter.NewWriter(p.Out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  NAME\tSTATUS\tAGE")
+	for _, job := range jobs {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\n", job.Name, jobStatus(&job), p.age(job.CreationTimestamp))
+	}
+	_ = w.Flush()
+}
+
+// timestamp formats an optional time with how long ago it was
+func (p *Plugin) timestamp(t *metav1.Time) string {
+	if t == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.UTC().Format(time.RFC3339), p.age(*t))
+}
+
+// ownedJobs returns the Jobs owned by obj, oldest first
+func (p *Plugin) ownedJobs(ctx context.Context, obj client.Object) ([]batchv1.Job, error) {
+	var jobs batchv1.JobList
+	if err := p.Client.List(ctx, &jobs, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("listing Jobs: %w", err)
+	}
+
+	var owned []batchv1.Job
+	for _, job := range jobs.Items {
+		for _, owner := range job.OwnerReferences {
+			if owner.UID == obj.GetUID() {
+				owned = append(owned, job)
+				break
+			}
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		return owned[i].CreationTimestamp.Before(&owned[j].CreationTimestamp)
+	})
+	return owned, nil
+}
+
+// jobStatus summarizes a Job as Complete, Failed or Running
+func jobStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return "Failed: " + condition.Reason
+		}
+	}
+	return "Running"
+}
+
+// listContents runs a Job that lists the backup's archive, waits for it,
+// prints its output and deletes it
+func (p *Plugin) listContents(ctx context.Context, backup *backupv1alpha1.Backup, image, toolsImage, storagePVC string,
+	timeout time.Duration) error {
+	job := newContentsJob(backup, image, toolsImage, storagePVC, timeout)
+	if err := p.Client.Create(ctx, job); err != nil {
+		return fmt.Errorf("creating listing Job: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so the Job is cleaned up after a timeout too
+		_ = p.Client.Delete(context.Background(), job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	}()
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	finished, err := p.waitForJob(waitCtx, job)
+	if err != nil {
+		return fmt.Errorf("waiting for listing Job %s: %w", job.Name, err)
+	}
+
+	if err := p.printJobLogs(ctx, job, "contents", false); err != nil {
+		return err
+	}
+	if finished != batchv1.JobComplete {
+		return fmt.Errorf("listing Job %s failed", job.Name)
+	}
+	return nil
+}
+
+// waitForJob polls a Job until it is complete or failed and returns which
+func (p *Plugin) waitForJob(ctx context.Context, job *batchv1.Job) (batchv1.JobConditionType, error) {
+	interval := p.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	for {
+		if err := p.Client.Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+			return "", err
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Status == corev1.ConditionTrue &&
+				(condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) {
+				return condition.Type, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-p.Clock.After(interval):
+		}
+	}
+}
+
+// contentsCommand lists the archive of a backup in the format it was written in
+func contentsCommand(backup *backupv1alpha1.Backup) []string {
+	return []string{"sh", "-c", archive.FormatOf(backup).ListCommand(backup.Status.BackupLocation, true)}
+}
+
+// moverToolsImage returns the image the operator copied the mover binary
+// from into one of the Jobs, or "" if none did
+func moverToolsImage(jobs []batchv1.Job) string {
+	for _, job := range jobs {
+		for _, container := range job.Spec.Template.Spec.InitContainers {
+			if container.Name == archive.ToolsVolume {
+				return container.Image
+			}
+		}
+	}
+	return ""
+}
+
+// newContentsJob builds the Job that lists an archive. It runs with the
+// restricted mover pod settings, mounts the storage read-only and is
+// owned by the Backup so it never outlives it. With a tools image an init
+// container first copies the mover binary from it.
+func newContentsJob(backup *backupv1alpha1.Backup, image, toolsImage, storagePVC string, timeout time.Duration) *batchv1.Job {
+	template := config.RestrictedMoverJobTemplate()
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "backup-contents-",
+			Namespace:    backup.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: backupv1alpha1.GroupVersion.String(),
+				Kind:       "Backup",
+				Name:       backup.Name,
+				UID:        backup.UID,
+			}},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To[int32](0),
+			ActiveDeadlineSeconds:   ptr.To(max(int64(timeout.Seconds()), 1)),
+			TTLSecondsAfterFinished: ptr.To[int32](300),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:   corev1.RestartPolicyNever,
+					SecurityContext: template.PodSecurityContext,
+					Containers: []corev1.Container{{
+						Name:            "contents",
+						Image:           image,
+						Command:         contentsCommand(backup),
+						SecurityContext: template.SecurityContext,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "backup-storage",
+							MountPath: contentsMountPath,
+							ReadOnly:  true,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "backup-storage",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: storagePVC,
+								ReadOnly:  true,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	if toolsImage != "" {
+		podSpec := &job.Spec.Template.Spec
+		// Archives compressed with zstd or lz4 are read by the operator's mover
+		// binary, which an init container copies from the operator image
+		mount := corev1.VolumeMount{Name: archive.ToolsVolume, MountPath: archive.ToolsDir}
+		podSpec.InitContainers = []corev1.Container{{
+			Name:            archive.ToolsVolume,
+			Image:           toolsImage,
+			Command:         []string{"/mover", "install", archive.Tool},
+			SecurityContext: template.SecurityContext,
+			VolumeMounts:    []corev1.VolumeMount{mount},
+		}}
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, mount)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         archive.ToolsVolume,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+	return job
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// list prints backups grouped by policy, newest first within each policy
+func (p *Plugin) list(ctx context.Context, args []string) error {
+	flags, namespace := p.newFlagSet("list")
+	allNamespaces := flags.Bool("A", false, "list backups in all namespaces")
+	flags.BoolVar(allNamespaces, "all-namespaces", false, "list backups in all namespaces")
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("%w: kubectl backup list expects at most one POLICY", ErrUsage)
+	}
+
+	var opts []client.ListOption
+	if !*allNamespaces {
+		opts = append(opts, client.InNamespace(*namespace))
+	}
+	var backups backupv1alpha1.BackupList
+	if err := p.Client.List(ctx, &backups, opts...); err != nil {
+		return fmt.Errorf("listing backups: %w", err)
+	}
+
+	items := backups.Items[:0]
+	for _, backup := range backups.Items {
+		if len(args) == 0 || backup.Spec.PolicyRef == args[0] {
+			items = append(items, backup)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Spec.PolicyRef != b.Spec.PolicyRef {
+			return a.Spec.PolicyRef < b.Spec.PolicyRef
+		}
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return b.CreationTimestamp.Before(&a.CreationTimestamp)
+		}
+		return a.Name < b.Name
+	})
+
+	if len(items) == 0 {
+		_, err := fmt.Fprintln(p.Out, "No backups found.")
+		return err
+	}
+
+	w := tabwriter.NewWriter(p.Out, 0, 0, 3, ' ', 0)
+	if *allNamespaces {
+		_, _ = fmt.Fprint(w, "NAMESPACE\t")
+	}
+	_, _ = fmt.Fprintln(w, "POLICY\tNAME\tPHASE\tSIZE\tAGE")
+	for _, backup := range items {
+		if *allNamespaces {
+			_, _ = fmt.Fprintf(w, "%s\t", backup.Namespace)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			orNone(backup.Spec.PolicyRef),
+			backup.Name,
+			orNone(string(backup.Status.Phase)),
+			backupSize(&backup),
+			p.age(backup.CreationTimestamp),
+		)
+	}
+	return w.Flush()
+}
+
+// backupSize formats the archive size of a Backup, or "-" while it is unknown
+func backupSize(backup *backupv1alpha1.Backup) string {
+	if backup.Status.Size == nil {
+		return "-"
+	}
+	return backup.Status.Size.String()
+}
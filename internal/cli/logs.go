@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// Main container of each kind of mover Job, used to tell the Jobs of a
+// Backup apart and to pick the container whose logs are shown
+const (
+	backupContainer  = "backup"
+	restoreContainer = "restore"
+	verifyContainer  = "verify-command"
+)
+
+// logs prints the logs of the latest mover Job of a Backup or Restore
+func (p *Plugin) logs(ctx context.Context, args []string) error {
+	flags, namespace := p.newFlagSet("logs")
+	follow := flags.Bool("f", false, "stream the logs while the Job runs")
+	flags.BoolVar(follow, "follow", false, "stream the logs while the Job runs")
+	verify := flags.Bool("verify", false, "show the latest verification Job of a Backup instead")
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs("logs", args, 1, "a BACKUP or restore/NAME"); err != nil {
+		return err
+	}
+
+	kind, name, found := strings.Cut(args[0], "/")
+	if !found {
+		kind, name = "backup", args[0]
+	}
+
+	var owner client.Object
+	var container string
+	switch strings.ToLower(kind) {
+	case "backup", "backups":
+		owner, container = &backupv1alpha1.Backup{}, backupContainer
+		if *verify {
+			container = verifyContainer
+		}
+	case "restore", "restores":
+		owner, container = &backupv1alpha1.Restore{}, restoreContainer
+	default:
+		return fmt.Errorf("%w: %q is not a backup or a restore", ErrUsage, args[0])
+	}
+	if err := p.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: *namespace}, owner); err != nil {
+		return fmt.Errorf("fetching %s %s: %w", kind, name, err)
+	}
+
+	jobs, err := p.ownedJobs(ctx, owner)
+	if err != nil {
+		return err
+	}
+	// The newest Job running the container is the latest attempt
+	for i := len(jobs) - 1; i >= 0; i-- {
+		if hasContainer(&jobs[i], container) {
+			return p.printJobLogs(ctx, &jobs[i], container, *follow)
+		}
+	}
+	return fmt.Errorf("%s %s has no %s Job; finished Jobs are removed after the operator's finishedJobTTL", kind, name, container)
+}
+
+// hasContainer reports whether a Job's pods run a container with the given name
+func hasContainer(job *batchv1.Job, name string) bool {
+	for _, container := range job.Spec.Template.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// printJobLogs copies the logs of a container of the Job's newest pod to the output
+func (p *Plugin) printJobLogs(ctx context.Context, job *batchv1.Job, container string, follow bool) error {
+	pods, err := p.Pods.Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: batchv1.JobNameLabel + "=" + job.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("listing pods of Job %s: %w", job.Name, err)
+	}
+	var newest *corev1.Pod
+	for i := range pods.Items {
+		if newest == nil || newest.CreationTimestamp.Before(&pods.Items[i].CreationTimestamp) {
+			newest = &pods.Items[i]
+		}
+	}
+	if newest == nil {
+		return fmt.Errorf("job %s has no pods", job.Name)
+	}
+
+	stream, err := p.Pods.Pods(job.Namespace).GetLogs(newest.Name, &corev1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("reading logs of pod %s: %w", newest.Name, err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	_, err = io.Copy(p.Out, stream)
+	return err
+}
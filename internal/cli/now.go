@@ -0,0 +1,58 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// now asks the operator for an immediate backup of a policy by setting a new
+// trigger-now token, the same as annotating the policy by hand
+func (p *Plugin) now(ctx context.Context, args []string) error {
+	flags, namespace := p.newFlagSet("now")
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs("now", args, 1, "a POLICY"); err != nil {
+		return err
+	}
+
+	var backupPolicy backupv1alpha1.BackupPolicy
+	if err := p.Client.Get(ctx, client.ObjectKey{Name: args[0], Namespace: *namespace}, &backupPolicy); err != nil {
+		return fmt.Errorf("fetching BackupPolicy %s: %w", args[0], err)
+	}
+
+	// Nanoseconds keep two requests in the same second apart
+	token := strconv.FormatInt(p.Clock.Now().UnixNano(), 10)
+	patch := client.MergeFrom(backupPolicy.DeepCopy())
+	metav1.SetMetaDataAnnotation(&backupPolicy.ObjectMeta, backupv1alpha1.TriggerNowAnnotation, token)
+	if err := p.Client.Patch(ctx, &backupPolicy, patch); err != nil {
+		return fmt.Errorf("requesting backup of %s: %w", backupPolicy.Name, err)
+	}
+
+	_, err = fmt.Fprintf(p.Out, "Requested a backup of policy %s (%s=%s)\n",
+		backupPolicy.Name, backupv1alpha1.TriggerNowAnnotation, token)
+	return err
+}
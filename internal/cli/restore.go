@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// restore creates a Restore from a named backup, or from the latest completed
+// backup of a policy taken at or before a point in time
+func (p *Plugin) restore(ctx context.Context, args []string) error {
+	flags, namespace := p.newFlagSet("restore")
+	policy := flags.String("policy", "", "restore the latest completed backup of this policy")
+	at := flags.String("at", "", "with --policy, restore the latest backup completed at or before this "+
+		"RFC3339 time, or this long ago (e.g. 6h)")
+	targetPVC := flags.String("to", "", "PVC to restore into (required)")
+	targetNamespace := flags.String("target-namespace", "", "namespace of the target PVC (default: the Restore's namespace)")
+	name := flags.String("name", "", "name of the Restore (default: generated from the backup name)")
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if *targetPVC == "" {
+		return fmt.Errorf("%w: --to is required", ErrUsage)
+	}
+	switch {
+	case len(args) == 1 && *policy == "" && *at == "":
+	case len(args) == 0 && *policy != "":
+	default:
+		return fmt.Errorf("%w: kubectl backup restore expects either a BACKUP or --policy", ErrUsage)
+	}
+
+	var backup *backupv1alpha1.Backup
+	if *policy == "" {
+		backup = &backupv1alpha1.Backup{}
+		if err := p.Client.Get(ctx, client.ObjectKey{Name: args[0], Namespace: *namespace}, backup); err != nil {
+			return fmt.Errorf("fetching Backup %s: %w", args[0], err)
+		}
+		if backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
+			return fmt.Errorf("backup %s is %s, only completed backups can be restored", backup.Name, orNone(string(backup.Status.Phase)))
+		}
+	} else {
+		pointInTime := p.Clock.Now()
+		if *at != "" {
+			if pointInTime, err = p.parseTime(*at); err != nil {
+				return err
+			}
+		}
+		if backup, err = p.latestBackup(ctx, *namespace, *policy, pointInTime); err != nil {
+			return err
+		}
+	}
+
+	restore := &backupv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      *name,
+			Namespace: *namespace,
+		},
+		Spec: backupv1alpha1.RestoreSpec{
+			BackupName:      backup.Name,
+			TargetPVC:       *targetPVC,
+			TargetNamespace: *targetNamespace,
+			BackupNamespace: backup.Namespace,
+		},
+	}
+	if restore.Name == "" {
+		restore.GenerateName = backup.Name + "-restore-"
+	}
+	if err := p.Client.Create(ctx, restore); err != nil {
+		return fmt.Errorf("creating Restore: %w", err)
+	}
+
+	_, err = fmt.Fprintf(p.Out, "Restore %s created from backup %s (completed %s ago) into PVC %s\n",
+		restore.Name, backup.Name, p.age(completedAt(backup)), *targetPVC)
+	return err
+}
+
+// latestBackup returns the newest completed backup of a policy that completed
+// at or before the given time
+func (p *Plugin) latestBackup(ctx context.Context, namespace, policy string, at time.Time) (*backupv1alpha1.Backup, error) {
+	var backups backupv1alpha1.BackupList
+	if err := p.Client.List(ctx, &backups, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing backups: %w", err)
+	}
+
+	var latest *backupv1alpha1.Backup
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		if backup.Spec.PolicyRef != policy || backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
+			continue
+		}
+		completed := completedAt(backup)
+		if completed.After(at) {
+			continue
+		}
+		if latest == nil || completed.After(completedAt(latest).Time) {
+			latest = backup
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("policy %s has no completed backup at or before %s", policy, at.UTC().Format(time.RFC3339))
+	}
+	return latest, nil
+}
+
+// completedAt is when a backup completed, falling back to its creation for
+// backups without a completion time
+func completedAt(backup *backupv1alpha1.Backup) metav1.Time {
+	if backup.Status.CompletionTime != nil {
+		return *backup.Status.CompletionTime
+	}
+	return backup.CreationTimestamp
+}
+
+// parseTime accepts an RFC3339 time or a duration meaning that long ago
+func (p *Plugin) parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	ago, err := time.ParseDuration(value)
+	if err != nil || ago < 0 {
+		return time.Time{}, fmt.Errorf("%w: --at %q is neither an RFC3339 time nor a positive duration", ErrUsage, value)
+	}
+	return p.Clock.Now().Add(-ago), nil
+}
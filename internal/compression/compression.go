@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compression implements the compression algorithms of backup
+// archives in Go, so mover pods do not depend on the tools of their image.
+// gzip, zstd and lz4 archives are compatible with the gzip, zstd and lz4 commands.
+package compression
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// NewWriter returns a writer compressing into w. Level 0 selects the
+// algorithm's default. Closing the writer does not close w.
+func NewWriter(w io.Writer, algorithm backupv1alpha1.CompressionAlgorithm, level int) (io.WriteCloser, error) {
+	switch algorithm {
+	case backupv1alpha1.CompressionNone:
+		return nopWriteCloser{w}, nil
+	case backupv1alpha1.CompressionGzip:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(w, level)
+	case backupv1alpha1.CompressionZstd:
+		if level == 0 {
+			level = 3
+		}
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	case backupv1alpha1.CompressionLZ4:
+		writer := lz4.NewWriter(w)
+		if err := writer.Apply(
+			lz4.BlockSizeOption(lz4.Block4Mb),
+			lz4.ChecksumOption(true),
+			lz4.CompressionLevelOption(lz4Level(level)),
+		); err != nil {
+			return nil, err
+		}
+		return writer, nil
+	}
+	return nil, fmt.Errorf("unknown compression %q", algorithm)
+}
+
+// NewReader returns a reader decompressing r. Closing it does not close r.
+func NewReader(r io.Reader, algorithm backupv1alpha1.CompressionAlgorithm) (io.ReadCloser, error) {
+	switch algorithm {
+	case backupv1alpha1.CompressionNone:
+		return io.NopCloser(r), nil
+	case backupv1alpha1.CompressionGzip:
+		return gzip.NewReader(r)
+	case backupv1alpha1.CompressionZstd:
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	case backupv1alpha1.CompressionLZ4:
+		return newLZ4Reader(r), nil
+	}
+	return nil, fmt.Errorf("unknown compression %q", algorithm)
+}
+
+// nopWriteCloser adds a no-op Close to an io.Writer
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
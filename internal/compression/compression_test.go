@@ -0,0 +1,303 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compression
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"os/exec"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// algorithms are all the compressions of backup archives
+var algorithms = []backupv1alpha1.CompressionAlgorithm{
+	backupv1alpha1.CompressionNone,
+	backupv1alpha1.CompressionGzip,
+	backupv1alpha1.CompressionZstd,
+	backupv1alpha1.CompressionLZ4,
+}
+
+// testInputs are inputs that exercise the edge cases of the block formats
+func testInputs() map[string][]byte {
+	random := rand.New(rand.NewSource(1))
+	incompressible := make([]byte, 300<<10)
+	random.Read(incompressible)
+
+	return map[string][]byte{
+		"empty":          {},
+		"one byte":       {'x'},
+		"short":          []byte("hello, world"),
+		"repeated byte":  bytes.Repeat([]byte{'a'}, 100<<10),
+		"text":           textArchive(random, 64<<10),
+		"incompressible": incompressible,
+		// Longer than an LZ4 block, so the frame holds several
+		"several blocks": textArchive(random, 9<<20),
+	}
+}
+
+// textArchive returns n bytes of random words from a small vocabulary
+func textArchive(random *rand.Rand, n int) []byte {
+	words := strings.Fields("the backup operator writes each volume to an archive in its storage location " +
+		"users orders invoices 2026-10-18T03:00:00Z id name email status pending completed failed")
+	var content bytes.Buffer
+	for content.Len() < n {
+		content.WriteString(words[random.Intn(len(words))])
+		content.WriteByte(' ')
+	}
+	return content.Bytes()[:n]
+}
+
+func compress(t testing.TB, algorithm backupv1alpha1.CompressionAlgorithm, level int, input []byte) []byte {
+	t.Helper()
+	var compressed bytes.Buffer
+	writer, err := NewWriter(&compressed, algorithm, level)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writer.Write(input); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return compressed.Bytes()
+}
+
+func decompress(algorithm backupv1alpha1.CompressionAlgorithm, input []byte) ([]byte, error) {
+	reader, err := NewReader(iotest.HalfReader(bytes.NewReader(input)), algorithm)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return io.ReadAll(reader)
+}
+
+func TestRoundTrip(t *testing.T) {
+	levels := map[backupv1alpha1.CompressionAlgorithm][]int{
+		backupv1alpha1.CompressionNone: {0},
+		backupv1alpha1.CompressionGzip: {0, 1, 9},
+		backupv1alpha1.CompressionZstd: {0, 1, 19},
+		backupv1alpha1.CompressionLZ4:  {0, 1, 3, 12},
+	}
+	for name, input := range testInputs() {
+		for _, algorithm := range algorithms {
+			for _, level := range levels[algorithm] {
+				t.Run(fmt.Sprintf("%s/%s-%d", name, algorithm, level), func(t *testing.T) {
+					compressed := compress(t, algorithm, level, input)
+					output, err := decompress(algorithm, compressed)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !bytes.Equal(output, input) {
+						t.Fatalf("round trip returned %d bytes that differ from the %d written", len(output), len(input))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestLZ4Levels(t *testing.T) {
+	input := textArchive(rand.New(rand.NewSource(1)), 1<<20)
+	fast := len(compress(t, backupv1alpha1.CompressionLZ4, 1, input))
+	high := len(compress(t, backupv1alpha1.CompressionLZ4, 9, input))
+	if high >= fast {
+		t.Errorf("level 9 wrote %d bytes, want fewer than the %d of level 1", high, fast)
+	}
+	if fast >= len(input)/2 {
+		t.Errorf("level 1 wrote %d bytes of %d, want text to compress to less than half", fast, len(input))
+	}
+}
+
+func TestLZ4Corrupt(t *testing.T) {
+	valid := compress(t, backupv1alpha1.CompressionLZ4, 1, textArchive(rand.New(rand.NewSource(1)), 64<<10))
+
+	flipped := bytes.Clone(valid)
+	flipped[len(flipped)/2] ^= 0xFF
+	badHeader := bytes.Clone(valid)
+	badHeader[6] ^= 0xFF
+
+	tests := map[string][]byte{
+		"not lz4":           []byte("plain text, not a frame"),
+		"bad header":        badHeader,
+		"truncated":         valid[:len(valid)-10],
+		"no end mark":       valid[:len(valid)-8],
+		"corrupt block":     flipped,
+		"dictionary frames": {0x04, 0x22, 0x4D, 0x18, 0x61, 0x70, 0x00},
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decompress(backupv1alpha1.CompressionLZ4, input); err == nil {
+				t.Error("corrupt input decompressed without an error")
+			}
+		})
+	}
+}
+
+// TestCommandCompatibility checks that archives can be read and written by the
+// zstd and lz4 commands, so existing archives and users' tools keep working.
+// It is skipped where the commands are not installed.
+func TestCommandCompatibility(t *testing.T) {
+	input := textArchive(rand.New(rand.NewSource(1)), 5<<20)
+	commands := map[backupv1alpha1.CompressionAlgorithm]string{
+		backupv1alpha1.CompressionGzip: "gzip",
+		backupv1alpha1.CompressionZstd: "zstd",
+		backupv1alpha1.CompressionLZ4:  "lz4",
+	}
+	for algorithm, command := range commands {
+		t.Run(command, func(t *testing.T) {
+			if _, err := exec.LookPath(command); err != nil {
+				t.Skipf("%s is not installed", command)
+			}
+
+			decompressed := run(t, bytes.NewReader(compress(t, algorithm, 0, input)), command, "-dc")
+			if !bytes.Equal(decompressed, input) {
+				t.Errorf("%s -dc returned %d bytes that differ from the %d written", command, len(decompressed), len(input))
+			}
+
+			variants := [][]string{{"-c"}, {"-c", "-9"}}
+			if command == "lz4" {
+				// Frame options this package does not write but must read
+				variants = append(variants, []string{"-c", "-B4", "-BD", "-BX", "--content-size"})
+			}
+			for _, args := range variants {
+				compressed := run(t, bytes.NewReader(input), command, args...)
+				output, err := decompress(algorithm, compressed)
+				if err != nil {
+					t.Fatalf("reading the output of %s %v: %v", command, args, err)
+				}
+				if !bytes.Equal(output, input) {
+					t.Errorf("reading the output of %s %v returned %d bytes that differ from the %d written",
+						command, args, len(output), len(input))
+				}
+			}
+		})
+	}
+}
+
+// run runs a command with stdin and returns its stdout
+func run(t *testing.T, stdin io.Reader, command string, args ...string) []byte {
+	t.Helper()
+	cmd := exec.Command(command, args...)
+	cmd.Stdin = stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("%s %v: %v: %s", command, args, err, stderr.String())
+	}
+	return output
+}
+
+// benchmarkArchive is a tar stream of files that compress about as well as
+// typical application data: random words from a small vocabulary
+func benchmarkArchive(b *testing.B) []byte {
+	b.Helper()
+	random := rand.New(rand.NewSource(1))
+
+	var archive bytes.Buffer
+	writer := tar.NewWriter(&archive)
+	for i := range 16 {
+		content := textArchive(random, 512<<10)
+		header := &tar.Header{Name: fmt.Sprintf("data/file-%d", i), Mode: 0o644, Size: int64(len(content))}
+		if err := writer.WriteHeader(header); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := writer.Write(content); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		b.Fatal(err)
+	}
+	return archive.Bytes()
+}
+
+// BenchmarkCompression compares the throughput of the algorithms at their
+// lowest, default and highest levels on the same tar stream and reports the
+// compressed size as a ratio, e.g. go test -bench Compression ./internal/compression
+func BenchmarkCompression(b *testing.B) {
+	archive := benchmarkArchive(b)
+	cases := []struct {
+		algorithm backupv1alpha1.CompressionAlgorithm
+		levels    []int
+	}{
+		{algorithm: backupv1alpha1.CompressionNone, levels: []int{0}},
+		{algorithm: backupv1alpha1.CompressionGzip, levels: []int{1, 6, 9}},
+		{algorithm: backupv1alpha1.CompressionZstd, levels: []int{1, 3, 19}},
+		{algorithm: backupv1alpha1.CompressionLZ4, levels: []int{1, 9, 12}},
+	}
+	for _, c := range cases {
+		for _, level := range c.levels {
+			b.Run(fmt.Sprintf("%s-%d", c.algorithm, level), func(b *testing.B) {
+				b.SetBytes(int64(len(archive)))
+				var written countingWriter
+				for b.Loop() {
+					written = 0
+					writer, err := NewWriter(&written, c.algorithm, level)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if _, err := writer.Write(archive); err != nil {
+						b.Fatal(err)
+					}
+					if err := writer.Close(); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(written)/float64(len(archive)), "ratio")
+			})
+		}
+	}
+}
+
+// BenchmarkDecompression compares how fast archives written at the default
+// level are read back
+func BenchmarkDecompression(b *testing.B) {
+	archive := benchmarkArchive(b)
+	for _, algorithm := range algorithms {
+		b.Run(string(algorithm), func(b *testing.B) {
+			compressed := compress(b, algorithm, 0, archive)
+			b.SetBytes(int64(len(archive)))
+			for b.Loop() {
+				reader, err := NewReader(bytes.NewReader(compressed), algorithm)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := io.Copy(io.Discard, reader); err != nil {
+					b.Fatal(err)
+				}
+				_ = reader.Close()
+			}
+		})
+	}
+}
+
+// countingWriter discards what it is given and counts the bytes
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
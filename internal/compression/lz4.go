@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compression
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// lz4Level maps the levels of the lz4 command to the library's: 1 and 2 are
+// its fast mode, 3 to 12 its high compression depths, of which it has 9
+func lz4Level(level int) lz4.CompressionLevel {
+	if level < 3 {
+		return lz4.Fast
+	}
+	return lz4.Level1 << min(level-3, 8)
+}
+
+// lz4ContentChecksum is the FLG bit of frames ending with a content checksum,
+// https://github.com/lz4/lz4/blob/dev/doc/lz4_Frame_format.md
+const lz4ContentChecksum = 1 << 2
+
+// lz4EndMark ends the blocks of a frame
+var lz4EndMark = []byte{0, 0, 0, 0}
+
+// lz4Reader makes a frame cut off between two blocks an error. The library
+// reads the end of its input there as the end of the frame, which would
+// restore a truncated archive without complaint.
+type lz4Reader struct {
+	*lz4.Reader
+	source *edgeReader
+}
+
+func newLZ4Reader(r io.Reader) *lz4Reader {
+	source := &edgeReader{r: r}
+	return &lz4Reader{Reader: lz4.NewReader(source), source: source}
+}
+
+func (z *lz4Reader) Read(p []byte) (int, error) {
+	n, err := z.Reader.Read(p)
+	if err == io.EOF && !z.source.endsFrame() {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (z *lz4Reader) Close() error {
+	return nil
+}
+
+// edgeReader remembers the first and last bytes read through it
+type edgeReader struct {
+	r    io.Reader
+	head []byte
+	tail []byte
+}
+
+// edgeSize covers the magic number and FLG byte at the start of a frame and
+// the end mark and content checksum at its end
+const edgeSize = 8
+
+func (e *edgeReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if missing := edgeSize - len(e.head); missing > 0 {
+		e.head = append(e.head, p[:min(n, missing)]...)
+	}
+	e.tail = append(e.tail, p[:n]...)
+	if len(e.tail) > edgeSize {
+		e.tail = append(e.tail[:0], e.tail[len(e.tail)-edgeSize:]...)
+	}
+	return n, err
+}
+
+// endsFrame reports whether what was read ends with the end mark, followed by
+// the content checksum if the frame has one
+func (e *edgeReader) endsFrame() bool {
+	if len(e.head) < 5 {
+		return false
+	}
+	trailer := len(lz4EndMark)
+	if e.head[4]&lz4ContentChecksum != 0 {
+		trailer += 4
+	}
+	if len(e.tail) < trailer {
+		return false
+	}
+	return bytes.Equal(e.tail[len(e.tail)-trailer:len(e.tail)-trailer+len(lz4EndMark)], lz4EndMark)
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config contains the operator-level configuration: defaults that
+// apply to every BackupPolicy, Backup and Restore unless the object overrides them.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// Controller names accepted as MaxConcurrentReconciles keys
+const (
+	BackupPolicyController = "backuppolicy"
+	BackupController       = "backup"
+	RestoreController      = "restore"
+)
+
+// defaultFailureLogTailLines is how many log lines of a failed mover container are recorded by default
+const defaultFailureLogTailLines int64 = 20
+
+// defaultProgressInterval is how often running movers report their progress by default
+const defaultProgressInterval = 30 * time.Second
+
+// defaultFinishedJobTTL is how long finished mover Jobs are kept by default
+const defaultFinishedJobTTL = 24 * time.Hour
+
+// defaultMoverSlotRetryInterval is how often a backup waiting for a mover slot checks again by default
+const defaultMoverSlotRetryInterval = 15 * time.Second
+
+// moverUserID is the unprivileged user mover containers run as with restrictedMovers
+const moverUserID int64 = 65532
+
+// OperatorConfig is the typed operator configuration loaded from the --config file
+type OperatorConfig struct {
+	// MoverImage runs the backup, restore and verification containers
+	MoverImage string `json:"moverImage,omitempty"`
+
+	// DefaultStoragePVC is the PVC backup archives are written to and read from
+	DefaultStoragePVC string `json:"defaultStoragePVC,omitempty"`
+
+	// DefaultJobTemplate holds the mover pod settings that per-object job templates override
+	DefaultJobTemplate *backupv1alpha1.MoverJobTemplate `json:"defaultJobTemplate,omitempty"`
+
+	// RestrictedMovers runs mover pods with the security contexts of
+	// RestrictedMoverJobTemplate, so they are admitted in namespaces enforcing
+	// the restricted Pod Security Standard. Security contexts set in
+	// defaultJobTemplate take precedence. Off by default: restricted movers run
+	// as UID 65532, cannot read files only their owner may read, and restore
+	// files owned by that UID.
+	RestrictedMovers bool `json:"restrictedMovers,omitempty"`
+
+	// FailureLogTailLines is how many log lines of a failed mover container are
+	// copied into the Backup or Restore status. 0 disables copying logs; the
+	// termination message and exit code are always recorded. Defaults to 20.
+	FailureLogTailLines *int64 `json:"failureLogTailLines,omitempty"`
+
+	// ProgressInterval is how often a running mover reports its progress, which
+	// is copied into status.progress. 0 disables progress reporting.
+	// Defaults to 30s.
+	ProgressInterval *metav1.Duration `json:"progressInterval,omitempty"`
+
+	// FinishedJobTTL is how long finished mover Jobs and their pods are kept before
+	// Kubernetes garbage collects them. 0 keeps them. Defaults to 24h.
+	FinishedJobTTL *metav1.Duration `json:"finishedJobTTL,omitempty"`
+
+	// MaxConcurrentReconciles per controller, keyed by controller name
+	// (backuppolicy, backup, restore). Only read at startup.
+	MaxConcurrentReconciles map[string]int `json:"maxConcurrentReconciles,omitempty"`
+
+	// DefaultRetention applies to BackupPolicies that do not set retention
+	DefaultRetention *backupv1alpha1.RetentionPolicy `json:"defaultRetention,omitempty"`
+
+	// MaxConcurrentMovers caps the backup and restore Jobs running at once.
+	// Backups wait for a free slot before their Job is created; restores and
+	// members of consistency groups are never held back, but count against the caps.
+	MaxConcurrentMovers *MoverLimits `json:"maxConcurrentMovers,omitempty"`
+
+	// BackupQueue caps the Backups running at once; further Backups wait in
+	// the Queued phase. Running Restores count against the caps but are never
+	// queued, and preempt running Backups to stay within them.
+	BackupQueue *QueueLimits `json:"backupQueue,omitempty"`
+}
+
+// QueueLimits caps running Backups and Restores. 0 means unlimited.
+type QueueLimits struct {
+	// MaxRunning caps them cluster-wide
+	MaxRunning int `json:"maxRunning,omitempty"`
+
+	// MaxRunningPerNamespace caps them in each namespace
+	MaxRunningPerNamespace int `json:"maxRunningPerNamespace,omitempty"`
+
+	// PollInterval also checks queued Backups for a free slot on a timer, e.g.
+	// to admit them soon after the caps are raised. Backups and Restores
+	// finishing admit queued Backups without it. 0 or unset disables it.
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// MoverLimits caps concurrently running mover Jobs. 0 means unlimited.
+type MoverLimits struct {
+	// PerNode caps the movers on the node a backup's mover will run on: the
+	// node of the running pods using its PVC. Backups of PVCs no running pod
+	// uses are not held back by it.
+	PerNode int `json:"perNode,omitempty"`
+
+	// PerStorageLocation caps the movers reading or writing one storage PVC
+	PerStorageLocation int `json:"perStorageLocation,omitempty"`
+
+	// RetryInterval is how often a backup waiting for a mover slot checks
+	// again. Defaults to 15s.
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+}
+
+// Default returns the built-in configuration used when no --config file is given
+func Default() *OperatorConfig {
+	return &OperatorConfig{
+		MoverImage:        "busybox:latest",
+		DefaultStoragePVC: "backup-storage",
+	}
+}
+
+// RestrictedMoverJobTemplate returns mover pod settings that satisfy the
+// restricted Pod Security Standard. They are applied with restrictedMovers;
+// by default movers keep the image's user so they can read every file on the
+// source PVC and restore ownership unchanged.
+func RestrictedMoverJobTemplate() *backupv1alpha1.MoverJobTemplate {
+	return &backupv1alpha1.MoverJobTemplate{
+		PodSecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot:        ptr.To(true),
+			RunAsUser:           ptr.To(moverUserID),
+			RunAsGroup:          ptr.To(moverUserID),
+			FSGroup:             ptr.To(moverUserID),
+			FSGroupChangePolicy: ptr.To(corev1.FSGroupChangeOnRootMismatch),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			RunAsNonRoot:             ptr.To(true),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+	}
+}
+
+// FailureLogLines returns how many log lines of a failed mover container to record
+func (c *OperatorConfig) FailureLogLines() int64 {
+	if c.FailureLogTailLines == nil {
+		return defaultFailureLogTailLines
+	}
+	return *c.FailureLogTailLines
+}
+
+// ProgressSampleInterval returns how often movers report their progress, 0 if never
+func (c *OperatorConfig) ProgressSampleInterval() time.Duration {
+	if c.ProgressInterval == nil {
+		return defaultProgressInterval
+	}
+	return c.ProgressInterval.Duration
+}
+
+// JobTTL returns how long finished mover Jobs are kept, 0 if until deleted otherwise
+func (c *OperatorConfig) JobTTL() time.Duration {
+	if c.FinishedJobTTL == nil {
+		return defaultFinishedJobTTL
+	}
+	return c.FinishedJobTTL.Duration
+}
+
+// MoverSlotRetryInterval returns how often a backup waiting for a mover slot checks again
+func (c *OperatorConfig) MoverSlotRetryInterval() time.Duration {
+	if c.MaxConcurrentMovers == nil || c.MaxConcurrentMovers.RetryInterval == nil {
+		return defaultMoverSlotRetryInterval
+	}
+	return c.MaxConcurrentMovers.RetryInterval.Duration
+}
+
+// QueuePollInterval returns how often queued Backups check for a free slot, 0 if only on changes
+func (c *OperatorConfig) QueuePollInterval() time.Duration {
+	if c.BackupQueue == nil || c.BackupQueue.PollInterval == nil {
+		return 0
+	}
+	return c.BackupQueue.PollInterval.Duration
+}
+
+// Load reads a configuration file, fills unset fields from Default and validates the result
+func Load(path string) (*OperatorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes a YAML or JSON configuration, rejecting unknown fields so typos
+// are caught, fills unset fields from Default and validates the result
+func Parse(data []byte) (*OperatorConfig, error) {
+	cfg := &OperatorConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+
+	cfg.applyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyDefaults fills every unset field from Default
+func (c *OperatorConfig) applyDefaults() {
+	defaults := Default()
+	if c.MoverImage == "" {
+		c.MoverImage = defaults.MoverImage
+	}
+	if c.DefaultStoragePVC == "" {
+		c.DefaultStoragePVC = defaults.DefaultStoragePVC
+	}
+	if c.DefaultJobTemplate == nil {
+		c.DefaultJobTemplate = defaults.DefaultJobTemplate
+	}
+	if c.RestrictedMovers {
+		restricted := RestrictedMoverJobTemplate()
+		if c.DefaultJobTemplate == nil {
+			c.DefaultJobTemplate = restricted
+		}
+		if c.DefaultJobTemplate.PodSecurityContext == nil {
+			c.DefaultJobTemplate.PodSecurityContext = restricted.PodSecurityContext
+		}
+		if c.DefaultJobTemplate.SecurityContext == nil {
+			c.DefaultJobTemplate.SecurityContext = restricted.SecurityContext
+		}
+	}
+}
+
+// Validate reports every problem with the configuration at once
+func (c *OperatorConfig) Validate() error {
+	var errs []error
+
+	if c.MoverImage == "" {
+		errs = append(errs, errors.New("moverImage must not be empty"))
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(c.DefaultStoragePVC) {
+		errs = append(errs, fmt.Errorf("defaultStoragePVC %q: %s", c.DefaultStoragePVC, msg))
+	}
+	if lines := c.FailureLogTailLines; lines != nil && *lines < 0 {
+		errs = append(errs, errors.New("failureLogTailLines must not be negative"))
+	}
+	if ttl := c.FinishedJobTTL; ttl != nil && ttl.Duration < 0 {
+		errs = append(errs, errors.New("finishedJobTTL must not be negative"))
+	}
+	if interval := c.ProgressInterval; interval != nil && interval.Duration != 0 && interval.Duration < time.Second {
+		errs = append(errs, errors.New("progressInterval must be 0 or at least 1s"))
+	}
+
+	for name, workers := range c.MaxConcurrentReconciles {
+		switch name {
+		case BackupPolicyController, BackupController, RestoreController:
+		default:
+			errs = append(errs, fmt.Errorf("maxConcurrentReconciles: unknown controller %q", name))
+		}
+		if workers < 1 {
+			errs = append(errs, fmt.Errorf("maxConcurrentReconciles.%s must be at least 1", name))
+		}
+	}
+
+	if limits := c.MaxConcurrentMovers; limits != nil {
+		if limits.PerNode < 0 {
+			errs = append(errs, errors.New("maxConcurrentMovers.perNode must not be negative"))
+		}
+		if limits.PerStorageLocation < 0 {
+			errs = append(errs, errors.New("maxConcurrentMovers.perStorageLocation must not be negative"))
+		}
+		if limits.RetryInterval != nil && limits.RetryInterval.Duration < time.Second {
+			errs = append(errs, errors.New("maxConcurrentMovers.retryInterval must be at least 1s"))
+		}
+	}
+
+	if queue := c.BackupQueue; queue != nil {
+		if queue.MaxRunning < 0 {
+			errs = append(errs, errors.New("backupQueue.maxRunning must not be negative"))
+		}
+		if queue.MaxRunningPerNamespace < 0 {
+			errs = append(errs, errors.New("backupQueue.maxRunningPerNamespace must not be negative"))
+		}
+		if interval := queue.PollInterval; interval != nil && interval.Duration != 0 && interval.Duration < time.Second {
+			errs = append(errs, errors.New("backupQueue.pollInterval must be 0 or at least 1s"))
+		}
+	}
+
+	if retention := c.DefaultRetention; retention != nil {
+		counts := []struct {
+			name  string
+			count *int
+		}{
+			{"keepLast", retention.KeepLast},
+			{"keepHourly", retention.KeepHourly},
+			{"keepDaily", retention.KeepDaily},
+			{"keepWeekly", retention.KeepWeekly},
+			{"keepMonthly", retention.KeepMonthly},
+			{"keepYearly", retention.KeepYearly},
+		}
+		for _, c := range counts {
+			if c.count != nil && *c.count < 1 {
+				errs = append(errs, fmt.Errorf("defaultRetention.%s must be at least 1", c.name))
+			}
+		}
+		if limit := retention.FailedBackupsHistoryLimit; limit != nil && *limit < 0 {
+			errs = append(errs, errors.New("defaultRetention.failedBackupsHistoryLimit must not be negative"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Store holds the current configuration and is safe for concurrent use.
+// A nil Store returns the built-in defaults.
+type Store struct {
+	current atomic.Pointer[OperatorConfig]
+}
+
+// NewStore returns a Store holding cfg
+func NewStore(cfg *OperatorConfig) *Store {
+	s := &Store{}
+	s.Set(cfg)
+	return s
+}
+
+// Get returns the current configuration. Callers must not modify it.
+func (s *Store) Get() *OperatorConfig {
+	if s == nil {
+		return Default()
+	}
+	if cfg := s.current.Load(); cfg != nil {
+		return cfg
+	}
+	return Default()
+}
+
+// Set replaces the current configuration
+func (s *Store) Set(cfg *OperatorConfig) {
+	s.current.Store(cfg)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		check   func(t *testing.T, cfg *OperatorConfig)
+		wantErr []string
+	}{
+		{
+			name: "empty file uses the built-in defaults",
+			data: "",
+			check: func(t *testing.T, cfg *OperatorConfig) {
+				if !reflect.DeepEqual(cfg, Default()) {
+					t.Errorf("got %+v, want the defaults", cfg)
+				}
+				if cfg.JobTTL() != 24*time.Hour {
+					t.Errorf("finishedJobTTL = %v, want 24h", cfg.JobTTL())
+				}
+			},
+		},
+		{
+			name: "set fields override the defaults",
+			data: `
+moverImage: registry.example.com/mover:1.0
+defaultStoragePVC: archive
+finishedJobTTL: 2h
+progressInterval: 0s
+maxConcurrentReconciles:
+  backup: 4
+defaultRetention:
+  keepDaily: 7
+maxConcurrentMovers:
+  perNode: 2
+  retryInterval: 1m
+backupQueue:
+  maxRunning: 20
+  maxRunningPerNamespace: 5
+  pollInterval: 30s
+`,
+			check: func(t *testing.T, cfg *OperatorConfig) {
+				if cfg.MoverImage != "registry.example.com/mover:1.0" {
+					t.Errorf("moverImage = %q", cfg.MoverImage)
+				}
+				if cfg.DefaultStoragePVC != "archive" {
+					t.Errorf("defaultStoragePVC = %q", cfg.DefaultStoragePVC)
+				}
+				if cfg.JobTTL() != 2*time.Hour {
+					t.Errorf("finishedJobTTL = %v", cfg.JobTTL())
+				}
+				if cfg.ProgressSampleInterval() != 0 {
+					t.Errorf("progressInterval 0s should disable progress sampling, got %v", cfg.ProgressSampleInterval())
+				}
+				if cfg.MaxConcurrentReconciles[BackupController] != 4 {
+					t.Errorf("maxConcurrentReconciles = %v", cfg.MaxConcurrentReconciles)
+				}
+				if cfg.DefaultRetention == nil || cfg.DefaultRetention.KeepDaily == nil || *cfg.DefaultRetention.KeepDaily != 7 {
+					t.Errorf("defaultRetention = %+v", cfg.DefaultRetention)
+				}
+				if limits := cfg.MaxConcurrentMovers; limits == nil || limits.PerNode != 2 || limits.PerStorageLocation != 0 {
+					t.Errorf("maxConcurrentMovers = %+v", limits)
+				}
+				if cfg.MoverSlotRetryInterval() != time.Minute {
+					t.Errorf("maxConcurrentMovers.retryInterval = %v", cfg.MoverSlotRetryInterval())
+				}
+				if queue := cfg.BackupQueue; queue == nil || queue.MaxRunning != 20 || queue.MaxRunningPerNamespace != 5 {
+					t.Errorf("backupQueue = %+v", queue)
+				}
+				if cfg.QueuePollInterval() != 30*time.Second {
+					t.Errorf("backupQueue.pollInterval = %v", cfg.QueuePollInterval())
+				}
+				if cfg.DefaultJobTemplate != nil {
+					t.Errorf("defaultJobTemplate = %+v, want no mover pod overrides by default", cfg.DefaultJobTemplate)
+				}
+			},
+		},
+		{
+			name: "finishedJobTTL 0s keeps finished Jobs",
+			data: "finishedJobTTL: 0s\n",
+			check: func(t *testing.T, cfg *OperatorConfig) {
+				if cfg.JobTTL() != 0 {
+					t.Errorf("finishedJobTTL = %v, want 0", cfg.JobTTL())
+				}
+			},
+		},
+		{
+			name: "restricted movers fill the security contexts the default template leaves unset",
+			data: `
+restrictedMovers: true
+defaultJobTemplate:
+  priorityClassName: low
+  securityContext:
+    runAsUser: 1000
+`,
+			check: func(t *testing.T, cfg *OperatorConfig) {
+				template := cfg.DefaultJobTemplate
+				if template == nil || template.PriorityClassName != "low" {
+					t.Fatalf("defaultJobTemplate = %+v, want the configured fields kept", template)
+				}
+				if !reflect.DeepEqual(template.PodSecurityContext, RestrictedMoverJobTemplate().PodSecurityContext) {
+					t.Errorf("podSecurityContext = %+v, want the restricted one", template.PodSecurityContext)
+				}
+				if sc := template.SecurityContext; sc == nil || *sc.RunAsUser != 1000 || sc.Capabilities != nil {
+					t.Errorf("securityContext = %+v, want the configured one", sc)
+				}
+			},
+		},
+		{
+			name:    "unknown fields are rejected",
+			data:    "moverImag: busybox\n",
+			wantErr: []string{"moverImag"},
+		},
+		{
+			name: "every validation error is reported",
+			data: `
+defaultStoragePVC: Not_A_PVC
+finishedJobTTL: -1h
+failureLogTailLines: -1
+progressInterval: 100ms
+maxConcurrentReconciles:
+  backups: 2
+  restore: 0
+defaultRetention:
+  keepLast: 0
+maxConcurrentMovers:
+  perStorageLocation: -1
+  retryInterval: 0s
+backupQueue:
+  maxRunning: -1
+  pollInterval: 10ms
+`,
+			wantErr: []string{
+				"defaultStoragePVC",
+				"finishedJobTTL",
+				"failureLogTailLines",
+				"progressInterval",
+				`unknown controller "backups"`,
+				"maxConcurrentReconciles.restore",
+				"defaultRetention.keepLast",
+				"maxConcurrentMovers.perStorageLocation",
+				"maxConcurrentMovers.retryInterval",
+				"backupQueue.maxRunning",
+				"backupQueue.pollInterval",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Parse([]byte(tt.data))
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatalf("expected an error, got config %+v", cfg)
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error %q does not mention %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	store := NewStore(Default())
+	watcher := &Watcher{Path: path, Store: store}
+
+	if err := os.WriteFile(path, []byte("moverImage: mover:v2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	watcher.reload()
+	if got := store.Get().MoverImage; got != "mover:v2" {
+		t.Fatalf("after a valid update moverImage = %q, want mover:v2", got)
+	}
+
+	if err := os.WriteFile(path, []byte("finishedJobTTL: -5s\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	watcher.reload()
+	if got := store.Get().MoverImage; got != "mover:v2" {
+		t.Errorf("an invalid update replaced the config: moverImage = %q", got)
+	}
+}
+
+func TestNilStoreReturnsDefaults(t *testing.T) {
+	var store *Store
+	if !reflect.DeepEqual(store.Get(), Default()) {
+		t.Errorf("nil store should return the defaults")
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+)
+
+// Watcher reloads the configuration file into a Store whenever it changes.
+// It watches the file's directory rather than the file itself because
+// ConfigMap volumes are updated by atomically swapping a symlink.
+// An invalid update is logged and the previous configuration is kept.
+// Watcher implements manager.Runnable.
+type Watcher struct {
+	Path  string
+	Store *Store
+	Log   logr.Logger
+}
+
+// NeedLeaderElection reports that every replica reloads its own configuration
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start watches the configuration file until ctx is cancelled
+func (w *Watcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating config watcher: %w", err)
+	}
+	defer watcher.Close() //nolint:errcheck
+
+	if err := watcher.Add(filepath.Dir(w.Path)); err != nil {
+		return fmt.Errorf("watching config directory: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) {
+				continue
+			}
+			w.reload()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.Log.Error(err, "config watcher error")
+		}
+	}
+}
+
+// reload loads the file and swaps it into the Store if it is valid and changed
+func (w *Watcher) reload() {
+	cfg, err := Load(w.Path)
+	if err != nil {
+		w.Log.Error(err, "ignoring invalid config update, keeping the previous config", "path", w.Path)
+		return
+	}
+	if reflect.DeepEqual(cfg, w.Store.Get()) {
+		return
+	}
+
+	w.Store.Set(cfg)
+	w.Log.Info("reloaded operator config", "path", w.Path)
+}
@@ -18,19 +18,31 @@ package controller
 
 import (
 	"context"
-
+	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/utils/ptr"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -39,13 +51,42 @@ type BackupReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// Config holds the operator-level defaults; nil means built-in defaults
+	Config *config.Store
+
+	// Pods reads mover pods and their logs to explain failures; nil skips the details
+	Pods corev1client.PodsGetter
+
+	// Clock is used for phase transition timestamps and retry backoff; set to the
+	// real clock by SetupWithManager
+	Clock clock.Clock
+
+	// APIReader reads mover Jobs past the cache; set to the manager's by
+	// SetupWithManager, nil reads through Client
+	APIReader client.Reader
+
+	// ClusterID is recorded in archive manifests, see ClusterID
+	ClusterID string
+
+	// MoverToolsImage is the operator image, whose mover binary compresses zstd
+	// and lz4 archives and reports progress, see OperatorImage; empty rejects
+	// those backups and leaves the others without progress
+	MoverToolsImage string
 }
 
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups/finalizers,verbs=update
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backuppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backupstoragelocations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=restores,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -68,78 +109,307 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// If backup is already completed or failed, nothing to do
-	if backup.Status.Phase == backupv1alpha1.BackupPhaseCompleted ||
-		backup.Status.Phase == backupv1alpha1.BackupPhaseFailed {
-		log.Info("Backup already in terminal state", "phase", backup.Status.Phase)
+	if done, err := r.reconcileImported(ctx, &backup); done || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if done, err := r.reconcileHold(ctx, &backup); done || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Imported Backups are read-only records of another cluster's archives:
+	// their status comes from the storage location sync, never from a Job
+	if isImported(&backup) && backup.Status.Phase == "" {
+		log.Info("Waiting for storage location sync to record imported Backup")
 		return ctrl.Result{}, nil
 	}
 
+	if done, err := r.reconcileBackupCancel(ctx, &backup); done || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// If backup is already finished, only replication, verification and expiration are left to handle
+	if backupFinished(backup.Status.Phase) {
+		log.Info("Backup already in terminal state", "phase", backup.Status.Phase)
+		// Copies run alongside verification and never hold up expiry
+		retryReplicasAfter, err := r.reconcileReplication(ctx, &backup)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if done, result, err := r.reconcileVerification(ctx, &backup); !done || err != nil {
+			return ctrl.Result{RequeueAfter: earliestRequeue(result.RequeueAfter, retryReplicasAfter)}, err
+		}
+		// An expired imported Backup would be imported again by the next sync
+		if isImported(&backup) {
+			return ctrl.Result{}, nil
+		}
+		if pruned, err := r.reconcileFailedHistory(ctx, &backup); pruned || err != nil {
+			return ctrl.Result{}, err
+		}
+		result, err := r.reconcileExpiration(ctx, &backup)
+		return ctrl.Result{RequeueAfter: earliestRequeue(result.RequeueAfter, retryReplicasAfter)}, err
+	}
+
+	// A compression level or throttle the mover cannot apply fails the backup
+	// before any Job runs. Members of a consistency group wait for the group's
+	// pre-hook, so they all start together.
+	if backup.Status.Attempts == 0 {
+		if err := archive.Validate(backup.Spec.Compression); err != nil {
+			return ctrl.Result{}, r.rejectBackup(ctx, &backup, "InvalidCompression", err.Error())
+		}
+		if format := formatOf(&backup); format.NeedsMoverTools() && r.MoverToolsImage == "" {
+			return ctrl.Result{}, r.rejectBackup(ctx, &backup, "MoverToolsUnavailable", moverToolsUnavailable(format))
+		}
+		if err := validateThrottle(backup.Spec.Throttle); err != nil {
+			return ctrl.Result{}, r.rejectBackup(ctx, &backup, "InvalidThrottle", err.Error())
+		}
+		if ready, err := r.reconcilePreHook(ctx, &backup); !ready || err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Backups beyond the operator's caps on running backups wait in the queue
+	if waitingPhase(backup.Status.Phase) {
+		admitted, err := r.admitBackup(ctx, &backup)
+		if err != nil {
+			log.Error(err, "unable to admit Backup")
+			return ctrl.Result{}, err
+		}
+		if !admitted {
+			log.Info("Backup queued", "position", backup.Status.QueuePosition)
+			return ctrl.Result{RequeueAfter: r.Config.Get().QueuePollInterval()}, nil
+		}
+	}
+
 	// Set phase to Running if not already set
-	if backup.Status.Phase == "" {
+	if waitingPhase(backup.Status.Phase) {
+		base := backup.DeepCopy()
 		backup.Status.Phase = backupv1alpha1.BackupPhaseRunning
-		now := metav1.Now()
+		backup.Status.QueuePosition = 0
+		now := metav1.NewTime(r.Clock.Now())
 		backup.Status.StartTime = &now
-		if err := r.Status().Update(ctx, &backup); err != nil {
+		setConditions(&backup.Status.Conditions, backup.Generation, "BackupStarted", "Backup execution started",
+			metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionFalse)
+		if err := patchBackupStatus(ctx, r.Client, &backup, base); err != nil {
 			log.Error(err, "unable to update Backup status to Running")
 			return ctrl.Result{}, err
 		}
 		log.Info("Updated Backup status to Running")
+		r.Recorder.Event(
+			&backup,
+			corev1.EventTypeNormal,
+			"BackupStarted",
+			"Backup execution started",
+		)
 	}
 
-	// Check if Job already exists
+	// Check if the Job of the current attempt already exists
+	attempt := max(backup.Status.Attempts, 1)
 	var existingJob batchv1.Job
-	jobName := backup.Name + "-job"
-	err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: backup.Namespace}, &existingJob)
-	if err == nil {
-		// Job exists, check its status
-		if existingJob.Status.Succeeded > 0 {
-			log.Info("Backup Job completed successfully")
-			backup.Status.Phase = backupv1alpha1.BackupPhaseCompleted
-			now := metav1.Now()
-			backup.Status.CompletionTime = &now
-			backup.Status.BackupLocation = "/backups/" + backup.Name + ".tar.gz"
-			if err := r.Status().Update(ctx, &backup); err != nil {
-				return ctrl.Result{}, err
-			}
-			return ctrl.Result{}, nil
-		} else if existingJob.Status.Failed > 0 {
-			log.Info("Backup Job failed")
-			backup.Status.Phase = backupv1alpha1.BackupPhaseFailed
-			now := metav1.Now()
-			backup.Status.CompletionTime = &now
-			if err := r.Status().Update(ctx, &backup); err != nil {
+	jobName := attemptJobName(backupJobName(&backup), attempt)
+	jobKey := client.ObjectKey{Name: jobName, Namespace: backup.Namespace}
+	err := r.Get(ctx, jobKey, &existingJob)
+	if apierrors.IsNotFound(err) {
+		lost, err := attemptJobLost(ctx, r.apiReader(), backup.Status.Conditions, backup.Status.Attempts, attempt, jobKey)
+		if err != nil {
+			log.Error(err, "unable to fetch Job")
+			return ctrl.Result{}, err
+		}
+		if !lost {
+			// Job doesn't exist, create it
+			return r.startBackupAttempt(ctx, &backup, attempt, "")
+		}
+		failure := lostJobFailure(jobName, attempt)
+		log.Info("Backup Job is gone", "jobName", jobName, "attempt", attempt)
+		if attempt < maxAttempts(backup.Spec.Retry) {
+			return r.startBackupAttempt(ctx, &backup, attempt+1, failure)
+		}
+		return ctrl.Result{}, r.rejectBackup(ctx, &backup, "JobLost",
+			fmt.Sprintf("Backup failed after %d attempt(s): %s", attempt, failure))
+	}
+	if err != nil {
+		log.Error(err, "unable to fetch Job")
+		return ctrl.Result{}, err
+	}
+	// The Job of a preempted attempt is created again once the deleted one is
+	// gone; its deletion triggers the next reconcile
+	if !existingJob.DeletionTimestamp.IsZero() {
+		log.Info("Waiting for the deleted Job of this attempt to go away", "jobName", jobName)
+		return ctrl.Result{}, nil
+	}
+
+	// The Job is owned by the Backup, so every change to its status triggers a
+	// reconcile; only the mover's progress is sampled while it runs
+	state := evaluateAttempt(&existingJob, attempt, backup.Spec.Retry, r.Clock.Now())
+	switch state.Outcome {
+	case attemptSucceeded:
+		log.Info("Backup Job completed successfully")
+		base := backup.DeepCopy()
+		backup.Status.Phase = backupv1alpha1.BackupPhaseCompleted
+		now := metav1.NewTime(r.Clock.Now())
+		backup.Status.CompletionTime = &now
+		backup.Status.BackupLocation = "/backups/" + archive.Name(&backup)
+		summary := backupSummary(ctx, r.Pods, &existingJob)
+		if size, ok := summary["size"]; ok {
+			backup.Status.Size = resource.NewQuantity(size, resource.BinarySI)
+		}
+		if bytes, ok := summary["bytes"]; ok {
+			backup.Status.DataSize = resource.NewQuantity(bytes, resource.BinarySI)
+		}
+		backup.Status.FileCount = summary["files"]
+		backup.Status.Progress = completedProgress(backup.Status.Progress, summary["bytes"], summary["files"], now.Time)
+		backup.Status.FailureReason = ""
+		setConditions(&backup.Status.Conditions, backup.Generation, "BackupCompleted",
+			"Backup archive written to "+backup.Status.BackupLocation,
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+		if err := patchBackupStatus(ctx, r.Client, &backup, base); err != nil {
+			log.Error(err, "unable to update Backup status to Completed")
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(
+			&backup,
+			corev1.EventTypeNormal,
+			"BackupCompleted",
+			"Backup completed successfully in %s",
+			backupDuration(&backup),
+		)
+		return ctrl.Result{}, nil
+
+	case attemptRetryPending:
+		log.Info("Backup Job failed, retrying later", "attempt", attempt, "reason", state.Failed.Reason, "retryAfter", state.RetryAfter)
+		// Record the failure once per attempt; later reconciles during the backoff only requeue
+		if progressing := meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionProgressing); progressing == nil ||
+			progressing.Reason != "RetryPending" {
+			base := backup.DeepCopy()
+			backup.Status.FailureReason = describeJobFailure(ctx, r.Pods, &existingJob, state.Failed, r.Config.Get().FailureLogLines())
+			setCondition(&backup.Status.Conditions, backup.Generation, backupv1alpha1.ConditionProgressing,
+				metav1.ConditionTrue, "RetryPending", fmt.Sprintf("Attempt %d failed, retrying in %s", attempt, state.RetryAfter.Round(time.Second)))
+			setCondition(&backup.Status.Conditions, backup.Generation, backupv1alpha1.ConditionDegraded,
+				metav1.ConditionTrue, "AttemptFailed", backup.Status.FailureReason)
+			if err := patchBackupStatus(ctx, r.Client, &backup, base); err != nil {
+				log.Error(err, "unable to record failed Backup attempt")
 				return ctrl.Result{}, err
 			}
-			return ctrl.Result{}, nil
 		}
-		// Job still running, requeue to check later
-		// 🔔 EVENT (Job still running)
+		return ctrl.Result{RequeueAfter: state.RetryAfter}, nil
+
+	case attemptRetry:
+		failure := describeJobFailure(ctx, r.Pods, &existingJob, state.Failed, r.Config.Get().FailureLogLines())
+		return r.startBackupAttempt(ctx, &backup, attempt+1, failure)
+
+	case attemptFailed:
+		log.Info("Backup Job failed", "attempts", attempt, "reason", state.Failed.Reason)
+		base := backup.DeepCopy()
+		backup.Status.FailureReason = describeJobFailure(ctx, r.Pods, &existingJob, state.Failed, r.Config.Get().FailureLogLines())
+		backup.Status.Phase = backupv1alpha1.BackupPhaseFailed
+		now := metav1.NewTime(r.Clock.Now())
+		backup.Status.CompletionTime = &now
+		message := fmt.Sprintf("Backup failed after %d attempt(s): %s", attempt, backup.Status.FailureReason)
+		setConditions(&backup.Status.Conditions, backup.Generation, "BackupFailed", message,
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+		if err := patchBackupStatus(ctx, r.Client, &backup, base); err != nil {
+			log.Error(err, "unable to update Backup status to Failed")
+			return ctrl.Result{}, err
+		}
 		r.Recorder.Event(
 			&backup,
-			corev1.EventTypeNormal,
-			"JobRunning",
-			"Backup Job is still running",
-		)		
-			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			corev1.EventTypeWarning,
+			"BackupFailed",
+			eventMessage(message),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	// Restores that push running backups over the queue's caps preempt the
+	// backups kept least
+	preempt, err := r.preempted(ctx, &backup)
+	if err != nil {
+		log.Error(err, "unable to check for preemption")
+		return ctrl.Result{}, err
+	}
+	if preempt {
+		return r.preemptBackup(ctx, &backup, jobName)
+	}
+
+	log.Info("Backup Job still running", "jobName", jobName)
+	// Movers report progress to a ConfigMap whose updates trigger a reconcile
+	if counters := readProgress(ctx, r.Client, &existingJob); counters != nil {
+		progress := newProgress(counters, existingJob.Status.StartTime, r.Clock.Now())
+		if progressChanged(backup.Status.Progress, progress) {
+			base := backup.DeepCopy()
+			backup.Status.Progress = progress
+			if err := patchBackupStatus(ctx, r.Client, &backup, base); err != nil {
+				log.Error(err, "unable to update Backup progress")
+				return ctrl.Result{}, err
+			}
 		}
+	}
+	return ctrl.Result{}, nil
+}
 
-	if !apierrors.IsNotFound(err) {
-		log.Error(err, "unable to fetch Job")
+// backupDuration is how long a finished backup took, rounded to the second
+func backupDuration(backup *backupv1alpha1.Backup) time.Duration {
+	if backup.Status.StartTime == nil || backup.Status.CompletionTime == nil {
+		return 0
+	}
+	return backup.Status.CompletionTime.Sub(backup.Status.StartTime.Time).Round(time.Second)
+}
+
+// startBackupAttempt creates the Job for the given attempt and records the
+// attempt in status. failure describes the previous attempt, if it failed.
+func (r *BackupReconciler) startBackupAttempt(ctx context.Context, backup *backupv1alpha1.Backup, attempt int32, failure string) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	storagePVC, err := storagePVCName(ctx, r.Client, r.Config.Get(), backup.Namespace, backup.Spec.StorageLocation)
+	if err != nil {
+		// The location may not have been created yet, so keep retrying
+		log.Error(err, "unable to resolve storage location")
+		base := backup.DeepCopy()
+		setCondition(&backup.Status.Conditions, backup.Generation, backupv1alpha1.ConditionDegraded,
+			metav1.ConditionTrue, "StorageLocationUnavailable", err.Error())
+		if statusErr := patchBackupStatus(ctx, r.Client, backup, base); statusErr != nil {
+			log.Error(statusErr, "unable to record unavailable storage location")
+		}
 		return ctrl.Result{}, err
 	}
 
-	// Job doesn't exist, create it
-	job := r.createBackupJob(&backup)
+	// Movers beyond the operator's concurrency caps wait for running ones to finish
+	message, err := r.moverSlotWait(ctx, backup, storagePVC)
+	if err != nil {
+		log.Error(err, "unable to count running movers")
+		return ctrl.Result{}, err
+	}
+	if message != "" {
+		log.Info("Waiting for a mover slot", "reason", message)
+		return ctrl.Result{RequeueAfter: r.Config.Get().MoverSlotRetryInterval()}, r.markWaitingForMoverSlot(ctx, backup, message)
+	}
+
+	// The source PVC spec goes into the archive's manifest and the Backup's
+	// status; a backup can run without it
+	var sourcePVC corev1.PersistentVolumeClaim
+	var sourcePVCSpec *corev1.PersistentVolumeClaimSpec
+	if err := r.Get(ctx, client.ObjectKey{Name: backup.Spec.Target.PVCName, Namespace: backup.Namespace}, &sourcePVC); err != nil {
+		log.Info("Source PVC spec not recorded in manifest", "pvc", backup.Spec.Target.PVCName, "reason", err.Error())
+	} else {
+		sourcePVCSpec = &sourcePVC.Spec
+	}
+
+	job := r.createBackupJob(backup, attempt, storagePVC, sourcePVCSpec)
 	if err := r.Create(ctx, job); err != nil {
 		// Ignore "already exists" errors (race condition from multiple reconciles)
 		if !apierrors.IsAlreadyExists(err) {
 			log.Error(err, "unable to create Backup Job")
+			base := backup.DeepCopy()
 			backup.Status.Phase = backupv1alpha1.BackupPhaseFailed
-			r.Status().Update(ctx, &backup)
+			message := fmt.Sprintf("Failed to create backup Job: %v", err)
+			setConditions(&backup.Status.Conditions, backup.Generation, "JobCreateFailed", message,
+				metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+			if statusErr := patchBackupStatus(ctx, r.Client, backup, base); statusErr != nil {
+				log.Error(statusErr, "unable to update Backup status to Failed")
+			}
 
 			r.Recorder.Event(
-				&backup,
+				backup,
 				corev1.EventTypeWarning,
 				"JobCreateFailed",
 				"Failed to create backup Job",
@@ -147,56 +417,252 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 			return ctrl.Result{}, err
 
-		
 		}
 		log.Info("Job already exists (race condition), continuing")
 	}
+	if progressSeconds(r.Config.Get(), r.MoverToolsImage) > 0 {
+		// Without the ConfigMap the backup still runs, only without progress
+		if err := createProgressConfigMap(ctx, r.Client, r.apiReader(), job, backupMover, client.ObjectKeyFromObject(backup)); err != nil {
+			log.Error(err, "unable to create the progress ConfigMap", "jobName", job.Name)
+		}
+	}
+
+	// A preempted attempt runs again under its own number. JobRunning is set
+	// for it too, as it tells a lost Job from one not created yet.
+	newAttempt := backup.Status.Attempts != attempt
+	base := backup.DeepCopy()
+	if newAttempt {
+		backup.Status.Attempts = attempt
+		backup.Status.Progress = nil
+		if sourcePVCSpec != nil {
+			backup.Status.SourcePVC = recordedSourcePVC(sourcePVCSpec)
+		}
+		if failure != "" {
+			backup.Status.FailureReason = failure
+			setCondition(&backup.Status.Conditions, backup.Generation, backupv1alpha1.ConditionDegraded,
+				metav1.ConditionTrue, "AttemptFailed", failure)
+		}
+	}
+	setCondition(&backup.Status.Conditions, backup.Generation, backupv1alpha1.ConditionProgressing,
+		metav1.ConditionTrue, "JobRunning", fmt.Sprintf("Running attempt %d of %d", attempt, maxAttempts(backup.Spec.Retry)))
+	if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+		log.Error(err, "unable to record Backup attempt")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Created Backup Job", "jobName", job.Name, "attempt", attempt)
+	if attempt > 1 && newAttempt {
+		r.Recorder.Event(
+			backup,
+			corev1.EventTypeWarning,
+			"JobRetried",
+			eventMessage(fmt.Sprintf(
+				"Attempt %d failed (%s), starting attempt %d of %d",
+				attempt-1,
+				backup.Status.FailureReason,
+				attempt,
+				maxAttempts(backup.Spec.Retry),
+			)),
+		)
+	} else {
+		r.Recorder.Eventf(
+			backup,
+			corev1.EventTypeNormal,
+			"JobCreated",
+			"Created backup job %s",
+			job.Name,
+		)
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileHold keeps the hold finalizer in sync with the Backup's hold and
+// reports whether reconciliation should stop because the Backup is being deleted
+func (r *BackupReconciler) reconcileHold(ctx context.Context, backup *backupv1alpha1.Backup) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	held := isBackupHeld(backup)
+	hasFinalizer := controllerutil.ContainsFinalizer(backup, backupv1alpha1.HoldFinalizer)
+	deleting := !backup.DeletionTimestamp.IsZero()
+
+	// Finalizers cannot be added once deletion has started, so a hold placed on
+	// a Backup that is already being deleted does not block it
+	if held && !hasFinalizer && !deleting {
+		controllerutil.AddFinalizer(backup, backupv1alpha1.HoldFinalizer)
+		if err := r.Update(ctx, backup); err != nil {
+			log.Error(err, "unable to add hold finalizer")
+			return false, err
+		}
+		log.Info("Backup placed on hold")
+	} else if !held && hasFinalizer {
+		controllerutil.RemoveFinalizer(backup, backupv1alpha1.HoldFinalizer)
+		if err := r.Update(ctx, backup); err != nil {
+			log.Error(err, "unable to remove hold finalizer")
+			return false, err
+		}
+		log.Info("Backup hold released")
+	}
+
+	if !deleting {
+		return false, nil
+	}
+
+	if held && hasFinalizer {
+		r.Recorder.Event(
+			backup,
+			corev1.EventTypeWarning,
+			"DeletionBlocked",
+			"Backup is on hold; clear the hold to finish deleting it",
+		)
+	}
+	return true, nil
+}
+
+// isBackupHeld reports whether a Backup is protected by a hold
+func isBackupHeld(backup *backupv1alpha1.Backup) bool {
+	return backup.Spec.Hold || backup.Annotations[backupv1alpha1.HoldAnnotation] == "true"
+}
+
+// reconcileExpiration garbage collects a finished Backup once its TTL or
+// ExpiresAt has passed, and otherwise requeues for the expiry time
+func (r *BackupReconciler) reconcileExpiration(ctx context.Context, backup *backupv1alpha1.Backup) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	expiration := backupExpiration(backup)
+	if expiration == nil {
+		return ctrl.Result{}, nil
+	}
 
-	
+	// A held backup never expires; clearing the hold triggers a new reconcile
+	if isBackupHeld(backup) {
+		log.Info("Backup is on hold, skipping expiration", "expirationTime", expiration.Time)
+		return ctrl.Result{}, nil
+	}
+
+	if backup.Status.ExpirationTime == nil || !backup.Status.ExpirationTime.Equal(expiration) {
+		base := backup.DeepCopy()
+		backup.Status.ExpirationTime = expiration
+		if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+			log.Error(err, "unable to update Backup expiration time")
+			return ctrl.Result{}, err
+		}
+	}
 
-	log.Info("Created Backup Job", "jobName", job.Name)
-	r.Recorder.Event(
-		&backup,
+	remaining := expiration.Sub(r.Clock.Now())
+	if remaining > 0 {
+		log.Info("Backup expires later", "expirationTime", expiration.Time, "requeueAfter", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	log.Info("Deleting expired Backup", "expirationTime", expiration.Time)
+	r.Recorder.Eventf(
+		backup,
 		corev1.EventTypeNormal,
-		"JobCreated",
-		"Backup Job created successfully",
+		"Expired",
+		"Backup expired at %s and is being deleted",
+		expiration.Format(time.RFC3339),
 	)
-	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	if err := r.Delete(ctx, backup); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileFailedHistory enforces the failed history limit of the policy of
+// a failed or cancelled backup, so the failed backups of on-demand runs and
+// suspended policies are deleted without waiting for a schedule tick. It
+// reports whether the backup itself was deleted.
+func (r *BackupReconciler) reconcileFailedHistory(ctx context.Context, backup *backupv1alpha1.Backup) (bool, error) {
+	if backup.Spec.PolicyRef == "" ||
+		(backup.Status.Phase != backupv1alpha1.BackupPhaseFailed && backup.Status.Phase != backupv1alpha1.BackupPhaseCancelled) {
+		return false, nil
+	}
+
+	var backupPolicy backupv1alpha1.BackupPolicy
+	if err := r.Get(ctx, types.NamespacedName{Name: backup.Spec.PolicyRef, Namespace: backup.Namespace}, &backupPolicy); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	// In dry-run mode the policy only reports what it would delete
+	retention := policyRetention(&backupPolicy, r.Config.Get())
+	if retention == nil || retention.FailedBackupsHistoryLimit == nil || retention.DryRun {
+		return false, nil
+	}
+
+	var backups backupv1alpha1.BackupList
+	if err := r.List(ctx, &backups, client.InNamespace(backup.Namespace)); err != nil {
+		return false, err
+	}
+	_, failed := policyBackupsByOutcome(backups.Items, backupPolicy.Name)
+	pruned := pruneFailedBackups(ctx, r.Client, r.Recorder, &backupPolicy, retention, failed)
+	return slices.Contains(pruned, backup.Name), nil
 }
 
-func (r *BackupReconciler) createBackupJob(backup *backupv1alpha1.Backup) *batchv1.Job {
-	jobName := backup.Name + "-job"
+// backupExpiration returns when a finished backup expires, or nil if it never does.
+// ExpiresAt wins over TTL; TTL counts from completion, falling back to creation.
+func backupExpiration(backup *backupv1alpha1.Backup) *metav1.Time {
+	if backup.Spec.ExpiresAt != nil {
+		return backup.Spec.ExpiresAt.DeepCopy()
+	}
+	if backup.Spec.TTL == nil {
+		return nil
+	}
 
-	return &batchv1.Job{
+	base := backup.CreationTimestamp
+	if backup.Status.CompletionTime != nil {
+		base = *backup.Status.CompletionTime
+	}
+	return &metav1.Time{Time: base.Add(backup.Spec.TTL.Duration)}
+}
+
+// createBackupJob builds the Job of an attempt. It archives the target PVC
+// into storagePVC and writes the archive's checksum and manifest next to it.
+func (r *BackupReconciler) createBackupJob(backup *backupv1alpha1.Backup, attempt int32, storagePVC string, sourcePVC *corev1.PersistentVolumeClaimSpec) *batchv1.Job {
+	cfg := r.Config.Get()
+	jobName := attemptJobName(backupJobName(backup), attempt)
+	format := formatOf(backup)
+	archiveFile := archive.Name(backup)
+	throttle := throttleOf(backup.Spec.Throttle)
+	progressEvery := progressSeconds(cfg, r.MoverToolsImage)
+	labels := backupLabels(backup)
+	maps.Copy(labels, moverLabels(backupMover, storagePVC))
+
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      jobName,
-			Namespace: backup.Namespace,
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion: backup.APIVersion,
-					Kind:       backup.Kind,
-					Name:       backup.Name,
-					UID:        backup.UID,
-					Controller: ptr.To(true),
-				},
-			},
+			Name:            jobName,
+			Namespace:       backup.Namespace,
+			Labels:          labels,
+			OwnerReferences: backupOwnerReferences(backup),
 		},
 		Spec: batchv1.JobSpec{
 			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: moverLabels(backupMover, storagePVC),
+				},
 				Spec: corev1.PodSpec{
 					RestartPolicy: corev1.RestartPolicyNever,
 					Containers: []corev1.Container{
 						{
 							Name:  "backup",
-							Image: "busybox:latest",
+							Image: cfg.MoverImage,
 							Command: []string{
 								"sh",
 								"-c",
-								"echo 'Starting backup of PVC: " + backup.Spec.Target.PVCName + "' && " +
-									"tar -czf /backup-output/" + backup.Name + ".tar.gz -C /data . && " +
+								throttle.function() +
+									"echo 'Starting backup of PVC: " + backup.Spec.Target.PVCName + "' && " +
+									"bytes_total=" + diskUsageOf("/data") + " && " +
+									"files_total=$(find /data 2>/dev/null | wc -l) && " +
+									withProgress(
+										format.compressCommand("/data", "/backup-output/"+archiveFile, moverFilesFile, throttle),
+										"echo \"progress filesDone="+filesDone()+" filesTotal=$files_total bytesTotal=$bytes_total\"",
+										reportCommand(backup.Namespace, jobName), progressEvery,
+									) + " && " +
+									"cd /backup-output && sha256sum " + archiveFile + " > " + archiveFile + ".sha256 && " +
+									"echo \"size=$(stat -c %s " + archiveFile + ") bytes=$bytes_total files=$files_total\" > /dev/termination-log && " +
+									writeManifest(backup.Name, archiveFile) + " && " +
 									"echo 'Backup completed successfully' && " +
 									"ls -lh /backup-output/",
 							},
+							Env: manifestEnv(backup, sourcePVC, r.ClusterID),
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									Name:      "source-data",
@@ -224,7 +690,7 @@ func (r *BackupReconciler) createBackupJob(backup *backupv1alpha1.Backup) *batch
 							Name: "backup-output",
 							VolumeSource: corev1.VolumeSource{
 								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-									ClaimName: "backup-storage", // Shared storage
+									ClaimName: storagePVC,
 								},
 							},
 						},
@@ -233,14 +699,77 @@ func (r *BackupReconciler) createBackupJob(backup *backupv1alpha1.Backup) *batch
 			},
 		},
 	}
+
+	if format.NeedsMoverTools() || progressEvery > 0 {
+		addMoverTools(&job.Spec.Template.Spec, r.MoverToolsImage)
+	}
+	applyJobTemplates(&job.Spec.Template.Spec, cfg.DefaultJobTemplate, backup.Spec.JobTemplate)
+	applyJobLimits(job, backup.Spec.Timeout, cfg.JobTTL())
+	useFallbackTerminationMessages(&job.Spec.Template.Spec)
+	return job
+}
+
+// backupSummary reads what the backup container writes to its termination
+// message when it succeeds: "size=<archive bytes> bytes=<data bytes> files=<count>".
+// It returns nil when nothing was reported, e.g. for Jobs created before
+// summaries were written.
+func backupSummary(ctx context.Context, pods corev1client.PodsGetter, job *batchv1.Job) map[string]int64 {
+	if pods == nil {
+		return nil
+	}
+	podList, err := pods.Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: batchv1.JobNameLabel + "=" + job.Name,
+	})
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "unable to read backup summary", "jobName", job.Name)
+		return nil
+	}
+
+	for _, pod := range podList.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if status.Name != "backup" || terminated == nil || terminated.ExitCode != 0 {
+				continue
+			}
+			if summary := parseCounters(terminated.Message); len(summary) > 0 {
+				return summary
+			}
+		}
+	}
+	return nil
+}
+
+// apiReader returns the reader for lookups that must not be answered from the cache
+func (r *BackupReconciler) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Recorder = mgr.GetEventRecorderFor("backup-operator")
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&backupv1alpha1.Backup{}).
 		Owns(&batchv1.Job{}).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.preHookMembers)).
+		Watches(&backupv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.queueMembers),
+			builder.WithPredicates(phaseChanged)).
+		Watches(&backupv1alpha1.Restore{}, handler.EnqueueRequestsFromMapFunc(r.queueMembers),
+			builder.WithPredicates(phaseChanged)).
+		// Movers report progress to ConfigMaps, see createProgressConfigMap
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(progressOwner),
+			builder.WithPredicates(reportsOn(backupMover))).
 		Named("backup").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.Get().MaxConcurrentReconciles[config.BackupController],
+		}).
 		Complete(r)
 }
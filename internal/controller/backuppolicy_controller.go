@@ -19,19 +19,25 @@ package controller
 import (
 	"context"
 	"fmt"
-	"sort"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
 	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -40,12 +46,19 @@ type BackupPolicyReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// Config holds the operator-level defaults; nil means built-in defaults
+	Config *config.Store
+
+	// Clock decides when schedule ticks are due; set to the real clock by
+	// SetupWithManager if nil
+	Clock clock.Clock
 }
 
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backuppolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backuppolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backuppolicies/finalizers,verbs=update
-// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups,verbs=get;list;watch;create;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -70,41 +83,109 @@ func (r *BackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	log.Info("Found backup policy", "schedule", backupPolicy.Spec.Schedule, "pvcName", backupPolicy.Spec.Target.PVCName)
 
+	// Handle on-demand backup requests first so they also work while the
+	// policy is suspended
+	if err := r.handleTriggerNow(ctx, &backupPolicy); err != nil {
+		log.Error(err, "unable to handle trigger-now request")
+		return ctrl.Result{}, err
+	}
+
+	// Every status change below is written as a patch against this snapshot
+	base := backupPolicy.DeepCopy()
+
+	// Retention runs on every reconcile, not only on schedule ticks: the policy
+	// is reconciled whenever one of its backups changes, so backups are
+	// cleaned up as soon as they complete, and a dry run reports a retention
+	// change as soon as it is made, even for a suspended policy
+	if err := r.cleanupOldBackups(ctx, &backupPolicy); err != nil {
+		log.Error(err, "failed to clean up old backups")
+		// Don't fail the reconciliation, just log the error
+	}
+
+	if backupPolicy.Spec.Suspend {
+		log.Info("BackupPolicy is suspended, skipping scheduled backups")
+		if ready := meta.FindStatusCondition(backupPolicy.Status.Conditions, backupv1alpha1.ConditionReady); ready == nil || ready.Reason != "Suspended" {
+			r.Recorder.Event(
+				&backupPolicy,
+				corev1.EventTypeNormal,
+				"Suspended",
+				"Scheduled backups are suspended",
+			)
+		}
+		backupPolicy.Status.NextScheduledBackup = nil
+		setConditions(&backupPolicy.Status.Conditions, backupPolicy.Generation, "Suspended", "Scheduled backups are suspended",
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionFalse)
+		if err := patchPolicyStatus(ctx, r.Client, &backupPolicy, base); err != nil {
+			log.Error(err, "unable to update BackupPolicy status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	now := r.Clock.Now()
+
+	// Ticks that passed while the policy was suspended are skipped, so
+	// resuming does not start a backup for a tick long gone
+	if ready := meta.FindStatusCondition(backupPolicy.Status.Conditions, backupv1alpha1.ConditionReady); ready != nil && ready.Reason == "Suspended" {
+		log.Info("BackupPolicy resumed, skipping ticks missed while suspended")
+		backupPolicy.Status.LastScheduleTime = &metav1.Time{Time: now}
+		r.Recorder.Event(
+			&backupPolicy,
+			corev1.EventTypeNormal,
+			"Resumed",
+			"Scheduled backups resumed; ticks missed while suspended are skipped",
+		)
+	}
+
 	// Parse the cron schedule
 	schedule, err := cron.ParseStandard(backupPolicy.Spec.Schedule)
 	if err != nil {
 		log.Error(err, "invalid cron schedule", "schedule", backupPolicy.Spec.Schedule)
-		backupPolicy.Status.Conditions = []metav1.Condition{
-			{
-				Type:               "Ready",
-				Status:             metav1.ConditionFalse,
-				Reason:             "InvalidSchedule",
-				Message:            fmt.Sprintf("Invalid cron schedule: %v", err),
-				LastTransitionTime: metav1.Now(),
-			},
+		if degraded := meta.FindStatusCondition(backupPolicy.Status.Conditions, backupv1alpha1.ConditionDegraded); degraded == nil ||
+			degraded.Reason != "InvalidSchedule" || degraded.ObservedGeneration != backupPolicy.Generation {
+			r.Recorder.Eventf(
+				&backupPolicy,
+				corev1.EventTypeWarning,
+				"InvalidSchedule",
+				"Invalid cron schedule %q: %v",
+				backupPolicy.Spec.Schedule,
+				err,
+			)
 		}
-		r.Recorder.Eventf(
-			&backupPolicy,
-			corev1.EventTypeWarning,
-			"InvalidSchedule",
-			"Invalid cron schedule %q: %v",
-			backupPolicy.Spec.Schedule,
-			err,
-		)
-		r.Status().Update(ctx, &backupPolicy)
+		backupPolicy.Status.NextScheduledBackup = nil
+		setConditions(&backupPolicy.Status.Conditions, backupPolicy.Generation, "InvalidSchedule",
+			fmt.Sprintf("Invalid cron schedule: %v", err),
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+		if err := patchPolicyStatus(ctx, r.Client, &backupPolicy, base); err != nil {
+			log.Error(err, "unable to update BackupPolicy status")
+			return ctrl.Result{}, err
+		}
+		// Only a spec change can fix the schedule, and that triggers a new reconcile
 		return ctrl.Result{}, nil
 	}
 
-	now := time.Now()
+	// Request scheduled verifications; this only ever shortens the requeue below
+	verifyAfter, err := r.scheduleVerification(ctx, &backupPolicy, now)
+	if err != nil {
+		log.Error(err, "unable to request scheduled verification")
+		return ctrl.Result{}, err
+	}
 
 	// Calculate when the next backup should run
-	var nextBackupTime time.Time
-	if backupPolicy.Status.LastBackupTime != nil {
-		// Find next scheduled time after the last backup
-		nextBackupTime = schedule.Next(backupPolicy.Status.LastBackupTime.Time)
-	} else {
-		// No previous backup, schedule from now
-		nextBackupTime = schedule.Next(now.Add(-1 * time.Second))
+	nextBackupTime := dueTick(schedule, &backupPolicy, now)
+	if deadline := backupPolicy.Spec.StartingDeadlineSeconds; deadline != nil && !nextBackupTime.After(now) &&
+		now.Sub(nextBackupTime) > time.Duration(*deadline)*time.Second {
+		log.Info("Skipping missed schedule tick past its starting deadline", "tick", nextBackupTime)
+		r.Recorder.Eventf(
+			&backupPolicy,
+			corev1.EventTypeWarning,
+			"MissedSchedule",
+			"Skipped the backup scheduled for %s: more than %ds late",
+			nextBackupTime.Format(time.RFC3339),
+			*deadline,
+		)
+		backupPolicy.Status.LastScheduleTime = &metav1.Time{Time: nextBackupTime}
+		nextBackupTime = schedule.Next(nextBackupTime)
 	}
 
 	// Check if it's time to create a backup
@@ -113,37 +194,268 @@ func (r *BackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		requeueAfter := nextBackupTime.Sub(now)
 		log.Info("Next backup scheduled", "nextBackupTime", nextBackupTime, "requeueAfter", requeueAfter)
 
-		r.Recorder.Eventf(
-			&backupPolicy,
-			corev1.EventTypeNormal,
-			"BackupScheduled",
-			"Next backup scheduled for %s",
-			nextBackupTime.Format(time.RFC3339),
-		)
+		// Only announce the schedule when it changes, not on every reconcile
+		if next := backupPolicy.Status.NextScheduledBackup; next == nil || !next.Time.Equal(nextBackupTime) {
+			r.Recorder.Eventf(
+				&backupPolicy,
+				corev1.EventTypeNormal,
+				"BackupScheduled",
+				"Next backup scheduled for %s",
+				nextBackupTime.Format(time.RFC3339),
+			)
+		}
 
 		// Update status with next scheduled time
 		backupPolicy.Status.NextScheduledBackup = &metav1.Time{Time: nextBackupTime}
-		backupPolicy.Status.Conditions = []metav1.Condition{
-			{
-				Type:               "Ready",
-				Status:             metav1.ConditionTrue,
-				Reason:             "Scheduled",
-				Message:            fmt.Sprintf("Next backup scheduled for %s", nextBackupTime.Format(time.RFC3339)),
-				LastTransitionTime: metav1.Now(),
-			},
+		setConditions(&backupPolicy.Status.Conditions, backupPolicy.Generation, "Scheduled",
+			fmt.Sprintf("Next backup scheduled for %s", nextBackupTime.Format(time.RFC3339)),
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+		if err := patchPolicyStatus(ctx, r.Client, &backupPolicy, base); err != nil {
+			log.Error(err, "unable to update BackupPolicy status")
+			return ctrl.Result{}, err
 		}
-		r.Status().Update(ctx, &backupPolicy)
 
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		return ctrl.Result{RequeueAfter: earliestRequeue(requeueAfter, verifyAfter)}, nil
 	}
 
 	// Time to create a backup!
 	log.Info("Creating scheduled backup", "scheduledTime", nextBackupTime)
 
-	// Create a new Backup
+	// A reconcile that failed after creating the Backup of this tick must not
+	// create a second one, so look for it by its labels first
+	name := scheduledBackupName(backupPolicy.Name, nextBackupTime)
+	existing, err := r.findTickBackup(ctx, &backupPolicy, nextBackupTime)
+	if err != nil {
+		log.Error(err, "unable to look up Backup for schedule tick")
+		return ctrl.Result{}, err
+	}
+	if existing != nil && existing.Spec.Group == "" {
+		log.Info("Backup for schedule tick already exists", "backupName", existing.Name, "tick", nextBackupTime)
+	} else {
+		// Create new Backups named after the tick, not the time of this reconcile.
+		// A group's members are created one by one, so a retried reconcile
+		// creates the ones still missing.
+		for _, backup := range policyBackups(&backupPolicy, name) {
+			backup.Labels[backupv1alpha1.TickLabel] = tickLabelValue(nextBackupTime)
+
+			created, err := r.createPolicyBackup(ctx, &backupPolicy, backup)
+			if err != nil {
+				log.Error(err, "unable to create Backup")
+				return ctrl.Result{}, err
+			}
+			if !created {
+				continue
+			}
+
+			log.Info("Created scheduled Backup", "backupName", backup.Name)
+
+			r.Recorder.Eventf(
+				&backupPolicy,
+				corev1.EventTypeNormal,
+				"BackupCreated",
+				"Created backup %s",
+				backup.Name,
+			)
+		}
+	}
+
+	// Update status with last backup time
+	backupPolicy.Status.LastBackupTime = &metav1.Time{Time: now}
+	backupPolicy.Status.LastScheduleTime = &metav1.Time{Time: nextBackupTime}
+	nextScheduledTime := schedule.Next(now)
+	backupPolicy.Status.NextScheduledBackup = &metav1.Time{Time: nextScheduledTime}
+	setConditions(&backupPolicy.Status.Conditions, backupPolicy.Generation, "BackupCreated",
+		fmt.Sprintf("%s created, next backup scheduled for %s", describePolicyRun(&backupPolicy, name), nextScheduledTime.Format(time.RFC3339)),
+		metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+
+	if err := patchPolicyStatus(ctx, r.Client, &backupPolicy, base); err != nil {
+		log.Error(err, "unable to update BackupPolicy status")
+		return ctrl.Result{}, err
+	}
+
+	// Requeue for the next scheduled backup
+	requeueAfter := nextScheduledTime.Sub(r.Clock.Now())
+	log.Info("Requeuing for next backup", "nextBackupTime", nextScheduledTime, "requeueAfter", requeueAfter)
+
+	return ctrl.Result{RequeueAfter: earliestRequeue(requeueAfter, verifyAfter)}, nil
+}
+
+// handleTriggerNow creates an ad-hoc Backup when the trigger-now annotation
+// carries a token that has not been handled yet
+func (r *BackupPolicyReconciler) handleTriggerNow(ctx context.Context, backupPolicy *backupv1alpha1.BackupPolicy) error {
+	log := logf.FromContext(ctx)
+
+	token := backupPolicy.Annotations[backupv1alpha1.TriggerNowAnnotation]
+	if token == "" || token == backupPolicy.Status.LastTriggerToken {
+		return nil
+	}
+
+	base := backupPolicy.DeepCopy()
+
+	// The name is derived from the token so that a retried reconcile finds the
+	// Backup it already created instead of creating a second one
+	for _, backup := range policyBackups(backupPolicy, manualBackupName(backupPolicy.Name, token)) {
+		backup.Annotations = map[string]string{
+			backupv1alpha1.TriggerNowAnnotation: token,
+		}
+
+		created, err := r.createPolicyBackup(ctx, backupPolicy, backup)
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+
+		log.Info("Created on-demand Backup", "backupName", backup.Name, "token", token)
+		r.Recorder.Eventf(
+			backupPolicy,
+			corev1.EventTypeNormal,
+			"ManualBackupCreated",
+			"Created on-demand backup %s",
+			backup.Name,
+		)
+	}
+
+	backupPolicy.Status.LastTriggerToken = token
+	return patchPolicyStatus(ctx, r.Client, backupPolicy, base)
+}
+
+// findTickBackup returns the policy's Backup for the given schedule tick, or
+// nil if there is none yet
+func (r *BackupPolicyReconciler) findTickBackup(ctx context.Context, backupPolicy *backupv1alpha1.BackupPolicy, tick time.Time) (*backupv1alpha1.Backup, error) {
+	var backups backupv1alpha1.BackupList
+	if err := r.List(ctx, &backups,
+		client.InNamespace(backupPolicy.Namespace),
+		client.MatchingLabels{
+			backupv1alpha1.PolicyLabel: labelValue(backupPolicy.Name),
+			backupv1alpha1.TickLabel:   tickLabelValue(tick),
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Long policy names are shortened in the label, so check the full name too
+	for i := range backups.Items {
+		if backups.Items[i].Spec.PolicyRef == backupPolicy.Name {
+			return &backups.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// createPolicyBackup creates a Backup for the policy and marks every Nth one for
+// verification, and reports whether it was created. An existing Backup with
+// the same name is not an error, so a retried reconcile does not create a duplicate.
+func (r *BackupPolicyReconciler) createPolicyBackup(ctx context.Context, backupPolicy *backupv1alpha1.BackupPolicy, backup *backupv1alpha1.Backup) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	count := backupPolicy.Status.BackupCount + 1
+	if verification := backupPolicy.Spec.Verification; verification != nil && verification.EveryNthBackup != nil &&
+		count%int64(*verification.EveryNthBackup) == 0 {
+		metav1.SetMetaDataAnnotation(&backup.ObjectMeta, backupv1alpha1.VerifyAnnotation, r.Clock.Now().UTC().Format(time.RFC3339))
+	}
+
+	if err := r.Create(ctx, backup); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return false, err
+		}
+		log.Info("Backup already exists (race condition), continuing", "backupName", backup.Name)
+		return false, nil
+	}
+
+	backupPolicy.Status.BackupCount = count
+	return true, nil
+}
+
+// scheduleVerification requests verification of the newest completed backup
+// when the verification schedule is due. It returns how long until the next
+// scheduled verification, or zero when there is no verification schedule.
+func (r *BackupPolicyReconciler) scheduleVerification(ctx context.Context, backupPolicy *backupv1alpha1.BackupPolicy, now time.Time) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	verification := backupPolicy.Spec.Verification
+	if verification == nil || verification.Schedule == "" {
+		return 0, nil
+	}
+
+	schedule, err := cron.ParseStandard(verification.Schedule)
+	if err != nil {
+		log.Error(err, "invalid verification schedule", "schedule", verification.Schedule)
+		r.Recorder.Eventf(
+			backupPolicy,
+			corev1.EventTypeWarning,
+			"InvalidVerificationSchedule",
+			"Invalid verification schedule %q: %v",
+			verification.Schedule,
+			err,
+		)
+		return 0, nil
+	}
+
+	last := backupPolicy.CreationTimestamp.Time
+	if backupPolicy.Status.LastVerificationTime != nil {
+		last = backupPolicy.Status.LastVerificationTime.Time
+	}
+	if next := schedule.Next(last); now.Before(next) {
+		return next.Sub(now), nil
+	}
+
+	var backups backupv1alpha1.BackupList
+	if err := r.List(ctx, &backups, client.InNamespace(backupPolicy.Namespace)); err != nil {
+		return 0, err
+	}
+
+	var latest *backupv1alpha1.Backup
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		if backup.Spec.PolicyRef != backupPolicy.Name || backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
+			continue
+		}
+		if latest == nil || backup.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = backup
+		}
+	}
+
+	if latest == nil {
+		log.Info("No completed backup to verify yet")
+	} else {
+		patch := client.MergeFrom(latest.DeepCopy())
+		metav1.SetMetaDataAnnotation(&latest.ObjectMeta, backupv1alpha1.VerifyAnnotation, now.UTC().Format(time.RFC3339))
+		if err := r.Patch(ctx, latest, patch); err != nil {
+			return 0, err
+		}
+		log.Info("Requested scheduled verification", "backupName", latest.Name)
+		r.Recorder.Eventf(
+			backupPolicy,
+			corev1.EventTypeNormal,
+			"VerificationRequested",
+			"Requested verification of backup %s",
+			latest.Name,
+		)
+	}
+
+	backupPolicy.Status.LastVerificationTime = &metav1.Time{Time: now}
+	return schedule.Next(now).Sub(now), nil
+}
+
+// earliestRequeue returns the shorter of two requeue delays, ignoring unset (zero) ones
+func earliestRequeue(a, b time.Duration) time.Duration {
+	if a <= 0 {
+		return b
+	}
+	if b <= 0 || a < b {
+		return a
+	}
+	return b
+}
+
+// newPolicyBackup builds a Backup owned by the given policy, labelled with
+// the policy and its target
+func newPolicyBackup(backupPolicy *backupv1alpha1.BackupPolicy, name string) *backupv1alpha1.Backup {
 	backup := &backupv1alpha1.Backup{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      backupPolicy.Name + "-" + time.Now().Format("20060102-150405"),
+			Name:      name,
 			Namespace: backupPolicy.Namespace,
 			OwnerReferences: []metav1.OwnerReference{
 				{
@@ -156,134 +468,228 @@ func (r *BackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			},
 		},
 		Spec: backupv1alpha1.BackupSpec{
-			PolicyRef: backupPolicy.Name,
-			Target:    backupPolicy.Spec.Target,
+			PolicyRef:       backupPolicy.Name,
+			Target:          backupPolicy.Spec.Target,
+			JobTemplate:     backupPolicy.Spec.JobTemplate.DeepCopy(),
+			Throttle:        backupPolicy.Spec.Throttle.DeepCopy(),
+			Priority:        backupPolicy.Spec.Priority,
+			Retry:           backupPolicy.Spec.Retry.DeepCopy(),
+			Timeout:         backupPolicy.Spec.Timeout.DeepCopy(),
+			StorageLocation: backupPolicy.Spec.StorageLocation,
+			Compression:     backupPolicy.Spec.Compression.DeepCopy(),
 		},
 	}
-
-	if err := r.Create(ctx, backup); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			log.Error(err, "unable to create Backup")
-			return ctrl.Result{}, err
-		}
-		log.Info("Backup already exists (race condition), continuing")
+	for _, replica := range backupPolicy.Spec.Replicas {
+		backup.Spec.Replicas = append(backup.Spec.Replicas, replica.StorageLocation)
 	}
+	backup.Labels = backupLabels(backup)
+	return backup
+}
 
-	log.Info("Created scheduled Backup", "backupName", backup.Name)
-
-	r.Recorder.Eventf(
-		&backupPolicy,
-		corev1.EventTypeNormal,
-		"BackupCreated",
-		"Created backup %s",
-		backup.Name,
-	)
-
-	// Clean up old backups based on retention policy
-	if err := r.cleanupOldBackups(ctx, &backupPolicy); err != nil {
-		log.Error(err, "failed to clean up old backups")
-		// Don't fail the reconciliation, just log the error
+// policyBackups builds the Backups of one run of the policy: a single Backup
+// with the given name, or for a consistency group one member per PVC, all
+// sharing the name as their group ID
+func policyBackups(backupPolicy *backupv1alpha1.BackupPolicy, name string) []*backupv1alpha1.Backup {
+	group := backupPolicy.Spec.Group
+	if group == nil {
+		return []*backupv1alpha1.Backup{newPolicyBackup(backupPolicy, name)}
 	}
 
-	// Update status with last backup time
-	backupPolicy.Status.LastBackupTime = &metav1.Time{Time: now}
-	nextScheduledTime := schedule.Next(now)
-	backupPolicy.Status.NextScheduledBackup = &metav1.Time{Time: nextScheduledTime}
-	backupPolicy.Status.Conditions = []metav1.Condition{
-		{
-			Type:               "Ready",
-			Status:             metav1.ConditionTrue,
-			Reason:             "BackupCreated",
-			Message:            fmt.Sprintf("Backup %s created, next backup scheduled for %s", backup.Name, nextScheduledTime.Format(time.RFC3339)),
-			LastTransitionTime: metav1.Now(),
-		},
+	pvcNames := append([]string{backupPolicy.Spec.Target.PVCName}, group.PVCNames...)
+	backups := make([]*backupv1alpha1.Backup, 0, len(pvcNames))
+	for _, pvcName := range pvcNames {
+		backup := newPolicyBackup(backupPolicy, groupMemberName(name, pvcName))
+		backup.Spec.Target.PVCName = pvcName
+		backup.Spec.Group = name
+		backup.Spec.PreHook = group.PreHook.DeepCopy()
+		backup.Labels = backupLabels(backup)
+		backups = append(backups, backup)
 	}
+	return backups
+}
 
-	if err := r.Status().Update(ctx, &backupPolicy); err != nil {
-		log.Error(err, "unable to update BackupPolicy status")
-		return ctrl.Result{}, err
+// describePolicyRun names what one run of the policy created, for status messages
+func describePolicyRun(backupPolicy *backupv1alpha1.BackupPolicy, name string) string {
+	if backupPolicy.Spec.Group != nil {
+		return fmt.Sprintf("Backup group %s of %d PVCs", name, len(backupPolicy.Spec.Group.PVCNames)+1)
 	}
-
-	// Requeue for the next scheduled backup
-	requeueAfter := nextScheduledTime.Sub(time.Now())
-	log.Info("Requeuing for next backup", "nextBackupTime", nextScheduledTime, "requeueAfter", requeueAfter)
-
-	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	return "Backup " + name
 }
 
-// cleanupOldBackups deletes backups beyond the retention policy
+// cleanupOldBackups deletes completed backups that no retention rule selects
+// and failed backups beyond the failed history limit, and prunes the copies in
+// replica locations beyond each location's keepLast
 func (r *BackupPolicyReconciler) cleanupOldBackups(ctx context.Context, backupPolicy *backupv1alpha1.BackupPolicy) error {
-	log := logf.FromContext(ctx)
-
-	// If no retention policy, don't clean up
-	if backupPolicy.Spec.Retention == nil || backupPolicy.Spec.Retention.KeepLast == nil {
-		return nil
+	// Fall back to the operator's default retention; if there is none, only
+	// replica locations with their own keepLast are cleaned up
+	retention := policyRetention(backupPolicy, r.Config.Get())
+	if retention == nil {
+		if !hasReplicaRetention(backupPolicy.Spec.Replicas) {
+			return nil
+		}
+		retention = &backupv1alpha1.RetentionPolicy{}
 	}
 
-	keepLast := *backupPolicy.Spec.Retention.KeepLast
-
 	// List all backups for this policy
 	var backups backupv1alpha1.BackupList
 	if err := r.List(ctx, &backups, client.InNamespace(backupPolicy.Namespace)); err != nil {
 		return err
 	}
 
-	// Filter backups owned by this policy and that are completed
-	var ownedBackups []backupv1alpha1.Backup
-	for _, backup := range backups.Items {
-		if backup.Spec.PolicyRef == backupPolicy.Name &&
-			backup.Status.Phase == backupv1alpha1.BackupPhaseCompleted {
-			ownedBackups = append(ownedBackups, backup)
-		}
+	completedBackups, failedBackups := policyBackupsByOutcome(backups.Items, backupPolicy.Name)
+
+	var expiredBackups []backupv1alpha1.Backup
+	if hasRetentionRules(retention) {
+		_, expiredBackups = applyRetention(completedBackups, retention)
 	}
 
-	// Sort by creation time (newest first)
-	sort.Slice(ownedBackups, func(i, j int) bool {
-		return ownedBackups[i].CreationTimestamp.After(ownedBackups[j].CreationTimestamp.Time)
+	// A Backup stays while a replica location still keeps its copy
+	keptByReplicas, prunes := applyReplicaRetention(completedBackups, backupPolicy.Spec.Replicas)
+	expiredBackups = slices.DeleteFunc(expiredBackups, func(backup backupv1alpha1.Backup) bool {
+		return keptByReplicas[backup.Name]
 	})
 
-	// If we have more backups than allowed, delete the extras
-	deletedCount := 0
+	if retention.DryRun {
+		r.reportPlannedDeletions(ctx, backupPolicy, append(expiredBackups, expiredFailedBackups(failedBackups, retention)...))
+		return nil
+	}
+	backupPolicy.Status.PlannedDeletions = nil
 
-	// Delete backups beyond keepLast
-	if len(ownedBackups) > keepLast {
-		backupsToDelete := ownedBackups[keepLast:]
-		for _, backup := range backupsToDelete {
-			log.Info("Deleting old backup due to retention policy",
-				"backupName", backup.Name,
-				"keepLast", keepLast)
-
-			if err := r.Delete(ctx, &backup); err != nil {
-				log.Error(err, "failed to delete old backup", "backupName", backup.Name)
-				// Continue trying to delete others
-				continue
+	// Like the archive itself, the copies of a deleted backup stay where they are
+	for _, backup := range expiredBackups {
+		delete(prunes, backup.Name)
+	}
+	if err := r.requestReplicaPrunes(ctx, completedBackups, prunes); err != nil {
+		return err
+	}
+
+	if deleted := deleteBackups(ctx, r.Client, expiredBackups); deleted > 0 {
+		r.Recorder.Eventf(
+			backupPolicy,
+			corev1.EventTypeNormal,
+			"CleanupTriggered",
+			"Deleted %d old backups (%s)",
+			deleted,
+			describeRetention(retention),
+		)
+	}
+
+	// The Backup controller enforces the limit too, whenever a backup fails
+	pruneFailedBackups(ctx, r.Client, r.Recorder, backupPolicy, retention, failedBackups)
+	return nil
+}
+
+// pruneFailedBackups deletes the failed backups of a policy beyond its failed
+// history limit and returns the names of the backups it expired
+func pruneFailedBackups(ctx context.Context, c client.Client, recorder record.EventRecorder,
+	backupPolicy *backupv1alpha1.BackupPolicy, retention *backupv1alpha1.RetentionPolicy, failed []backupv1alpha1.Backup) []string {
+	expired := expiredFailedBackups(failed, retention)
+	if deleted := deleteBackups(ctx, c, expired); deleted > 0 {
+		recorder.Eventf(
+			backupPolicy,
+			corev1.EventTypeNormal,
+			"CleanupTriggered",
+			"Deleted %d failed backups (failedBackupsHistoryLimit=%d)",
+			deleted,
+			*retention.FailedBackupsHistoryLimit,
+		)
+	}
+	names := make([]string, 0, len(expired))
+	for _, backup := range expired {
+		names = append(names, backup.Name)
+	}
+	return names
+}
+
+// requestReplicaPrunes asks the Backup controller, through the prune-replicas
+// annotation, to remove the copies in the given locations of each backup
+func (r *BackupPolicyReconciler) requestReplicaPrunes(ctx context.Context, backups []backupv1alpha1.Backup, prunes map[string][]string) error {
+	log := logf.FromContext(ctx)
+
+	for i := range backups {
+		backup := &backups[i]
+		requested := replicasToPrune(backup)
+		added := false
+		for _, location := range prunes[backup.Name] {
+			if !requested[location] {
+				requested[location] = true
+				added = true
 			}
-			deletedCount++
+		}
+		if !added {
+			continue
+		}
 
+		patch := client.MergeFrom(backup.DeepCopy())
+		metav1.SetMetaDataAnnotation(&backup.ObjectMeta, backupv1alpha1.PruneReplicasAnnotation,
+			strings.Join(slices.Sorted(maps.Keys(requested)), ","))
+		if err := r.Patch(ctx, backup, patch); err != nil {
+			return err
 		}
+		log.Info("Requested pruning of replicas", "backupName", backup.Name, "storageLocations", prunes[backup.Name])
+	}
+	return nil
+}
+
+// reportPlannedDeletions records what retention would delete without deleting it.
+// The caller persists the policy status.
+func (r *BackupPolicyReconciler) reportPlannedDeletions(ctx context.Context, backupPolicy *backupv1alpha1.BackupPolicy, backups []backupv1alpha1.Backup) {
+	log := logf.FromContext(ctx)
+
+	planned := make([]string, 0, len(backups))
+	for _, backup := range backups {
+		planned = append(planned, backup.Name)
 	}
+	// Retention runs on every reconcile; only a changed plan is announced
+	changed := !slices.Equal(backupPolicy.Status.PlannedDeletions, planned)
+	backupPolicy.Status.PlannedDeletions = planned
 
-	// Emit ONE event if we deleted anything
-	if deletedCount > 0 {
+	log.Info("Retention dry run", "plannedDeletions", planned)
+	if changed && len(planned) > 0 {
 		r.Recorder.Eventf(
 			backupPolicy,
 			corev1.EventTypeNormal,
-			"CleanupTriggered",
-			"Deleted %d old backups (keepLast=%d)",
-			deletedCount,
-			keepLast,
+			"RetentionDryRun",
+			"Retention would delete %d backups: %s",
+			len(planned),
+			strings.Join(planned, ", "),
 		)
 	}
+}
 
-	return nil
+// deleteBackups deletes the given backups, along with their Jobs through owner
+// references, and returns how many were deleted
+func deleteBackups(ctx context.Context, c client.Client, backups []backupv1alpha1.Backup) int {
+	log := logf.FromContext(ctx)
+
+	deletedCount := 0
+	for _, backup := range backups {
+		log.Info("Deleting old backup due to retention policy",
+			"backupName", backup.Name,
+			"phase", backup.Status.Phase)
+
+		if err := c.Delete(ctx, &backup, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			log.Error(err, "failed to delete old backup", "backupName", backup.Name)
+			// Continue trying to delete others
+			continue
+		}
+		deletedCount++
+	}
+	return deletedCount
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BackupPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Recorder = mgr.GetEventRecorderFor("backuppolicy-controller")
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&backupv1alpha1.BackupPolicy{}).
 		Owns(&backupv1alpha1.Backup{}). // Watch backups owned by this policy
 		Named("backuppolicy").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.Get().MaxConcurrentReconciles[config.BackupPolicyController],
+		}).
 		Complete(r)
 }
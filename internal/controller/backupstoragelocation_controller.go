@@ -0,0 +1,418 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+// BackupStorageLocationReconciler imports Backups from the archives in a
+// storage location, so a new cluster can restore what a lost one backed up
+type BackupStorageLocationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Config holds the operator-level defaults; nil means built-in defaults
+	Config *config.Store
+
+	// Pods reads the manifests printed by sync Jobs; nil disables importing
+	Pods corev1client.PodsGetter
+
+	// Clock is used for sync timestamps; set to the real clock by SetupWithManager if nil
+	Clock clock.Clock
+
+	// ClusterID identifies this cluster; archives it wrote are never imported
+	ClusterID string
+}
+
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backupstoragelocations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backupstoragelocations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backupstoragelocations/finalizers,verbs=update
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// Reconcile scans a storage location every syncInterval with short-lived
+// Jobs that print the manifest of every complete archive, one page each, and
+// creates a read-only Backup for each archive that has none.
+func (r *BackupStorageLocationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var location backupv1alpha1.BackupStorageLocation
+	if err := r.Get(ctx, req.NamespacedName, &location); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	interval := syncInterval(&location)
+	if interval == 0 || r.Pods == nil {
+		base := location.DeepCopy()
+		setConditions(&location.Status.Conditions, location.Generation, "SyncDisabled",
+			"Archives are written here but not imported",
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+		return ctrl.Result{}, patchLocationStatus(ctx, r.Client, &location, base)
+	}
+
+	scan := location.Status.Scan
+	if scan == nil {
+		scan = &backupv1alpha1.LocationScan{Page: 1}
+	}
+
+	var job batchv1.Job
+	err := r.Get(ctx, client.ObjectKey{Name: syncJobName(&location, scan.Page), Namespace: location.Namespace}, &job)
+	if apierrors.IsNotFound(err) {
+		if location.Status.Scan == nil && location.Status.LastSyncTime != nil && location.Status.ObservedGeneration == location.Generation {
+			if wait := location.Status.LastSyncTime.Add(interval).Sub(r.Clock.Now()); wait > 0 {
+				return ctrl.Result{RequeueAfter: wait}, nil
+			}
+		}
+		if err := r.Create(ctx, r.createSyncJob(&location, scan)); err != nil && !apierrors.IsAlreadyExists(err) {
+			log.Error(err, "unable to create sync Job")
+			return ctrl.Result{}, err
+		}
+		base := location.DeepCopy()
+		setCondition(&location.Status.Conditions, location.Generation, backupv1alpha1.ConditionProgressing,
+			metav1.ConditionTrue, "Syncing", "Scanning "+location.Spec.PVCName+" for archives")
+		return ctrl.Result{}, patchLocationStatus(ctx, r.Client, &location, base)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The Job is owned by the location, so it is reconciled again when the Job finishes
+	if failed := jobCondition(&job, batchv1.JobFailed); failed != nil {
+		message := describeJobFailure(ctx, r.Pods, &job, failed, r.Config.Get().FailureLogLines())
+		return r.finishSync(ctx, &location, &job, nil, fmt.Errorf("sync Job failed: %s", message))
+	}
+	if jobCondition(&job, batchv1.JobComplete) == nil {
+		return ctrl.Result{}, nil
+	}
+
+	logs, err := jobLogs(ctx, r.Pods, &job, "sync", 0, syncLogReadBytes)
+	if err != nil {
+		return r.finishSync(ctx, &location, &job, nil, err)
+	}
+	page, err := parseSyncPage(logs)
+	if err != nil {
+		return r.finishSync(ctx, &location, &job, nil, fmt.Errorf("reading page %d of the scan: %w", scan.Page, err))
+	}
+	for _, err := range page.errs {
+		log.Error(err, "skipping archive")
+	}
+	imported, err := r.importBackups(ctx, &location, page.manifests)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result := &backupv1alpha1.LocationScan{
+		Page:     scan.Page + 1,
+		Offset:   page.next,
+		Archives: scan.Archives + int32(len(page.manifests)),
+		Imported: scan.Imported + int32(imported),
+	}
+	if page.next > 0 {
+		return r.nextSyncPage(ctx, &location, &job, result)
+	}
+	return r.finishSync(ctx, &location, &job, result, nil)
+}
+
+// nextSyncPage records how far the scan got and replaces the Job of the page
+// just read with the Job of the next one
+func (r *BackupStorageLocationReconciler) nextSyncPage(ctx context.Context, location *backupv1alpha1.BackupStorageLocation,
+	job *batchv1.Job, scan *backupv1alpha1.LocationScan) (ctrl.Result, error) {
+	base := location.DeepCopy()
+	location.Status.Scan = scan
+	if err := patchLocationStatus(ctx, r.Client, location, base); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Create(ctx, r.createSyncJob(location, scan)); err != nil && !apierrors.IsAlreadyExists(err) {
+		return ctrl.Result{}, err
+	}
+	logf.FromContext(ctx).Info("Reading next page of storage location", "page", scan.Page, "offset", scan.Offset)
+	return ctrl.Result{}, nil
+}
+
+// finishSync records the outcome of a scan, then deletes its last Job; the
+// next scan starts syncInterval after this one. result holds the totals of a
+// successful scan.
+func (r *BackupStorageLocationReconciler) finishSync(ctx context.Context, location *backupv1alpha1.BackupStorageLocation,
+	job *batchv1.Job, result *backupv1alpha1.LocationScan, syncErr error) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	base := location.DeepCopy()
+	now := metav1.NewTime(r.Clock.Now())
+	location.Status.LastSyncTime = &now
+	location.Status.Scan = nil
+	if syncErr != nil {
+		log.Error(syncErr, "storage location sync failed")
+		setConditions(&location.Status.Conditions, location.Generation, "SyncFailed", syncErr.Error(),
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+		r.Recorder.Event(
+			location,
+			corev1.EventTypeWarning,
+			"SyncFailed",
+			eventMessage(syncErr.Error()),
+		)
+	} else {
+		location.Status.ArchiveCount = result.Archives
+		location.Status.ImportedBackups = result.Imported
+		setConditions(&location.Status.Conditions, location.Generation, "Synced",
+			fmt.Sprintf("Found %d archives, imported %d Backups", result.Archives, result.Imported),
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+		if result.Imported > 0 {
+			r.Recorder.Eventf(
+				location,
+				corev1.EventTypeNormal,
+				"BackupsImported",
+				"Imported %d Backups from %s",
+				result.Imported,
+				location.Spec.PVCName,
+			)
+		}
+	}
+	if err := patchLocationStatus(ctx, r.Client, location, base); err != nil {
+		log.Error(err, "unable to update BackupStorageLocation status")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: syncInterval(location)}, nil
+}
+
+// importBackups creates a read-only Backup for every manifest whose Backup
+// does not exist in the location's namespace and returns how many it created.
+// Archives written by this cluster are skipped: their Backup was deleted
+// here, e.g. by retention, and importing it again would bring it back.
+func (r *BackupStorageLocationReconciler) importBackups(ctx context.Context, location *backupv1alpha1.BackupStorageLocation,
+	manifests []backupManifest) (int, error) {
+	log := logf.FromContext(ctx)
+
+	imported := 0
+	for i := range manifests {
+		manifest := &manifests[i]
+		if r.ClusterID != "" && manifest.ClusterID == r.ClusterID {
+			continue
+		}
+		var existing backupv1alpha1.Backup
+		err := r.Get(ctx, client.ObjectKey{Name: manifest.Backup.Name, Namespace: location.Namespace}, &existing)
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return imported, err
+		}
+
+		backup := importedBackup(location, manifest)
+		status := backup.Status
+		if err := r.Create(ctx, backup); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				continue
+			}
+			return imported, err
+		}
+		// Status is a subresource and is dropped on create
+		base := backup.DeepCopy()
+		backup.Status = status
+		setConditions(&backup.Status.Conditions, backup.Generation, "Imported",
+			"Imported from storage location "+location.Name,
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+		if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+			return imported, err
+		}
+		log.Info("Imported Backup", "backup", backup.Name)
+		imported++
+	}
+	return imported, nil
+}
+
+// importedBackup recreates a completed Backup from its manifest. It keeps the
+// original name, so the archive is found where the restore Job expects it,
+// and is owned by the location so removing the location removes it too.
+func importedBackup(location *backupv1alpha1.BackupStorageLocation, manifest *backupManifest) *backupv1alpha1.Backup {
+	labels := map[string]string{}
+	for key, value := range manifest.Backup.Labels {
+		labels[key] = value
+	}
+	labels[backupv1alpha1.OriginLabel] = backupv1alpha1.OriginImported
+
+	spec := *manifest.Backup.Spec.DeepCopy()
+	spec.StorageLocation = location.Name
+	spec.Cancel = false
+	// Copies are made by the cluster that wrote the archive
+	spec.Replicas = nil
+
+	completion := manifest.CompletionTime
+	backup := &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        manifest.Backup.Name,
+			Namespace:   location.Namespace,
+			Labels:      labels,
+			Annotations: map[string]string{backupv1alpha1.ImportedSpecAnnotation: importedSpec(&spec)},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: backupv1alpha1.GroupVersion.String(),
+				Kind:       "BackupStorageLocation",
+				Name:       location.Name,
+				UID:        location.UID,
+			}},
+		},
+		Spec: spec,
+		Status: backupv1alpha1.BackupStatus{
+			Phase:          backupv1alpha1.BackupPhaseCompleted,
+			StartTime:      manifest.Backup.Status.StartTime,
+			CompletionTime: &completion,
+			BackupLocation: "/backups/" + manifest.Backup.Name + formatOf(&manifest.Backup).Extension(),
+			Size:           resource.NewQuantity(manifest.Size, resource.BinarySI),
+			DataSize:       resource.NewQuantity(manifest.DataSize, resource.BinarySI),
+			FileCount:      manifest.FileCount,
+			SourcePVC:      recordedSourcePVC(manifest.SourcePVC),
+			Attempts:       manifest.Backup.Status.Attempts,
+		},
+	}
+	return backup
+}
+
+// createSyncJob builds the Job that prints a page of the manifests of the
+// archives in the location: those after the scan's offset, up to
+// syncPageBytes, followed by the page line. Archives without a manifest are
+// incomplete or were written before manifests existed, and are skipped.
+// Archives added or removed while a scan runs can shift the pages; an archive
+// skipped that way is imported by the next scan.
+func (r *BackupStorageLocationReconciler) createSyncJob(location *backupv1alpha1.BackupStorageLocation, scan *backupv1alpha1.LocationScan) *batchv1.Job {
+	cfg := r.Config.Get()
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      syncJobName(location, scan.Page),
+			Namespace: location.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: backupv1alpha1.GroupVersion.String(),
+				Kind:       "BackupStorageLocation",
+				Name:       location.Name,
+				UID:        location.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "sync",
+							Image: cfg.MoverImage,
+							Command: []string{
+								"sh",
+								"-c",
+								"cd /backups && index=0 && printed=0 && bytes=0 && for manifest in *.json; do " +
+									"[ -f \"$manifest\" ] && " + anyArchiveTest() + " || continue; " +
+									"index=$((index + 1)); " +
+									"[ \"$index\" -gt " + strconv.Itoa(int(scan.Offset)) + " ] || continue; " +
+									"size=$(wc -c < \"$manifest\"); " +
+									"if [ \"$printed\" -gt 0 ] && [ $((bytes + size)) -gt " + strconv.Itoa(syncPageBytes) + " ]; then " +
+									"echo \"" + pagePrefix + "$printed next $((index - 1))\"; exit 0; fi; " +
+									"printf '" + manifestPrefix + "'; tr -d '\\n' < \"$manifest\"; echo; " +
+									"printed=$((printed + 1)); bytes=$((bytes + size)); " +
+									"done; echo \"" + pagePrefix + "$printed end\"",
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "backup-storage",
+									MountPath: "/backups",
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "backup-storage",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: location.Spec.PVCName,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	applyJobTemplates(&job.Spec.Template.Spec, cfg.DefaultJobTemplate)
+	applyJobLimits(job, nil, cfg.JobTTL())
+	useFallbackTerminationMessages(&job.Spec.Template.Spec)
+	return job
+}
+
+// syncInterval returns how often a location is scanned; 0 disables importing
+func syncInterval(location *backupv1alpha1.BackupStorageLocation) time.Duration {
+	if location.Spec.SyncInterval == nil {
+		return 0
+	}
+	return location.Spec.SyncInterval.Duration
+}
+
+// syncJobName is the name of the Job reading a page of a storage location.
+// The first page keeps the plain name.
+func syncJobName(location *backupv1alpha1.BackupStorageLocation, page int32) string {
+	if page <= 1 {
+		return boundedName(location.Name+"-sync", maxJobNameLength)
+	}
+	return boundedName(fmt.Sprintf("%s-sync-%d", location.Name, page), maxJobNameLength)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupStorageLocationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor("backupstoragelocation-controller")
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&backupv1alpha1.BackupStorageLocation{}).
+		Owns(&batchv1.Job{}).
+		Named("backupstoragelocation").
+		Complete(r)
+}
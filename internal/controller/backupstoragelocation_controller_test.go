@@ -0,0 +1,543 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	fakerest "k8s.io/client-go/rest/fake"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+func TestParseManifests(t *testing.T) {
+	logs := strings.Join([]string{
+		`manifest {"version":1,"checksum":"abc","size":10,"dataSize":20,"fileCount":3,"completionTime":"2026-03-01T02:00:06Z",` +
+			`"backup":{"metadata":{"name":"nightly-1","namespace":"prod"},"spec":{"target":{"pvcName":"data"}}},"sourcePVC":null}`,
+		`manifest {"version":2,"backup":{"metadata":{"name":"future"}}}`,
+		`manifest {"version":1,`,
+		`some other output`,
+		``,
+	}, "\n")
+
+	manifests, errs := parseManifests(logs)
+	if len(manifests) != 1 || len(errs) != 2 {
+		t.Fatalf("parseManifests() = %d manifests, %d errors (%v); want 1, 2", len(manifests), len(errs), errs)
+	}
+	manifest := manifests[0]
+	if manifest.Backup.Name != "nightly-1" || manifest.Size != 10 || manifest.DataSize != 20 || manifest.FileCount != 3 ||
+		manifest.Checksum != "abc" || manifest.SourcePVC != nil {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	if !manifest.CompletionTime.Time.Equal(time.Date(2026, 3, 1, 2, 0, 6, 0, time.UTC)) {
+		t.Errorf("completionTime = %v", manifest.CompletionTime)
+	}
+}
+
+func TestParseSyncPage(t *testing.T) {
+	manifest := `manifest {"version":1,"backup":{"metadata":{"name":"nightly-1"}}}`
+	tests := []struct {
+		name      string
+		logs      string
+		manifests int
+		next      int32
+		wantErr   bool
+	}{
+		{name: "last page", logs: manifest + "\npage 1 end\n", manifests: 1},
+		{name: "more pages", logs: manifest + "\n" + manifest + "\npage 2 next 7\n", manifests: 2, next: 7},
+		{name: "empty location", logs: "page 0 end\n"},
+		{name: "bad manifests are counted", logs: "manifest {\npage 1 end", manifests: 0},
+		{name: "cut before the page line", logs: manifest + "\n" + manifest[:20], wantErr: true},
+		{name: "lines lost", logs: manifest + "\npage 2 end\n", wantErr: true},
+		{name: "empty log", logs: "", wantErr: true},
+		{name: "unknown page line", logs: "page two end\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := parseSyncPage(tt.logs)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSyncPage() = %+v, want an error", page)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page.manifests) != tt.manifests || page.next != tt.next {
+				t.Errorf("parseSyncPage() = %d manifests, next %d; want %d, next %d", len(page.manifests), page.next, tt.manifests, tt.next)
+			}
+		})
+	}
+}
+
+func TestBackupJobWritesManifest(t *testing.T) {
+	backup := &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+		Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+	}
+	storageClass := "fast"
+	job := (&BackupReconciler{ClusterID: "cluster-uid"}).createBackupJob(backup, 1, "offsite", &corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClass})
+
+	container := job.Spec.Template.Spec.Containers[0]
+	if script := container.Command[2]; !strings.Contains(script, "> nightly.json.tmp && mv nightly.json.tmp nightly.json") {
+		t.Errorf("backup script does not write the manifest: %s", script)
+	}
+	env := map[string]string{}
+	for _, v := range container.Env {
+		env[v.Name] = v.Value
+	}
+	if !strings.Contains(env[backupManifestEnv], `"name":"nightly"`) || env[sourcePVCManifestEnv] != `{"resources":{},"storageClassName":"fast"}` ||
+		env[clusterIDManifestEnv] != "cluster-uid" {
+		t.Errorf("manifest env = %v", env)
+	}
+	if claim := job.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName; claim != "offsite" {
+		t.Errorf("storage PVC = %s, want offsite", claim)
+	}
+}
+
+func TestStoragePVCName(t *testing.T) {
+	ctx := context.Background()
+	c := newTransitionClient(t, &backupv1alpha1.BackupStorageLocation{
+		ObjectMeta: metav1.ObjectMeta{Name: "offsite", Namespace: "default"},
+		Spec:       backupv1alpha1.BackupStorageLocationSpec{PVCName: "offsite-storage"},
+	})
+	cfg := config.Default()
+
+	if got, err := storagePVCName(ctx, c, cfg, "default", ""); err != nil || got != cfg.DefaultStoragePVC {
+		t.Errorf("storagePVCName(\"\") = %q, %v; want %q", got, err, cfg.DefaultStoragePVC)
+	}
+	if got, err := storagePVCName(ctx, c, cfg, "default", "offsite"); err != nil || got != "offsite-storage" {
+		t.Errorf("storagePVCName(offsite) = %q, %v; want offsite-storage", got, err)
+	}
+	if _, err := storagePVCName(ctx, c, cfg, "default", "missing"); !apierrors.IsNotFound(err) {
+		t.Errorf("storagePVCName(missing) error = %v, want NotFound", err)
+	}
+}
+
+func TestBackupStorageLocationSync(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "offsite", Namespace: "default"}
+	clock := clocktesting.NewFakeClock(transitionStart)
+	recorder := record.NewFakeRecorder(100)
+	syncPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "offsite-sync-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{batchv1.JobNameLabel: "offsite-sync"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	r := &BackupStorageLocationReconciler{
+		Client: newTransitionClient(t, &backupv1alpha1.BackupStorageLocation{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Generation: 1},
+			Spec: backupv1alpha1.BackupStorageLocationSpec{
+				PVCName:      "offsite-storage",
+				SyncInterval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		}),
+		Recorder: recorder,
+		Clock:    clock,
+		Pods:     withLogs(fake.NewClientset(syncPod).CoreV1(), map[string]string{syncPod.Name: "page 0 end\n"}),
+	}
+	fetch := func() *backupv1alpha1.BackupStorageLocation {
+		t.Helper()
+		var location backupv1alpha1.BackupStorageLocation
+		if err := r.Get(ctx, key, &location); err != nil {
+			t.Fatal(err)
+		}
+		return &location
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: "offsite-sync", Namespace: "default"}, &job); err != nil {
+		t.Fatalf("sync Job not created: %v", err)
+	}
+	if claim := job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim; claim.ClaimName != "offsite-storage" || !claim.ReadOnly {
+		t.Errorf("sync Job volume = %+v, want offsite-storage read-only", claim)
+	}
+
+	// The page holds no manifests, so the scan finds nothing
+	setJobCondition(t, r.Client, "offsite-sync", batchv1.JobComplete, clock.Now())
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 5*time.Minute {
+		t.Errorf("RequeueAfter = %v, want the 5m interval", result.RequeueAfter)
+	}
+	location := fetch()
+	if location.Status.LastSyncTime == nil || !meta.IsStatusConditionTrue(location.Status.Conditions, backupv1alpha1.ConditionReady) {
+		t.Errorf("status = %+v, want a successful sync", location.Status)
+	}
+	expectJobGone(t, r.Client, "offsite-sync")
+
+	// The next scan waits for the interval
+	clock.Step(time.Minute)
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 4*time.Minute {
+		t.Errorf("RequeueAfter = %v, want the rest of the interval", result.RequeueAfter)
+	}
+	expectJobGone(t, r.Client, "offsite-sync")
+}
+
+func TestBackupStorageLocationSyncPages(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "offsite", Namespace: "default"}
+	clock := clocktesting.NewFakeClock(transitionStart)
+	syncPod := func(job string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      job + "-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{batchv1.JobNameLabel: job},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		}
+	}
+	manifest := func(name string) string {
+		return fmt.Sprintf(`manifest {"version":1,"backup":{"metadata":{"name":%q,"namespace":"prod"},`+
+			`"spec":{"target":{"pvcName":"data"}}}}`, name)
+	}
+	logs := map[string]string{
+		"offsite-sync-abcde":   manifest("nightly-1") + "\n" + manifest("nightly-2") + "\npage 2 next 2\n",
+		"offsite-sync-2-abcde": manifest("nightly-3") + "\npage 1 end\n",
+	}
+	r := &BackupStorageLocationReconciler{
+		Client: newTransitionClient(t, &backupv1alpha1.BackupStorageLocation{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Generation: 1},
+			Spec: backupv1alpha1.BackupStorageLocationSpec{
+				PVCName:      "offsite-storage",
+				SyncInterval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		}),
+		Recorder: record.NewFakeRecorder(100),
+		Clock:    clock,
+		Pods:     withLogs(fake.NewClientset(syncPod("offsite-sync"), syncPod("offsite-sync-2")).CoreV1(), logs),
+	}
+	fetch := func() *backupv1alpha1.BackupStorageLocation {
+		t.Helper()
+		var location backupv1alpha1.BackupStorageLocation
+		if err := r.Get(ctx, key, &location); err != nil {
+			t.Fatal(err)
+		}
+		return &location
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+
+	// The first page imports its Backups and starts the Job of the next one
+	setJobCondition(t, r.Client, "offsite-sync", batchv1.JobComplete, clock.Now())
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	expectJobGone(t, r.Client, "offsite-sync")
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: "offsite-sync-2", Namespace: "default"}, &job); err != nil {
+		t.Fatalf("Job of the second page not created: %v", err)
+	}
+	if script := job.Spec.Template.Spec.Containers[0].Command[2]; !strings.Contains(script, `[ "$index" -gt 2 ]`) {
+		t.Errorf("second page does not skip the first one: %s", script)
+	}
+	scan := fetch().Status.Scan
+	if scan == nil || *scan != (backupv1alpha1.LocationScan{Page: 2, Offset: 2, Archives: 2, Imported: 2}) {
+		t.Errorf("scan = %+v, want page 2 after 2 archives", scan)
+	}
+
+	// The last page completes the scan with the totals of all pages
+	setJobCondition(t, r.Client, "offsite-sync-2", batchv1.JobComplete, clock.Now())
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequeueAfter != 5*time.Minute {
+		t.Errorf("RequeueAfter = %v, want the 5m interval", result.RequeueAfter)
+	}
+	expectJobGone(t, r.Client, "offsite-sync-2")
+	location := fetch()
+	if location.Status.Scan != nil || location.Status.ArchiveCount != 3 || location.Status.ImportedBackups != 3 ||
+		!meta.IsStatusConditionTrue(location.Status.Conditions, backupv1alpha1.ConditionReady) {
+		t.Errorf("status = %+v, want 3 archives imported", location.Status)
+	}
+	for _, name := range []string{"nightly-1", "nightly-2", "nightly-3"} {
+		var backup backupv1alpha1.Backup
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &backup); err != nil {
+			t.Errorf("Backup %s not imported: %v", name, err)
+		}
+	}
+}
+
+func TestBackupStorageLocationSyncTruncated(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "offsite", Namespace: "default"}
+	clock := clocktesting.NewFakeClock(transitionStart)
+	syncPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "offsite-sync-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{batchv1.JobNameLabel: "offsite-sync"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	// The log is longer than the operator reads, so its page line is cut off
+	manifest := `manifest {"version":1,"backup":{"metadata":{"name":"nightly-1"},"spec":{"target":{"pvcName":"data"}}}}` + "\n"
+	logs := strings.Repeat(manifest, syncLogReadBytes/len(manifest)+1) + "page 1 end\n"
+	recorder := record.NewFakeRecorder(100)
+	r := &BackupStorageLocationReconciler{
+		Client: newTransitionClient(t, &backupv1alpha1.BackupStorageLocation{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Generation: 1},
+			Spec: backupv1alpha1.BackupStorageLocationSpec{
+				PVCName:      "offsite-storage",
+				SyncInterval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		}),
+		Recorder: recorder,
+		Clock:    clock,
+		Pods:     withLogs(fake.NewClientset(syncPod).CoreV1(), map[string]string{syncPod.Name: logs}),
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	setJobCondition(t, r.Client, "offsite-sync", batchv1.JobComplete, clock.Now())
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+
+	var location backupv1alpha1.BackupStorageLocation
+	if err := r.Get(ctx, key, &location); err != nil {
+		t.Fatal(err)
+	}
+	degraded := meta.FindStatusCondition(location.Status.Conditions, backupv1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || !strings.Contains(degraded.Message, "truncated") {
+		t.Errorf("Degraded = %+v, want a truncated log reported", degraded)
+	}
+	// Nothing is imported from a page that cannot be trusted
+	var backup backupv1alpha1.Backup
+	if err := r.Get(ctx, types.NamespacedName{Name: "nightly-1", Namespace: "default"}, &backup); !apierrors.IsNotFound(err) {
+		t.Errorf("Backup imported from a truncated page: %v", err)
+	}
+}
+
+// logPods serves the given logs per pod name from GetLogs, honouring
+// LimitBytes like the API server does
+type logPods struct {
+	corev1client.PodsGetter
+	logs map[string]string
+}
+
+func withLogs(pods corev1client.PodsGetter, logs map[string]string) corev1client.PodsGetter {
+	return &logPods{PodsGetter: pods, logs: logs}
+}
+
+func (p *logPods) Pods(namespace string) corev1client.PodInterface {
+	return &logPodInterface{PodInterface: p.PodsGetter.Pods(namespace), namespace: namespace, logs: p.logs}
+}
+
+type logPodInterface struct {
+	corev1client.PodInterface
+	namespace string
+	logs      map[string]string
+}
+
+func (p *logPodInterface) GetLogs(name string, opts *corev1.PodLogOptions) *rest.Request {
+	logs := p.logs[name]
+	if opts.LimitBytes != nil && int64(len(logs)) > *opts.LimitBytes {
+		logs = logs[:*opts.LimitBytes]
+	}
+	restClient := &fakerest.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		GroupVersion:         corev1.SchemeGroupVersion,
+		Client: fakerest.CreateHTTPClient(func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(logs))}, nil
+		}),
+	}
+	return restClient.Get().Namespace(p.namespace).Resource("pods").Name(name).SubResource("log")
+}
+
+func TestBackupStorageLocationSyncDisabled(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "offsite", Namespace: "default"}
+	r := &BackupStorageLocationReconciler{
+		Client: newTransitionClient(t, &backupv1alpha1.BackupStorageLocation{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       backupv1alpha1.BackupStorageLocationSpec{PVCName: "offsite-storage"},
+		}),
+		Recorder: record.NewFakeRecorder(100),
+		Clock:    clocktesting.NewFakeClock(transitionStart),
+		Pods:     fake.NewClientset().CoreV1(),
+	}
+
+	// Without a syncInterval the location is only written to
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	expectJobGone(t, r.Client, "offsite-sync")
+	var location backupv1alpha1.BackupStorageLocation
+	if err := r.Get(ctx, key, &location); err != nil {
+		t.Fatal(err)
+	}
+	if ready := meta.FindStatusCondition(location.Status.Conditions, backupv1alpha1.ConditionReady); ready == nil || ready.Reason != "SyncDisabled" {
+		t.Errorf("Ready = %+v, want SyncDisabled", ready)
+	}
+}
+
+func TestClusterID(t *testing.T) {
+	namespaces := fake.NewClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceSystem, UID: "cluster-uid"},
+	}).CoreV1()
+	if id, err := ClusterID(context.Background(), namespaces); err != nil || id != "cluster-uid" {
+		t.Errorf("ClusterID() = %q, %v; want the kube-system UID", id, err)
+	}
+}
+
+func TestImportBackups(t *testing.T) {
+	ctx := context.Background()
+	location := &backupv1alpha1.BackupStorageLocation{
+		ObjectMeta: metav1.ObjectMeta{Name: "offsite", Namespace: "default", UID: "location-uid"},
+		Spec:       backupv1alpha1.BackupStorageLocationSpec{PVCName: "offsite-storage"},
+	}
+	existing := &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-1", Namespace: "default"},
+		Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+	}
+	c := newTransitionClient(t, location, existing)
+	r := &BackupStorageLocationReconciler{Client: c, ClusterID: "this-cluster"}
+
+	started := metav1.NewTime(transitionStart)
+	manifest := func(name string) backupManifest {
+		return backupManifest{
+			Version:        manifestVersion,
+			Size:           1024,
+			DataSize:       4096,
+			FileCount:      12,
+			CompletionTime: metav1.NewTime(transitionStart.Add(time.Minute)),
+			Backup: backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod", Labels: map[string]string{backupv1alpha1.PolicyLabel: "nightly"}},
+				Spec: backupv1alpha1.BackupSpec{
+					PolicyRef: "nightly",
+					Target:    backupv1alpha1.BackupTarget{PVCName: "data"},
+					TTL:       &metav1.Duration{Duration: time.Hour},
+					Cancel:    true,
+				},
+				Status: backupv1alpha1.BackupStatus{StartTime: &started, Attempts: 1},
+			},
+		}
+	}
+
+	// nightly-3 was written by this cluster and deleted here since, e.g. by retention
+	own := manifest("nightly-3")
+	own.ClusterID = "this-cluster"
+	lost := manifest("nightly-2")
+	lost.ClusterID = "lost-cluster"
+	imported, err := r.importBackups(ctx, location, []backupManifest{manifest("nightly-1"), lost, own})
+	if err != nil || imported != 1 {
+		t.Fatalf("importBackups() = %d, %v; want 1 new Backup", imported, err)
+	}
+	var deleted backupv1alpha1.Backup
+	if err := c.Get(ctx, types.NamespacedName{Name: "nightly-3", Namespace: "default"}, &deleted); !apierrors.IsNotFound(err) {
+		t.Errorf("an archive written by this cluster was imported: %v", err)
+	}
+
+	var backup backupv1alpha1.Backup
+	if err := c.Get(ctx, types.NamespacedName{Name: "nightly-2", Namespace: "default"}, &backup); err != nil {
+		t.Fatal(err)
+	}
+	if !isImported(&backup) || backup.Labels[backupv1alpha1.PolicyLabel] != "nightly" {
+		t.Errorf("labels = %v", backup.Labels)
+	}
+	if backup.Spec.StorageLocation != "offsite" || backup.Spec.Cancel || backup.OwnerReferences[0].UID != "location-uid" {
+		t.Errorf("imported Backup = %+v", backup)
+	}
+	status := backup.Status
+	if status.Phase != backupv1alpha1.BackupPhaseCompleted || status.Size.Value() != 1024 || status.DataSize.Value() != 4096 ||
+		status.FileCount != 12 || status.BackupLocation != "/backups/nightly-2.tar.gz" || !status.StartTime.Equal(&started) {
+		t.Errorf("imported status = %+v", status)
+	}
+
+	// The Backup reconciler leaves imported Backups alone: no Job, no expiry
+	recorder := record.NewFakeRecorder(100)
+	backups := &BackupReconciler{
+		Client:   c,
+		Recorder: recorder,
+		Clock:    clocktesting.NewFakeClock(transitionStart.Add(24 * time.Hour)),
+	}
+	result, err := backups.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&backup)})
+	expectNoRequeue(t, result, err)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(&backup), &backup); err != nil {
+		t.Fatalf("imported Backup should not expire: %v", err)
+	}
+	expectJobGone(t, c, "nightly-2-job")
+	expectEvents(t, recorder)
+
+	// Edits that would make the operator expire or run it are reverted
+	backup.Spec.TTL = &metav1.Duration{Duration: time.Minute}
+	backup.Spec.Target.PVCName = "other"
+	delete(backup.Labels, backupv1alpha1.OriginLabel)
+	if err := c.Update(ctx, &backup); err != nil {
+		t.Fatal(err)
+	}
+	result, err = backups.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&backup)})
+	expectNoRequeue(t, result, err)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(&backup), &backup); err != nil {
+		t.Fatal(err)
+	}
+	if !isImported(&backup) || backup.Spec.TTL.Duration != time.Hour || backup.Spec.Target.PVCName != "data" {
+		t.Errorf("imported Backup = %+v, want the edits reverted", backup)
+	}
+	expectEvents(t, recorder, "ReadOnly")
+
+	// Holding it is allowed
+	backup.Spec.Hold = true
+	if err := c.Update(ctx, &backup); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backups.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&backup)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(&backup), &backup); err != nil {
+		t.Fatal(err)
+	}
+	if !backup.Spec.Hold {
+		t.Errorf("hold on an imported Backup was reverted")
+	}
+	expectEvents(t, recorder)
+}
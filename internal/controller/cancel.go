@@ -0,0 +1,296 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+)
+
+// reconcileBackupCancel handles spec.cancel on a Backup that has not finished.
+// It reports whether reconciliation should stop because the Backup is being,
+// or has been, cancelled. Every step waits for a Job watch event, so nothing
+// is polled.
+func (r *BackupReconciler) reconcileBackupCancel(ctx context.Context, backup *backupv1alpha1.Backup) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if !backup.Spec.Cancel || backupFinished(backup.Status.Phase) {
+		return false, nil
+	}
+
+	// Stop the mover first, so it cannot write the archive after it is removed
+	jobName := attemptJobName(backupJobName(backup), max(backup.Status.Attempts, 1))
+	gone, err := stopJob(ctx, r.Client, backup.Namespace, jobName)
+	if errors.Is(err, errJobSucceeded) {
+		// Too late to cancel: the archive is complete and is recorded as usual
+		log.Info("Backup Job already succeeded, ignoring cancel", "jobName", jobName)
+		return false, nil
+	}
+	if err != nil {
+		log.Error(err, "unable to delete Backup Job", "jobName", jobName)
+		return true, err
+	}
+	if !gone {
+		return true, r.markBackupCancelling(ctx, backup, "Waiting for backup Job "+jobName+" to stop")
+	}
+
+	// Remove whatever the mover wrote, unless it never ran
+	cleanup := "no archive had been written"
+	if backup.Status.Attempts > 0 {
+		finished, err := r.cleanupPartialArchive(ctx, backup)
+		if err != nil {
+			log.Error(err, "unable to clean up partial archive")
+			return true, err
+		}
+		if finished == "" {
+			return true, r.markBackupCancelling(ctx, backup, "Removing the partial archive from storage")
+		}
+		cleanup = "the partial archive was removed"
+		if finished == batchv1.JobFailed {
+			cleanup = "removing the partial archive failed, " + archive.Name(backup) + " may remain in storage"
+		}
+	}
+
+	base := backup.DeepCopy()
+	backup.Status.Phase = backupv1alpha1.BackupPhaseCancelled
+	now := metav1.NewTime(r.Clock.Now())
+	backup.Status.CompletionTime = &now
+	backup.Status.Progress = nil
+	message := "Backup cancelled; " + cleanup
+	setConditions(&backup.Status.Conditions, backup.Generation, "Cancelled", message,
+		metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionFalse)
+	if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+		log.Error(err, "unable to update Backup status to Cancelled")
+		return true, err
+	}
+	r.Recorder.Event(
+		backup,
+		corev1.EventTypeNormal,
+		"BackupCancelled",
+		message,
+	)
+	return true, nil
+}
+
+// markBackupCancelling records a cancellation in progress, once per step
+func (r *BackupReconciler) markBackupCancelling(ctx context.Context, backup *backupv1alpha1.Backup, message string) error {
+	if progressing := meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionProgressing); progressing != nil &&
+		progressing.Reason == "Cancelling" && progressing.Message == message {
+		return nil
+	}
+	base := backup.DeepCopy()
+	setCondition(&backup.Status.Conditions, backup.Generation, backupv1alpha1.ConditionProgressing,
+		metav1.ConditionTrue, "Cancelling", message)
+	return patchBackupStatus(ctx, r.Client, backup, base)
+}
+
+// cleanupPartialArchive runs a Job that deletes the Backup's archive and
+// checksum from storage and returns how it finished, or "" while it runs
+func (r *BackupReconciler) cleanupPartialArchive(ctx context.Context, backup *backupv1alpha1.Backup) (batchv1.JobConditionType, error) {
+	var job batchv1.Job
+	err := r.Get(ctx, client.ObjectKey{Name: cleanupJobName(backup), Namespace: backup.Namespace}, &job)
+	if apierrors.IsNotFound(err) {
+		storagePVC, err := storagePVCName(ctx, r.Client, r.Config.Get(), backup.Namespace, backup.Spec.StorageLocation)
+		if err != nil {
+			return "", err
+		}
+		if err := r.Create(ctx, r.createCleanupJob(backup, cleanupJobName(backup), storagePVC)); err != nil && !apierrors.IsAlreadyExists(err) {
+			return "", err
+		}
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case jobCondition(&job, batchv1.JobComplete) != nil:
+		return batchv1.JobComplete, nil
+	case jobCondition(&job, batchv1.JobFailed) != nil:
+		return batchv1.JobFailed, nil
+	}
+	return "", nil
+}
+
+// createCleanupJob builds a Job that removes the Backup's archive and the
+// files written next to it from storagePVC. It cleans up after cancelled
+// backups and prunes copies from replica locations.
+func (r *BackupReconciler) createCleanupJob(backup *backupv1alpha1.Backup, name, storagePVC string) *batchv1.Job {
+	cfg := r.Config.Get()
+	archivePath := "/backup-output/" + archive.Name(backup)
+	manifest := "/backup-output/" + backup.Name + ".json"
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       backup.Namespace,
+			Labels:          backupLabels(backup),
+			OwnerReferences: backupOwnerReferences(backup),
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "cleanup",
+							Image: cfg.MoverImage,
+							Command: []string{
+								"sh",
+								"-c",
+								"rm -f " + archivePath + " " + archivePath + ".sha256 " + manifest + " " + manifest + ".tmp && " +
+									"echo 'Removed archive " + archive.Name(backup) + "'",
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "backup-output",
+									MountPath: "/backup-output",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "backup-output",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: storagePVC,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	applyJobTemplates(&job.Spec.Template.Spec, cfg.DefaultJobTemplate, backup.Spec.JobTemplate)
+	applyJobLimits(job, nil, cfg.JobTTL())
+	useFallbackTerminationMessages(&job.Spec.Template.Spec)
+	return job
+}
+
+// reconcileRestoreCancel handles spec.cancel on a Restore that has not
+// finished, and reports whether reconciliation should stop. Restores only
+// read from storage, so stopping the Job is all there is to clean up.
+func (r *RestoreReconciler) reconcileRestoreCancel(ctx context.Context, restore *backupv1alpha1.Restore) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if !restore.Spec.Cancel || restoreFinished(restore.Status.Phase) {
+		return false, nil
+	}
+
+	jobName := attemptJobName(restoreJobName(restore), max(restore.Status.Attempts, 1))
+	gone, err := stopJob(ctx, r.Client, restoreJobNamespace(restore), jobName)
+	if errors.Is(err, errJobSucceeded) {
+		log.Info("Restore Job already succeeded, ignoring cancel", "jobName", jobName)
+		return false, nil
+	}
+	if err != nil {
+		log.Error(err, "unable to delete Restore Job", "jobName", jobName)
+		return true, err
+	}
+	if !gone {
+		message := "Waiting for restore Job " + jobName + " to stop"
+		if progressing := meta.FindStatusCondition(restore.Status.Conditions, backupv1alpha1.ConditionProgressing); progressing != nil &&
+			progressing.Reason == "Cancelling" && progressing.Message == message {
+			return true, nil
+		}
+		base := restore.DeepCopy()
+		setCondition(&restore.Status.Conditions, restore.Generation, backupv1alpha1.ConditionProgressing,
+			metav1.ConditionTrue, "Cancelling", message)
+		return true, patchRestoreStatus(ctx, r.Client, restore, base)
+	}
+
+	base := restore.DeepCopy()
+	restore.Status.Phase = backupv1alpha1.RestorePhaseCancelled
+	now := metav1.NewTime(r.Clock.Now())
+	restore.Status.CompletionTime = &now
+	restore.Status.Progress = nil
+	message := "Restore cancelled"
+	if restore.Status.Attempts > 0 {
+		message = fmt.Sprintf("Restore cancelled; files already extracted remain in PVC %s", restore.Status.TargetPVC)
+	}
+	setConditions(&restore.Status.Conditions, restore.Generation, "Cancelled", message,
+		metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionFalse)
+	if err := patchRestoreStatus(ctx, r.Client, restore, base); err != nil {
+		log.Error(err, "unable to update Restore status to Cancelled")
+		return true, err
+	}
+	r.Recorder.Event(
+		restore,
+		corev1.EventTypeNormal,
+		"RestoreCancelled",
+		message,
+	)
+	return true, nil
+}
+
+// errJobSucceeded is returned by stopJob for a Job that already succeeded
+var errJobSucceeded = errors.New("job already succeeded")
+
+// stopJob deletes a mover Job with foreground propagation, so the Job only
+// disappears once its pods are gone, and reports whether it is gone. A Job
+// that already succeeded is left alone and errJobSucceeded is returned.
+func stopJob(ctx context.Context, c client.Client, namespace, name string) (bool, error) {
+	var job batchv1.Job
+	if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &job); err != nil {
+		return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+	}
+	if jobCondition(&job, batchv1.JobComplete) != nil {
+		return false, errJobSucceeded
+	}
+	if !job.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+	if err := c.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
+		return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+	}
+	// The deletion event of the Job triggers the next reconcile
+	return false, nil
+}
+
+// backupFinished reports whether a Backup phase is terminal
+func backupFinished(phase backupv1alpha1.BackupPhase) bool {
+	return phase == backupv1alpha1.BackupPhaseCompleted ||
+		phase == backupv1alpha1.BackupPhaseFailed ||
+		phase == backupv1alpha1.BackupPhaseCancelled
+}
+
+// restoreFinished reports whether a Restore phase is terminal
+func restoreFinished(phase backupv1alpha1.RestorePhase) bool {
+	return phase == backupv1alpha1.RestorePhaseCompleted ||
+		phase == backupv1alpha1.RestorePhaseFailed ||
+		phase == backupv1alpha1.RestorePhaseCancelled
+}
+
+// cleanupJobName is the name of the Job that removes a cancelled Backup's archive
+func cleanupJobName(backup *backupv1alpha1.Backup) string {
+	return boundedName(backup.Name+"-cleanup", maxJobNameLength)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// setCancel sets spec.cancel on a Backup or Restore
+func setCancel(t *testing.T, c client.Client, obj client.Object) {
+	t.Helper()
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj); err != nil {
+		t.Fatal(err)
+	}
+	switch obj := obj.(type) {
+	case *backupv1alpha1.Backup:
+		obj.Spec.Cancel = true
+	case *backupv1alpha1.Restore:
+		obj.Spec.Cancel = true
+	}
+	if err := c.Update(context.Background(), obj); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// expectJobGone fails the test if the named Job still exists
+func expectJobGone(t *testing.T, c client.Client, name string) {
+	t.Helper()
+	var job batchv1.Job
+	if err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &job); !apierrors.IsNotFound(err) {
+		t.Errorf("Job %s should be deleted, got err = %v", name, err)
+	}
+}
+
+func TestBackupCancel(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+
+	newReconciler := func(backup *backupv1alpha1.Backup) (*BackupReconciler, *record.FakeRecorder) {
+		recorder := record.NewFakeRecorder(100)
+		return &BackupReconciler{
+			Client:   newTransitionClient(t, backup),
+			Recorder: recorder,
+			Clock:    clocktesting.NewFakeClock(transitionStart),
+		}, recorder
+	}
+	fetch := func(r *BackupReconciler) *backupv1alpha1.Backup {
+		t.Helper()
+		var backup backupv1alpha1.Backup
+		if err := r.Get(ctx, key, &backup); err != nil {
+			t.Fatal(err)
+		}
+		return &backup
+	}
+	newBackup := func() *backupv1alpha1.Backup {
+		return &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+		}
+	}
+
+	t.Run("running backup stops its Job and removes the partial archive", func(t *testing.T) {
+		r, recorder := newReconciler(newBackup())
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		drainEvents(recorder)
+
+		setCancel(t, r.Client, &backupv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}})
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		expectJobGone(t, r.Client, "nightly-job")
+		if progressing := meta.FindStatusCondition(fetch(r).Status.Conditions, backupv1alpha1.ConditionProgressing); progressing == nil ||
+			progressing.Reason != "Cancelling" {
+			t.Errorf("Progressing condition = %+v, want Cancelling", progressing)
+		}
+
+		// With the mover gone, the cleanup Job removes what it wrote
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		var cleanup batchv1.Job
+		if err := r.Get(ctx, types.NamespacedName{Name: "nightly-cleanup", Namespace: "default"}, &cleanup); err != nil {
+			t.Fatalf("cleanup Job not created: %v", err)
+		}
+		if command := strings.Join(cleanup.Spec.Template.Spec.Containers[0].Command, " "); !strings.Contains(command,
+			"rm -f /backup-output/nightly.tar.gz /backup-output/nightly.tar.gz.sha256") {
+			t.Errorf("cleanup command = %q", command)
+		}
+		if backup := fetch(r); backup.Status.Phase != backupv1alpha1.BackupPhaseRunning {
+			t.Errorf("phase = %s, want Running until the cleanup finishes", backup.Status.Phase)
+		}
+		expectEvents(t, recorder)
+
+		setJobCondition(t, r.Client, "nightly-cleanup", batchv1.JobComplete, transitionStart)
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		backup := fetch(r)
+		if backup.Status.Phase != backupv1alpha1.BackupPhaseCancelled || backup.Status.CompletionTime == nil {
+			t.Fatalf("phase = %s, completionTime = %v; want Cancelled with a completion time",
+				backup.Status.Phase, backup.Status.CompletionTime)
+		}
+		if ready := meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionReady); ready == nil ||
+			ready.Reason != "Cancelled" || ready.Message != "Backup cancelled; the partial archive was removed" {
+			t.Errorf("Ready condition = %+v", ready)
+		}
+		expectEvents(t, recorder, "BackupCancelled")
+
+		// Cancelled backups stay quiet and start no new Job
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		expectEvents(t, recorder)
+		expectJobGone(t, r.Client, "nightly-job")
+	})
+
+	t.Run("backup cancelled before it started", func(t *testing.T) {
+		backup := newBackup()
+		backup.Spec.Cancel = true
+		r, recorder := newReconciler(backup)
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		if backup := fetch(r); backup.Status.Phase != backupv1alpha1.BackupPhaseCancelled {
+			t.Fatalf("phase = %s, want Cancelled", backup.Status.Phase)
+		}
+		expectJobGone(t, r.Client, "nightly-job")
+		expectJobGone(t, r.Client, "nightly-cleanup")
+		expectEvents(t, recorder, "BackupCancelled")
+	})
+
+	t.Run("cancel after the Job succeeded is ignored", func(t *testing.T) {
+		r, recorder := newReconciler(newBackup())
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		drainEvents(recorder)
+
+		setJobCondition(t, r.Client, "nightly-job", batchv1.JobComplete, transitionStart)
+		setCancel(t, r.Client, &backupv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}})
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		if backup := fetch(r); backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
+			t.Errorf("phase = %s, want Completed", backup.Status.Phase)
+		}
+		expectEvents(t, recorder, "BackupCompleted")
+	})
+}
+
+func TestRestoreCancel(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "restore-nightly", Namespace: "default"}
+
+	recorder := record.NewFakeRecorder(100)
+	r := &RestoreReconciler{
+		Client: newTransitionClient(t,
+			&backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+				Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+				Status:     backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+			},
+			&backupv1alpha1.Restore{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec:       backupv1alpha1.RestoreSpec{BackupName: "nightly", TargetPVC: "data-restored"},
+			},
+		),
+		Recorder: recorder,
+		Clock:    clocktesting.NewFakeClock(transitionStart),
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatal(err)
+	}
+	drainEvents(recorder)
+
+	setCancel(t, r.Client, &backupv1alpha1.Restore{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}})
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	expectJobGone(t, r.Client, "restore-nightly-job")
+	expectEvents(t, recorder)
+
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	var restore backupv1alpha1.Restore
+	if err := r.Get(ctx, key, &restore); err != nil {
+		t.Fatal(err)
+	}
+	if restore.Status.Phase != backupv1alpha1.RestorePhaseCancelled {
+		t.Fatalf("phase = %s, want Cancelled", restore.Status.Phase)
+	}
+	if ready := meta.FindStatusCondition(restore.Status.Conditions, backupv1alpha1.ConditionReady); ready == nil ||
+		!strings.Contains(ready.Message, "remain in PVC data-restored") {
+		t.Errorf("Ready condition = %+v", ready)
+	}
+	expectEvents(t, recorder, "RestoreCancelled")
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+)
+
+// archiveFormat adds the commands the mover runs to write and extract
+// archives to their format. The mover pipes tar through gzip from the mover
+// image, or through the operator's mover binary for zstd and lz4, which
+// busybox lacks.
+type archiveFormat struct {
+	archive.Format
+}
+
+// formatOf returns the archive format of a Backup, see archive.FormatOf
+func formatOf(backup *backupv1alpha1.Backup) archiveFormat {
+	return archiveFormat{archive.FormatOf(backup)}
+}
+
+// rejectBackup fails a backup that cannot run as specified, without starting a Job
+func (r *BackupReconciler) rejectBackup(ctx context.Context, backup *backupv1alpha1.Backup, reason, message string) error {
+	base := backup.DeepCopy()
+	backup.Status.Phase = backupv1alpha1.BackupPhaseFailed
+	now := metav1.NewTime(r.Clock.Now())
+	backup.Status.CompletionTime = &now
+	backup.Status.FailureReason = message
+	setConditions(&backup.Status.Conditions, backup.Generation, reason, message,
+		metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+	if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+		return err
+	}
+	r.Recorder.Event(
+		backup,
+		corev1.EventTypeWarning,
+		reason,
+		eventMessage(message),
+	)
+	return nil
+}
+
+// levelFlag returns the command-line flag for the compression level
+func (f archiveFormat) levelFlag() string {
+	if f.Level == 0 {
+		return ""
+	}
+	return " -" + strconv.Itoa(int(f.Level))
+}
+
+// compressor is the command compressing a tar stream from stdin to stdout,
+// empty when the archive is not compressed
+func (f archiveFormat) compressor() string {
+	switch {
+	case f.Algorithm == backupv1alpha1.CompressionNone:
+		return ""
+	case f.NeedsMoverTools():
+		command := archive.Tool + " compress -algorithm " + string(f.Algorithm)
+		if f.Level != 0 {
+			command += " -level " + strconv.Itoa(int(f.Level))
+		}
+		return command
+	}
+	return "gzip" + f.levelFlag()
+}
+
+// compressCommand is a shell command that archives dir into path and writes
+// the paths it adds to listFile, one per line. tar writes the archive itself
+// unless it has to be piped through a compressor or a throttle.
+func (f archiveFormat) compressCommand(dir, path, listFile string, throttle moverThrottle) string {
+	if throttle.unlimited() {
+		switch {
+		case f.Algorithm == backupv1alpha1.CompressionNone:
+			return "tar -cvf " + path + " -C " + dir + " . > " + listFile
+		case f.Algorithm == backupv1alpha1.CompressionGzip && f.Level == 0:
+			return "tar -czvf " + path + " -C " + dir + " . > " + listFile
+		}
+	}
+	// tar lists paths on stderr when it writes the archive to stdout;
+	// pipefail keeps tar's errors
+	return "set -o pipefail; tar -cvf - -C " + dir + " . 2> " + listFile +
+		throttle.readFilter() + pipeTo(f.compressor()) + throttle.uploadFilter() + " > " + path
+}
+
+// extractCommand is a shell command that extracts the archive at path into
+// dir and writes the paths it extracts to listFile
+func (f archiveFormat) extractCommand(path, dir, listFile string, throttle moverThrottle) string {
+	if throttle.unlimited() && f.Algorithm == backupv1alpha1.CompressionGzip {
+		return "tar -xzvf " + path + " -C " + dir + " > " + listFile
+	}
+	stream := f.DecompressCommand(path)
+	if read := throttle.readFilter(); read != "" {
+		stream = "cat " + path + read + pipeTo(f.Decompressor())
+	}
+	return "set -o pipefail; " + stream + throttle.uploadFilter() + " | tar -xvf - -C " + dir + " > " + listFile
+}
+
+// pipeTo is a pipe stage running command, empty when there is no command
+func pipeTo(command string) string {
+	if command == "" {
+		return ""
+	}
+	return " | " + command
+}
+
+// testCommand is a shell command that reads the whole archive at path,
+// failing if it is corrupt
+func (f archiveFormat) testCommand(path string) string {
+	return "{ " + f.ListCommand(path, false) + " > /dev/null; }"
+}
+
+// anyArchiveTest is a shell test for an archive of any format next to the
+// manifest in $manifest, for storage locations holding archives of several formats
+func anyArchiveTest() string {
+	test := "{ false"
+	for _, algorithm := range archive.Algorithms {
+		test += " || [ -f \"${manifest%.json}" + archive.Format{Algorithm: algorithm}.Extension() + "\" ]"
+	}
+	return test + "; }"
+}
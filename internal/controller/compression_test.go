@@ -0,0 +1,220 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+func TestArchiveFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression *backupv1alpha1.CompressionSpec
+		archive     string
+		compress    string
+		extract     string
+	}{
+		{
+			name:     "default",
+			archive:  "nightly.tar.gz",
+			compress: "tar -czvf /backups/nightly.tar.gz -C /data . > /tmp/files",
+			extract:  "tar -xzvf /backups/nightly.tar.gz -C /restore-target > /tmp/files",
+		},
+		{
+			name:        "gzip with a level",
+			compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionGzip, Level: 1},
+			archive:     "nightly.tar.gz",
+			compress:    "set -o pipefail; tar -cvf - -C /data . 2> /tmp/files | gzip -1 > /backups/nightly.tar.gz",
+			extract:     "tar -xzvf /backups/nightly.tar.gz -C /restore-target > /tmp/files",
+		},
+		{
+			name:        "none",
+			compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionNone},
+			archive:     "nightly.tar",
+			compress:    "tar -cvf /backups/nightly.tar -C /data . > /tmp/files",
+			extract:     "set -o pipefail; cat /backups/nightly.tar | tar -xvf - -C /restore-target > /tmp/files",
+		},
+		{
+			name:        "zstd",
+			compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionZstd, Level: 19},
+			archive:     "nightly.tar.zst",
+			compress: "set -o pipefail; tar -cvf - -C /data . 2> /tmp/files | " +
+				"/mover-tools/mover compress -algorithm zstd -level 19 > /backups/nightly.tar.zst",
+			extract: "set -o pipefail; /mover-tools/mover decompress -algorithm zstd < /backups/nightly.tar.zst | " +
+				"tar -xvf - -C /restore-target > /tmp/files",
+		},
+		{
+			name:        "lz4",
+			compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionLZ4},
+			archive:     "nightly.tar.lz4",
+			compress: "set -o pipefail; tar -cvf - -C /data . 2> /tmp/files | " +
+				"/mover-tools/mover compress -algorithm lz4 > /backups/nightly.tar.lz4",
+			extract: "set -o pipefail; /mover-tools/mover decompress -algorithm lz4 < /backups/nightly.tar.lz4 | " +
+				"tar -xvf - -C /restore-target > /tmp/files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := &backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly"},
+				Spec:       backupv1alpha1.BackupSpec{Compression: tt.compression},
+			}
+			if got := archive.Name(backup); got != tt.archive {
+				t.Errorf("archive.Name() = %s, want %s", got, tt.archive)
+			}
+			format := formatOf(backup)
+			if got := format.compressCommand("/data", "/backups/"+tt.archive, "/tmp/files", moverThrottle{}); got != tt.compress {
+				t.Errorf("compressCommand() = %s, want %s", got, tt.compress)
+			}
+			if got := format.extractCommand("/backups/"+tt.archive, "/restore-target", "/tmp/files", moverThrottle{}); got != tt.extract {
+				t.Errorf("extractCommand() = %s, want %s", got, tt.extract)
+			}
+		})
+	}
+}
+
+func TestInvalidCompressionFailsBackup(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression *backupv1alpha1.CompressionSpec
+		wantReason  string
+	}{
+		{
+			name:        "level out of range",
+			compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionGzip, Level: 15},
+			wantReason:  "InvalidCompression",
+		},
+		{
+			name:        "operator image unknown",
+			compression: &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionZstd},
+			wantReason:  "MoverToolsUnavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+
+			recorder := record.NewFakeRecorder(100)
+			r := &BackupReconciler{
+				Client: newTransitionClient(t, &backupv1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec: backupv1alpha1.BackupSpec{
+						Target:      backupv1alpha1.BackupTarget{PVCName: "data"},
+						Compression: tt.compression,
+					},
+				}),
+				Recorder: recorder,
+				Clock:    clocktesting.NewFakeClock(transitionStart),
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			expectNoRequeue(t, result, err)
+
+			var backup backupv1alpha1.Backup
+			if err := r.Get(ctx, key, &backup); err != nil {
+				t.Fatal(err)
+			}
+			if backup.Status.Phase != backupv1alpha1.BackupPhaseFailed {
+				t.Errorf("phase = %s, want Failed", backup.Status.Phase)
+			}
+			var jobs batchv1.JobList
+			if err := r.List(ctx, &jobs); err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs.Items) != 0 {
+				t.Errorf("%d Jobs created for a backup with an invalid compression", len(jobs.Items))
+			}
+			expectEvents(t, recorder, tt.wantReason)
+		})
+	}
+}
+
+func TestMoverTools(t *testing.T) {
+	cfg := config.Default()
+	cfg.ProgressInterval = &metav1.Duration{}
+	r := &BackupReconciler{MoverToolsImage: "operator:v1", Config: config.NewStore(cfg)}
+	backup := &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+		Spec: backupv1alpha1.BackupSpec{
+			Target: backupv1alpha1.BackupTarget{PVCName: "data"},
+		},
+	}
+
+	// gzip is the mover image's own command, and without progress reports
+	// the mover binary has nothing to do
+	podSpec := r.createBackupJob(backup, 1, "backup-storage", nil).Spec.Template.Spec
+	if len(podSpec.InitContainers) != 0 {
+		t.Errorf("a gzip backup without progress needs no mover binary: %+v", podSpec.InitContainers)
+	}
+	r.Config = nil
+	podSpec = r.createBackupJob(backup, 1, "backup-storage", nil).Spec.Template.Spec
+	if len(podSpec.InitContainers) != 1 || !strings.Contains(podSpec.Containers[0].Command[2], "| /mover-tools/mover report") {
+		t.Errorf("a gzip backup does not report progress with the mover binary: %+v", podSpec.InitContainers)
+	}
+
+	backup.Spec.Compression = &backupv1alpha1.CompressionSpec{Algorithm: backupv1alpha1.CompressionLZ4}
+	podSpec = r.createBackupJob(backup, 1, "backup-storage", nil).Spec.Template.Spec
+	if len(podSpec.InitContainers) != 1 {
+		t.Fatalf("init containers = %+v, want the one installing the mover binary", podSpec.InitContainers)
+	}
+	install := podSpec.InitContainers[0]
+	if install.Image != "operator:v1" || strings.Join(install.Command, " ") != "/mover install /mover-tools/mover" {
+		t.Errorf("install container = %s %v, want operator:v1 installing /mover-tools/mover", install.Image, install.Command)
+	}
+	backupContainer := podSpec.Containers[0]
+	if mount := backupContainer.VolumeMounts[len(backupContainer.VolumeMounts)-1]; mount.MountPath != "/mover-tools" {
+		t.Errorf("backup container mounts = %+v, want the mover tools", backupContainer.VolumeMounts)
+	}
+	if !strings.Contains(backupContainer.Command[2], "/mover-tools/mover compress -algorithm lz4") {
+		t.Errorf("backup command does not compress with the mover binary: %s", backupContainer.Command[2])
+	}
+	if volume := podSpec.Volumes[len(podSpec.Volumes)-1]; volume.Name != "mover-tools" || volume.EmptyDir == nil {
+		t.Errorf("volumes = %+v, want an emptyDir for the mover tools", podSpec.Volumes)
+	}
+}
+
+func TestOperatorImage(t *testing.T) {
+	pods := fake.NewClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator-7d9f", Namespace: "backup-system"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "kube-rbac-proxy", Image: "proxy:v1"},
+			{Name: "manager", Image: "operator:v1"},
+		}},
+	}).CoreV1()
+	if image, err := OperatorImage(context.Background(), pods, "backup-system", "operator-7d9f"); err != nil || image != "operator:v1" {
+		t.Errorf("OperatorImage() = %q, %v; want the manager container's image", image, err)
+	}
+	if _, err := OperatorImage(context.Background(), pods, "backup-system", "missing"); err == nil {
+		t.Error("expected an error for a pod that does not exist")
+	}
+}
@@ -0,0 +1,474 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// reconcilePreHook holds a member of a consistency group back until the
+// group's pre-hook has succeeded, and reports whether the member may start.
+// Whichever member gets here first creates the hook Job; its completion
+// wakes every member, so they all start their mover Jobs together.
+func (r *BackupReconciler) reconcilePreHook(ctx context.Context, backup *backupv1alpha1.Backup) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	hook := backup.Spec.PreHook
+	if backup.Spec.Group == "" || hook == nil {
+		return true, nil
+	}
+
+	members, err := groupMembers(ctx, r.Client, backup.Namespace, backup.Spec.Group)
+	if err != nil {
+		return false, err
+	}
+	// A member that started its mover Job saw the hook succeed; the hook Job
+	// itself may have been cleaned up since
+	for _, member := range members {
+		if member.Status.Attempts > 0 {
+			return true, nil
+		}
+	}
+
+	var job batchv1.Job
+	name := preHookJobName(backup.Spec.Group)
+	err = r.Get(ctx, client.ObjectKey{Name: name, Namespace: backup.Namespace}, &job)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, r.createPreHookJob(backup, members, name)); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return false, err
+			}
+		} else {
+			log.Info("Created pre-hook Job for consistency group", "group", backup.Spec.Group, "jobName", name)
+			r.Recorder.Eventf(
+				backup,
+				corev1.EventTypeNormal,
+				"PreHookStarted",
+				"Running pre-hook of group %s in Job %s",
+				backup.Spec.Group,
+				name,
+			)
+		}
+		return false, r.markWaitingForPreHook(ctx, backup, "Waiting for pre-hook Job "+name)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if jobCondition(&job, batchv1.JobComplete) != nil {
+		return true, nil
+	}
+	failed := jobCondition(&job, batchv1.JobFailed)
+	if failed == nil {
+		return false, r.markWaitingForPreHook(ctx, backup, "Waiting for pre-hook Job "+name)
+	}
+
+	log.Info("Pre-hook of consistency group failed", "group", backup.Spec.Group, "reason", failed.Reason)
+	base := backup.DeepCopy()
+	backup.Status.Phase = backupv1alpha1.BackupPhaseFailed
+	now := metav1.NewTime(r.Clock.Now())
+	backup.Status.CompletionTime = &now
+	backup.Status.FailureReason = describeJobFailure(ctx, r.Pods, &job, failed, r.Config.Get().FailureLogLines())
+	message := fmt.Sprintf("Pre-hook of group %s failed: %s", backup.Spec.Group, backup.Status.FailureReason)
+	setConditions(&backup.Status.Conditions, backup.Generation, "PreHookFailed", message,
+		metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+	if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+		log.Error(err, "unable to update Backup status to Failed")
+		return false, err
+	}
+	r.Recorder.Event(
+		backup,
+		corev1.EventTypeWarning,
+		"PreHookFailed",
+		eventMessage(message),
+	)
+	return false, nil
+}
+
+// markWaitingForPreHook records that a member is waiting for its group's pre-hook
+func (r *BackupReconciler) markWaitingForPreHook(ctx context.Context, backup *backupv1alpha1.Backup, message string) error {
+	if progressing := meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionProgressing); backup.Status.Phase == backupv1alpha1.BackupPhasePending &&
+		progressing != nil && progressing.Reason == "WaitingForPreHook" && progressing.Message == message {
+		return nil
+	}
+	base := backup.DeepCopy()
+	backup.Status.Phase = backupv1alpha1.BackupPhasePending
+	setConditions(&backup.Status.Conditions, backup.Generation, "WaitingForPreHook", message,
+		metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionFalse)
+	return patchBackupStatus(ctx, r.Client, backup, base)
+}
+
+// createPreHookJob builds the Job running a group's pre-hook. It is owned by
+// every member known when it is created, so it lives as long as the group.
+func (r *BackupReconciler) createPreHookJob(backup *backupv1alpha1.Backup, members []backupv1alpha1.Backup, name string) *batchv1.Job {
+	cfg := r.Config.Get()
+	hook := backup.Spec.PreHook
+	image := hook.Image
+	if image == "" {
+		image = cfg.MoverImage
+	}
+
+	var owners []metav1.OwnerReference
+	for _, member := range members {
+		owners = append(owners, metav1.OwnerReference{
+			APIVersion: backupv1alpha1.GroupVersion.String(),
+			Kind:       "Backup",
+			Name:       member.Name,
+			UID:        member.UID,
+		})
+	}
+	labels := map[string]string{
+		backupv1alpha1.GroupLabel: labelValue(backup.Spec.Group),
+	}
+	if backup.Spec.PolicyRef != "" {
+		labels[backupv1alpha1.PolicyLabel] = labelValue(backup.Spec.PolicyRef)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       backup.Namespace,
+			Labels:          labels,
+			OwnerReferences: owners,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "pre-hook",
+							Image:   image,
+							Command: hook.Command,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	applyJobTemplates(&job.Spec.Template.Spec, cfg.DefaultJobTemplate, backup.Spec.JobTemplate)
+	applyJobLimits(job, hook.Timeout, cfg.JobTTL())
+	useFallbackTerminationMessages(&job.Spec.Template.Spec)
+	return job
+}
+
+// preHookMembers maps a pre-hook Job to the members of its group, which are
+// not its controller and so are not woken by Owns
+func (r *BackupReconciler) preHookMembers(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetLabels()[backupv1alpha1.GroupLabel] == "" {
+		return nil
+	}
+	var backups backupv1alpha1.BackupList
+	if err := r.List(ctx, &backups,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabels{backupv1alpha1.GroupLabel: obj.GetLabels()[backupv1alpha1.GroupLabel]},
+	); err != nil {
+		logf.FromContext(ctx).Error(err, "unable to list consistency group members", "jobName", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, backup := range backups.Items {
+		if backup.Spec.Group != "" && preHookJobName(backup.Spec.Group) == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&backup)})
+		}
+	}
+	return requests
+}
+
+// groupMembers returns the Backups of a consistency group, sorted by name
+func groupMembers(ctx context.Context, c client.Reader, namespace, group string) ([]backupv1alpha1.Backup, error) {
+	var backups backupv1alpha1.BackupList
+	if err := c.List(ctx, &backups,
+		client.InNamespace(namespace),
+		client.MatchingLabels{backupv1alpha1.GroupLabel: labelValue(group)},
+	); err != nil {
+		return nil, err
+	}
+
+	// Long group IDs are shortened in the label, so check the full ID too
+	var members []backupv1alpha1.Backup
+	for _, backup := range backups.Items {
+		if backup.Spec.Group == group {
+			members = append(members, backup)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Name < members[j].Name
+	})
+	return members, nil
+}
+
+// reconcileGroupRestore restores every member of a consistency group through
+// one member Restore each and aggregates their phases. Cancelling the group
+// restore cancels the members that have not finished.
+func (r *RestoreReconciler) reconcileGroupRestore(ctx context.Context, restore *backupv1alpha1.Restore) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if restoreFinished(restore.Status.Phase) {
+		return ctrl.Result{}, nil
+	}
+	if restore.Spec.BackupName != "" || restore.Spec.TargetPVC != "" || restore.Spec.Migration != nil {
+		return ctrl.Result{}, r.rejectRestore(ctx, restore, "InvalidSpec",
+			"group restores every member into the PVC it was backed up from and cannot be combined with backupName, targetPVC or migration")
+	}
+
+	backupNamespace := restoreBackupNamespace(restore)
+
+	base := restore.DeepCopy()
+	if len(restore.Status.Members) == 0 {
+		if restore.Spec.Cancel {
+			restore.Status.Phase = backupv1alpha1.RestorePhaseCancelled
+			now := metav1.NewTime(r.Clock.Now())
+			restore.Status.CompletionTime = &now
+			setConditions(&restore.Status.Conditions, restore.Generation, "Cancelled", "Restore cancelled",
+				metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionFalse)
+			if err := patchRestoreStatus(ctx, r.Client, restore, base); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Event(
+				restore,
+				corev1.EventTypeNormal,
+				"RestoreCancelled",
+				"Restore cancelled",
+			)
+			return ctrl.Result{}, nil
+		}
+		members, err := groupMembers(ctx, r.Client, backupNamespace, restore.Spec.Group)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(members) == 0 {
+			return ctrl.Result{}, r.rejectRestore(ctx, restore, "GroupNotFound",
+				fmt.Sprintf("No Backups of group %s in namespace %s", restore.Spec.Group, backupNamespace))
+		}
+		// Restoring only part of a group would bring back an inconsistent application
+		for _, member := range members {
+			if member.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
+				return ctrl.Result{}, r.rejectRestore(ctx, restore, "BackupNotReady",
+					fmt.Sprintf("Backup %s of group %s is in phase %s, not Completed", member.Name, restore.Spec.Group, member.Status.Phase))
+			}
+		}
+
+		for _, member := range members {
+			targetPVC, err := transformName(member.Spec.Target.PVCName, restore.Spec.NameTransforms)
+			if err != nil {
+				return ctrl.Result{}, r.rejectRestore(ctx, restore, "InvalidTarget", err.Error())
+			}
+			restore.Status.Members = append(restore.Status.Members, backupv1alpha1.RestoreMemberStatus{
+				BackupName:  member.Name,
+				TargetPVC:   targetPVC,
+				RestoreName: boundedName(restore.Name+"-"+member.Spec.Target.PVCName, maxBackupNameLength),
+			})
+		}
+		restore.Status.Phase = backupv1alpha1.RestorePhaseRunning
+		now := metav1.NewTime(r.Clock.Now())
+		restore.Status.StartTime = &now
+		r.Recorder.Eventf(
+			restore,
+			corev1.EventTypeNormal,
+			"RestoreStarted",
+			"Restoring %d members of group %s",
+			len(members),
+			restore.Spec.Group,
+		)
+	}
+
+	for i := range restore.Status.Members {
+		status := &restore.Status.Members[i]
+		var member backupv1alpha1.Restore
+		err := r.Get(ctx, client.ObjectKey{Name: status.RestoreName, Namespace: restore.Namespace}, &member)
+		if apierrors.IsNotFound(err) {
+			switch {
+			case restoreFinished(status.Phase):
+			case status.Phase != "":
+				// The member Restore was deleted while it ran
+				status.Phase = backupv1alpha1.RestorePhaseFailed
+			default:
+				if err := r.Create(ctx, groupMemberRestore(restore, status)); err != nil && !apierrors.IsAlreadyExists(err) {
+					return ctrl.Result{}, err
+				}
+				log.Info("Created member Restore", "restoreName", status.RestoreName, "backupName", status.BackupName)
+			}
+			continue
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if restore.Spec.Cancel && !member.Spec.Cancel && !restoreFinished(member.Status.Phase) {
+			patch := client.MergeFrom(member.DeepCopy())
+			member.Spec.Cancel = true
+			if err := r.Patch(ctx, &member, patch); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		status.Phase = member.Status.Phase
+	}
+
+	r.summarizeGroupRestore(restore)
+	if !equality.Semantic.DeepEqual(base.Status, restore.Status) {
+		if err := patchRestoreStatus(ctx, r.Client, restore, base); err != nil {
+			log.Error(err, "unable to update Restore status")
+			return ctrl.Result{}, err
+		}
+	}
+	// Member Restores are owned by this one, so their phase changes trigger a reconcile
+	return ctrl.Result{}, nil
+}
+
+// summarizeGroupRestore aggregates the member phases into the group restore's
+// phase and conditions
+func (r *RestoreReconciler) summarizeGroupRestore(restore *backupv1alpha1.Restore) {
+	counts := map[backupv1alpha1.RestorePhase]int{}
+	for _, member := range restore.Status.Members {
+		counts[member.Phase]++
+	}
+	total := len(restore.Status.Members)
+	completed, failed, cancelled := counts[backupv1alpha1.RestorePhaseCompleted], counts[backupv1alpha1.RestorePhaseFailed],
+		counts[backupv1alpha1.RestorePhaseCancelled]
+
+	degraded := metav1.ConditionFalse
+	if failed > 0 {
+		degraded = metav1.ConditionTrue
+	}
+	if completed+failed+cancelled < total {
+		reason, message := "MembersRestoring", fmt.Sprintf("%d of %d members of group %s restored", completed, total, restore.Spec.Group)
+		if restore.Spec.Cancel {
+			reason, message = "Cancelling", "Waiting for member restores to stop"
+		}
+		setConditions(&restore.Status.Conditions, restore.Generation, reason, message,
+			metav1.ConditionFalse, metav1.ConditionTrue, degraded)
+		return
+	}
+
+	now := metav1.NewTime(r.Clock.Now())
+	restore.Status.CompletionTime = &now
+	switch {
+	case failed > 0:
+		restore.Status.Phase = backupv1alpha1.RestorePhaseFailed
+		message := fmt.Sprintf("%d of %d members of group %s failed to restore", failed, total, restore.Spec.Group)
+		setConditions(&restore.Status.Conditions, restore.Generation, "RestoreFailed", message,
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+		r.Recorder.Event(
+			restore,
+			corev1.EventTypeWarning,
+			"RestoreFailed",
+			message,
+		)
+	case cancelled > 0:
+		restore.Status.Phase = backupv1alpha1.RestorePhaseCancelled
+		message := fmt.Sprintf("Restore cancelled; %d of %d members of group %s were restored", completed, total, restore.Spec.Group)
+		setConditions(&restore.Status.Conditions, restore.Generation, "Cancelled", message,
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionFalse)
+		r.Recorder.Event(
+			restore,
+			corev1.EventTypeNormal,
+			"RestoreCancelled",
+			message,
+		)
+	default:
+		restore.Status.Phase = backupv1alpha1.RestorePhaseCompleted
+		message := fmt.Sprintf("Successfully restored %d members of group %s", total, restore.Spec.Group)
+		setConditions(&restore.Status.Conditions, restore.Generation, "RestoreCompleted", message,
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+		r.Recorder.Event(
+			restore,
+			corev1.EventTypeNormal,
+			"RestoreCompleted",
+			message,
+		)
+	}
+}
+
+// groupMemberRestore builds the Restore of one member of a group restore
+func groupMemberRestore(restore *backupv1alpha1.Restore, member *backupv1alpha1.RestoreMemberStatus) *backupv1alpha1.Restore {
+	return &backupv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      member.RestoreName,
+			Namespace: restore.Namespace,
+			Labels: map[string]string{
+				backupv1alpha1.GroupLabel: labelValue(restore.Spec.Group),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: backupv1alpha1.GroupVersion.String(),
+					Kind:       "Restore",
+					Name:       restore.Name,
+					UID:        restore.UID,
+					Controller: ptr.To(true),
+				},
+			},
+		},
+		Spec: backupv1alpha1.RestoreSpec{
+			BackupName: member.BackupName,
+			// Name transforms are already applied to the member's target PVC
+			TargetPVC:        member.TargetPVC,
+			TargetNamespace:  restore.Spec.TargetNamespace,
+			BackupNamespace:  restoreBackupNamespace(restore),
+			NamespaceMapping: maps.Clone(restore.Spec.NamespaceMapping),
+			Transforms:       restore.Spec.Transforms,
+			JobTemplate:      restore.Spec.JobTemplate.DeepCopy(),
+			Throttle:         restore.Spec.Throttle.DeepCopy(),
+			Retry:            restore.Spec.Retry.DeepCopy(),
+			Timeout:          restore.Spec.Timeout.DeepCopy(),
+		},
+	}
+}
+
+// rejectRestore fails a Restore that cannot start
+func (r *RestoreReconciler) rejectRestore(ctx context.Context, restore *backupv1alpha1.Restore, reason, message string) error {
+	base := restore.DeepCopy()
+	restore.Status.Phase = backupv1alpha1.RestorePhaseFailed
+	now := metav1.NewTime(r.Clock.Now())
+	restore.Status.CompletionTime = &now
+	setConditions(&restore.Status.Conditions, restore.Generation, reason, message,
+		metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+	if err := patchRestoreStatus(ctx, r.Client, restore, base); err != nil {
+		return err
+	}
+	r.Recorder.Event(
+		restore,
+		corev1.EventTypeWarning,
+		reason,
+		eventMessage(message),
+	)
+	return nil
+}
+
+// preHookJobName is the name of the Job running a consistency group's pre-hook
+func preHookJobName(group string) string {
+	return boundedName(group+"-pre-hook", maxJobNameLength)
+}
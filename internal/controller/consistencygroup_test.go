@@ -0,0 +1,298 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestPolicyBackups(t *testing.T) {
+	policy := &backupv1alpha1.BackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "default"},
+		Spec: backupv1alpha1.BackupPolicySpec{
+			Target: backupv1alpha1.BackupTarget{PVCName: "data"},
+		},
+	}
+	if backups := policyBackups(policy, "postgres-1"); len(backups) != 1 || backups[0].Name != "postgres-1" || backups[0].Spec.Group != "" {
+		t.Fatalf("policyBackups() without a group = %+v", backups)
+	}
+
+	policy.Spec.Group = &backupv1alpha1.BackupGroupSpec{
+		PVCNames: []string{"wal"},
+		PreHook:  &backupv1alpha1.BackupHook{Command: []string{"psql", "-c", "CHECKPOINT"}},
+	}
+	backups := policyBackups(policy, "postgres-1")
+	if len(backups) != 2 {
+		t.Fatalf("policyBackups() = %d backups, want 2", len(backups))
+	}
+	for i, want := range []string{"data", "wal"} {
+		backup := backups[i]
+		if backup.Name != "postgres-1-"+want || backup.Spec.Target.PVCName != want || backup.Spec.Group != "postgres-1" ||
+			backup.Spec.PreHook == nil || backup.Labels[backupv1alpha1.GroupLabel] != "postgres-1" ||
+			backup.Labels[backupv1alpha1.TargetLabel] != want {
+			t.Errorf("member %d = %+v", i, backup)
+		}
+	}
+	if backups[0].Spec.PreHook == backups[1].Spec.PreHook {
+		t.Error("members share the policy's pre-hook instead of copying it")
+	}
+}
+
+func TestGroupPreHook(t *testing.T) {
+	ctx := context.Background()
+
+	member := func(pvc string) *backupv1alpha1.Backup {
+		return &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly-" + pvc,
+				Namespace: "default",
+				Labels:    map[string]string{backupv1alpha1.GroupLabel: "nightly"},
+			},
+			Spec: backupv1alpha1.BackupSpec{
+				Target:  backupv1alpha1.BackupTarget{PVCName: pvc},
+				Group:   "nightly",
+				PreHook: &backupv1alpha1.BackupHook{Image: "postgres:16", Command: []string{"psql", "-c", "CHECKPOINT"}},
+			},
+		}
+	}
+	newReconciler := func() (*BackupReconciler, *clocktesting.FakeClock, *record.FakeRecorder) {
+		clock := clocktesting.NewFakeClock(transitionStart)
+		recorder := record.NewFakeRecorder(100)
+		return &BackupReconciler{
+			Client:   newTransitionClient(t, member("data"), member("wal")),
+			Recorder: recorder,
+			Clock:    clock,
+		}, clock, recorder
+	}
+	reconcileMember := func(r *BackupReconciler, name string) *backupv1alpha1.Backup {
+		t.Helper()
+		key := types.NamespacedName{Name: name, Namespace: "default"}
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		var backup backupv1alpha1.Backup
+		if err := r.Get(ctx, key, &backup); err != nil {
+			t.Fatal(err)
+		}
+		return &backup
+	}
+	expectMoverJob := func(r *BackupReconciler, backup string, want bool) {
+		t.Helper()
+		var job batchv1.Job
+		err := r.Get(ctx, types.NamespacedName{Name: backup + "-job", Namespace: "default"}, &job)
+		if got := err == nil; got != want {
+			t.Errorf("mover Job of %s exists = %v, want %v", backup, got, want)
+		}
+	}
+
+	t.Run("members start together once the hook succeeded", func(t *testing.T) {
+		r, clock, recorder := newReconciler()
+
+		data := reconcileMember(r, "nightly-data")
+		if data.Status.Phase != backupv1alpha1.BackupPhasePending {
+			t.Errorf("phase = %s, want Pending", data.Status.Phase)
+		}
+		var hook batchv1.Job
+		if err := r.Get(ctx, types.NamespacedName{Name: "nightly-pre-hook", Namespace: "default"}, &hook); err != nil {
+			t.Fatalf("pre-hook Job not created: %v", err)
+		}
+		if container := hook.Spec.Template.Spec.Containers[0]; container.Image != "postgres:16" || container.Command[2] != "CHECKPOINT" {
+			t.Errorf("pre-hook container = %+v", container)
+		}
+		if len(hook.OwnerReferences) != 2 {
+			t.Errorf("pre-hook owners = %+v, want both members", hook.OwnerReferences)
+		}
+		expectEvents(t, recorder, "PreHookStarted")
+
+		// The second member waits for the same hook
+		reconcileMember(r, "nightly-wal")
+		expectEvents(t, recorder)
+		expectMoverJob(r, "nightly-data", false)
+		expectMoverJob(r, "nightly-wal", false)
+
+		requests := r.preHookMembers(ctx, &hook)
+		if len(requests) != 2 {
+			t.Errorf("pre-hook Job wakes %v, want both members", requests)
+		}
+
+		setJobCondition(t, r.Client, "nightly-pre-hook", batchv1.JobComplete, clock.Now())
+		for _, name := range []string{"nightly-data", "nightly-wal"} {
+			if backup := reconcileMember(r, name); backup.Status.Phase != backupv1alpha1.BackupPhaseRunning || backup.Status.Attempts != 1 {
+				t.Errorf("%s status = %+v, want the first attempt running", name, backup.Status)
+			}
+			expectMoverJob(r, name, true)
+		}
+		expectEvents(t, recorder, "BackupStarted", "JobCreated", "BackupStarted", "JobCreated")
+
+		// Once a member started, the hook is not run again even if its Job is gone
+		if err := r.Delete(ctx, &hook); err != nil {
+			t.Fatal(err)
+		}
+		if ready, err := r.reconcilePreHook(ctx, member("data")); !ready || err != nil {
+			t.Errorf("reconcilePreHook() = %v, %v; want ready", ready, err)
+		}
+	})
+
+	t.Run("a failed hook fails every member", func(t *testing.T) {
+		r, clock, recorder := newReconciler()
+
+		reconcileMember(r, "nightly-data")
+		setJobCondition(t, r.Client, "nightly-pre-hook", batchv1.JobFailed, clock.Now())
+		for _, name := range []string{"nightly-data", "nightly-wal"} {
+			if backup := reconcileMember(r, name); backup.Status.Phase != backupv1alpha1.BackupPhaseFailed {
+				t.Errorf("%s phase = %s, want Failed", name, backup.Status.Phase)
+			}
+			expectMoverJob(r, name, false)
+		}
+		expectEvents(t, recorder, "PreHookStarted", "PreHookFailed", "PreHookFailed")
+	})
+}
+
+func TestGroupRestore(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "recover", Namespace: "default"}
+
+	member := func(pvc string, phase backupv1alpha1.BackupPhase) *backupv1alpha1.Backup {
+		return &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly-" + pvc,
+				Namespace: "default",
+				Labels:    map[string]string{backupv1alpha1.GroupLabel: "nightly"},
+			},
+			Spec: backupv1alpha1.BackupSpec{
+				Target: backupv1alpha1.BackupTarget{PVCName: pvc},
+				Group:  "nightly",
+			},
+			Status: backupv1alpha1.BackupStatus{Phase: phase},
+		}
+	}
+	newReconciler := func(walPhase backupv1alpha1.BackupPhase) (*RestoreReconciler, *record.FakeRecorder) {
+		recorder := record.NewFakeRecorder(100)
+		return &RestoreReconciler{
+			Client: newTransitionClient(t,
+				member("data", backupv1alpha1.BackupPhaseCompleted),
+				member("wal", walPhase),
+				&backupv1alpha1.Restore{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec:       backupv1alpha1.RestoreSpec{Group: "nightly"},
+				},
+			),
+			Recorder: recorder,
+			Clock:    clocktesting.NewFakeClock(transitionStart),
+		}, recorder
+	}
+	fetch := func(c client.Client, name string) *backupv1alpha1.Restore {
+		t.Helper()
+		var restore backupv1alpha1.Restore
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &restore); err != nil {
+			t.Fatalf("fetching Restore %s: %v", name, err)
+		}
+		return &restore
+	}
+	setPhase := func(c client.Client, name string, phase backupv1alpha1.RestorePhase) {
+		t.Helper()
+		restore := fetch(c, name)
+		restore.Status.Phase = phase
+		if err := c.Status().Update(ctx, restore); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("restores every member into its own PVC", func(t *testing.T) {
+		r, recorder := newReconciler(backupv1alpha1.BackupPhaseCompleted)
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		restore := fetch(r.Client, key.Name)
+		if restore.Status.Phase != backupv1alpha1.RestorePhaseRunning || len(restore.Status.Members) != 2 {
+			t.Fatalf("status = %+v", restore.Status)
+		}
+		for _, pvc := range []string{"data", "wal"} {
+			memberRestore := fetch(r.Client, "recover-"+pvc)
+			if memberRestore.Spec.BackupName != "nightly-"+pvc || memberRestore.Spec.TargetPVC != pvc ||
+				memberRestore.Spec.BackupNamespace != "default" || !metav1.IsControlledBy(memberRestore, restore) {
+				t.Errorf("member Restore = %+v", memberRestore)
+			}
+		}
+		expectEvents(t, recorder, "RestoreStarted")
+
+		setPhase(r.Client, "recover-data", backupv1alpha1.RestorePhaseCompleted)
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		if restore := fetch(r.Client, key.Name); restore.Status.Phase != backupv1alpha1.RestorePhaseRunning ||
+			restore.Status.Members[0].Phase != backupv1alpha1.RestorePhaseCompleted {
+			t.Errorf("status = %+v", restore.Status)
+		}
+
+		setPhase(r.Client, "recover-wal", backupv1alpha1.RestorePhaseCompleted)
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		if restore := fetch(r.Client, key.Name); restore.Status.Phase != backupv1alpha1.RestorePhaseCompleted {
+			t.Errorf("phase = %s, want Completed", restore.Status.Phase)
+		}
+		expectEvents(t, recorder, "RestoreCompleted")
+	})
+
+	t.Run("refuses a group with an incomplete member", func(t *testing.T) {
+		r, recorder := newReconciler(backupv1alpha1.BackupPhaseFailed)
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		if restore := fetch(r.Client, key.Name); restore.Status.Phase != backupv1alpha1.RestorePhaseFailed {
+			t.Errorf("phase = %s, want Failed", restore.Status.Phase)
+		}
+		var restores backupv1alpha1.RestoreList
+		if err := r.List(ctx, &restores); err != nil || len(restores.Items) != 1 {
+			t.Errorf("member Restores were created: %d Restores, %v", len(restores.Items), err)
+		}
+		expectEvents(t, recorder, "BackupNotReady")
+	})
+
+	t.Run("cancels the members still running", func(t *testing.T) {
+		r, recorder := newReconciler(backupv1alpha1.BackupPhaseCompleted)
+
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		setPhase(r.Client, "recover-data", backupv1alpha1.RestorePhaseCompleted)
+		setCancel(t, r.Client, fetch(r.Client, key.Name))
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		if fetch(r.Client, "recover-data").Spec.Cancel || !fetch(r.Client, "recover-wal").Spec.Cancel {
+			t.Error("cancel not propagated to exactly the unfinished member")
+		}
+
+		setPhase(r.Client, "recover-wal", backupv1alpha1.RestorePhaseCancelled)
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		if restore := fetch(r.Client, key.Name); restore.Status.Phase != backupv1alpha1.RestorePhaseCancelled {
+			t.Errorf("phase = %s, want Cancelled", restore.Status.Phase)
+		}
+		expectEvents(t, recorder, "RestoreStarted", "RestoreCancelled")
+	})
+}
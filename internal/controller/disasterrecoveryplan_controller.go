@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// DisasterRecoveryPlanReconciler validates DisasterRecoveryPlans, so a broken
+// plan is found before it is needed
+type DisasterRecoveryPlanReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=disasterrecoveryplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=disasterrecoveryplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=disasterrecoveryplans/finalizers,verbs=update
+
+// Reconcile checks a plan's steps and dependencies and records the order its
+// steps run in
+func (r *DisasterRecoveryPlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var plan backupv1alpha1.DisasterRecoveryPlan
+	if err := r.Get(ctx, req.NamespacedName, &plan); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	base := plan.DeepCopy()
+	order, err := planOrder(plan.Spec.Steps)
+	if err != nil {
+		if degraded := meta.FindStatusCondition(plan.Status.Conditions, backupv1alpha1.ConditionDegraded); degraded == nil ||
+			degraded.Reason != "InvalidPlan" || degraded.ObservedGeneration != plan.Generation {
+			r.Recorder.Event(
+				&plan,
+				corev1.EventTypeWarning,
+				"InvalidPlan",
+				err.Error(),
+			)
+		}
+		plan.Status.StepOrder = nil
+		setConditions(&plan.Status.Conditions, plan.Generation, "InvalidPlan", err.Error(),
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+	} else {
+		plan.Status.StepOrder = order
+		setConditions(&plan.Status.Conditions, plan.Generation, "Valid",
+			fmt.Sprintf("Plan has %d steps: %s", len(order), strings.Join(order, ", ")),
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+	}
+	if err := patchPlanStatus(ctx, r.Client, &plan, base); err != nil {
+		log.Error(err, "unable to update DisasterRecoveryPlan status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// planOrder validates a plan's steps and returns their names in an order that
+// respects dependsOn. Steps that do not depend on each other keep the order
+// they are listed in.
+func planOrder(steps []backupv1alpha1.RecoveryStep) ([]string, error) {
+	names := map[string]bool{}
+	for _, step := range steps {
+		if names[step.Name] {
+			return nil, fmt.Errorf("step %q is defined more than once", step.Name)
+		}
+		names[step.Name] = true
+		if (step.BackupName == "") == (step.PolicyRef == "") {
+			return nil, fmt.Errorf("step %q must set exactly one of backupName and policyRef", step.Name)
+		}
+		for i, action := range step.PostRestore {
+			if (action.Scale == nil) == (action.ReadinessCheck == nil) {
+				return nil, fmt.Errorf("post-restore action %d of step %q must set exactly one of scale and readinessCheck", i+1, step.Name)
+			}
+		}
+	}
+	for _, step := range steps {
+		for _, dependency := range step.DependsOn {
+			if !names[dependency] {
+				return nil, fmt.Errorf("step %q depends on unknown step %q", step.Name, dependency)
+			}
+		}
+	}
+
+	order := make([]string, 0, len(steps))
+	ordered := map[string]bool{}
+	for len(order) < len(steps) {
+		progressed := false
+		for _, step := range steps {
+			if ordered[step.Name] || !allOrdered(step.DependsOn, ordered) {
+				continue
+			}
+			order = append(order, step.Name)
+			ordered[step.Name] = true
+			progressed = true
+		}
+		if !progressed {
+			var cycle []string
+			for _, step := range steps {
+				if !ordered[step.Name] {
+					cycle = append(cycle, step.Name)
+				}
+			}
+			return nil, fmt.Errorf("steps %s have circular dependencies", strings.Join(cycle, ", "))
+		}
+	}
+	return order, nil
+}
+
+// allOrdered reports whether every name is in ordered
+func allOrdered(names []string, ordered map[string]bool) bool {
+	for _, name := range names {
+		if !ordered[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DisasterRecoveryPlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor("disasterrecoveryplan-controller")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&backupv1alpha1.DisasterRecoveryPlan{}).
+		Named("disasterrecoveryplan").
+		Complete(r)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestPlanOrder(t *testing.T) {
+	step := func(name string, dependsOn ...string) backupv1alpha1.RecoveryStep {
+		return backupv1alpha1.RecoveryStep{Name: name, BackupName: name + "-backup", TargetPVC: name, DependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name    string
+		steps   []backupv1alpha1.RecoveryStep
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "independent steps keep their order",
+			steps: []backupv1alpha1.RecoveryStep{step("cache"), step("db")},
+			want:  "cache,db",
+		},
+		{
+			name:  "dependencies run first",
+			steps: []backupv1alpha1.RecoveryStep{step("app", "db", "cache"), step("cache", "db"), step("db"), step("search")},
+			want:  "db,search,cache,app",
+		},
+		{
+			name:    "unknown dependency",
+			steps:   []backupv1alpha1.RecoveryStep{step("app", "db")},
+			wantErr: `step "app" depends on unknown step "db"`,
+		},
+		{
+			name:    "cycle",
+			steps:   []backupv1alpha1.RecoveryStep{step("db"), step("a", "b"), step("b", "a")},
+			wantErr: "steps a, b have circular dependencies",
+		},
+		{
+			name:    "duplicate step",
+			steps:   []backupv1alpha1.RecoveryStep{step("db"), step("db")},
+			wantErr: `step "db" is defined more than once`,
+		},
+		{
+			name:    "backup source missing",
+			steps:   []backupv1alpha1.RecoveryStep{{Name: "db", TargetPVC: "db"}},
+			wantErr: `step "db" must set exactly one of backupName and policyRef`,
+		},
+		{
+			name: "action without a kind",
+			steps: []backupv1alpha1.RecoveryStep{{Name: "db", PolicyRef: "nightly", TargetPVC: "db",
+				PostRestore: []backupv1alpha1.PostRestoreAction{{}}}},
+			wantErr: `post-restore action 1 of step "db" must set exactly one of scale and readinessCheck`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := planOrder(tt.steps)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("planOrder() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("planOrder() error = %v", err)
+			}
+			if got := strings.Join(order, ","); got != tt.want {
+				t.Errorf("planOrder() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,638 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+const (
+	// defaultActionTimeout bounds a post-restore action without a timeout
+	defaultActionTimeout = 10 * time.Minute
+
+	// workloadPollInterval is how often a scaled workload is checked for ready
+	// replicas; workloads are not watched
+	workloadPollInterval = 10 * time.Second
+)
+
+// DRExecutionReconciler runs DisasterRecoveryPlans
+type DRExecutionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Config holds the operator-level defaults; nil means built-in defaults
+	Config *config.Store
+
+	// Pods reads readiness check pods to explain failures; nil skips the details
+	Pods corev1client.PodsGetter
+
+	// Clock is used for step timestamps and action timeouts; set to the real
+	// clock by SetupWithManager if nil
+	Clock clock.Clock
+}
+
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=drexecutions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=drexecutions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=drexecutions/finalizers,verbs=update
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=disasterrecoveryplans,verbs=get;list;watch
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=restores,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+// Reconcile advances every step of the execution's plan: a step creates its
+// Restore once its dependencies completed, then runs its post-restore
+// actions in order. Steps are fixed when the execution starts; their
+// settings are read from the plan as they run.
+func (r *DRExecutionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var execution backupv1alpha1.DRExecution
+	if err := r.Get(ctx, req.NamespacedName, &execution); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if executionFinished(execution.Status.Phase) {
+		return ctrl.Result{}, nil
+	}
+
+	var plan backupv1alpha1.DisasterRecoveryPlan
+	if err := r.Get(ctx, client.ObjectKey{Name: execution.Spec.PlanRef, Namespace: execution.Namespace}, &plan); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.failExecution(ctx, &execution, "PlanNotFound",
+				fmt.Sprintf("DisasterRecoveryPlan %s not found", execution.Spec.PlanRef))
+		}
+		return ctrl.Result{}, err
+	}
+	order, err := planOrder(plan.Spec.Steps)
+	if err != nil {
+		return ctrl.Result{}, r.failExecution(ctx, &execution, "InvalidPlan", err.Error())
+	}
+
+	base := execution.DeepCopy()
+	if execution.Status.Phase == "" {
+		execution.Status.Phase = backupv1alpha1.DRExecutionPhaseRunning
+		now := metav1.NewTime(r.Clock.Now())
+		execution.Status.StartTime = &now
+		execution.Status.TotalSteps = int32(len(order))
+		for _, name := range order {
+			execution.Status.Steps = append(execution.Status.Steps, backupv1alpha1.RecoveryStepStatus{
+				Name:  name,
+				Phase: backupv1alpha1.RecoveryStepPhasePending,
+			})
+		}
+		r.Recorder.Eventf(
+			&execution,
+			corev1.EventTypeNormal,
+			"ExecutionStarted",
+			"Running plan %s with %d steps",
+			plan.Name,
+			len(order),
+		)
+	}
+
+	steps := map[string]*backupv1alpha1.RecoveryStep{}
+	for i := range plan.Spec.Steps {
+		steps[plan.Spec.Steps[i].Name] = &plan.Spec.Steps[i]
+	}
+
+	// Steps are in dependency order, so a step can start in the same pass its
+	// dependencies complete in
+	var requeueAfter time.Duration
+	var stepErr error
+	for i := range execution.Status.Steps {
+		status := &execution.Status.Steps[i]
+		step, ok := steps[status.Name]
+		if !ok {
+			r.finishStep(&execution, status, backupv1alpha1.RecoveryStepPhaseFailed, "Step was removed from the plan")
+			continue
+		}
+		after, err := r.runStep(ctx, &execution, step, status)
+		if err != nil {
+			log.Error(err, "unable to run recovery step", "step", status.Name)
+			stepErr = err
+			break
+		}
+		requeueAfter = earliestRequeue(requeueAfter, after)
+	}
+
+	r.summarizeExecution(&execution)
+	if !equality.Semantic.DeepEqual(base.Status, execution.Status) {
+		if err := patchExecutionStatus(ctx, r.Client, &execution, base); err != nil {
+			log.Error(err, "unable to update DRExecution status")
+			return ctrl.Result{}, err
+		}
+	}
+	if stepErr != nil || executionFinished(execution.Status.Phase) {
+		return ctrl.Result{}, stepErr
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// runStep advances one step and returns how long until it needs another look
+// when no watch event will trigger one
+func (r *DRExecutionReconciler) runStep(ctx context.Context, execution *backupv1alpha1.DRExecution,
+	step *backupv1alpha1.RecoveryStep, status *backupv1alpha1.RecoveryStepStatus) (time.Duration, error) {
+	switch status.Phase {
+	case backupv1alpha1.RecoveryStepPhasePending:
+		for _, dependency := range step.DependsOn {
+			dependencyStatus := findStep(execution.Status.Steps, dependency)
+			switch {
+			case dependencyStatus == nil,
+				dependencyStatus.Phase == backupv1alpha1.RecoveryStepPhaseFailed,
+				dependencyStatus.Phase == backupv1alpha1.RecoveryStepPhaseSkipped:
+				r.finishStep(execution, status, backupv1alpha1.RecoveryStepPhaseSkipped, "Dependency "+dependency+" did not complete")
+				return 0, nil
+			case dependencyStatus.Phase != backupv1alpha1.RecoveryStepPhaseCompleted:
+				status.Message = "Waiting for " + dependency
+				return 0, nil
+			}
+		}
+		return 0, r.startStep(ctx, execution, step, status)
+
+	case backupv1alpha1.RecoveryStepPhaseRestoring:
+		var restore backupv1alpha1.Restore
+		err := r.Get(ctx, client.ObjectKey{Name: status.RestoreName, Namespace: execution.Namespace}, &restore)
+		if apierrors.IsNotFound(err) {
+			r.finishStep(execution, status, backupv1alpha1.RecoveryStepPhaseFailed, "Restore "+status.RestoreName+" was deleted")
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		// The Restore is owned by the execution, so its phase changes trigger a reconcile
+		switch restore.Status.Phase {
+		case backupv1alpha1.RestorePhaseCompleted:
+			if len(step.PostRestore) == 0 {
+				r.finishStep(execution, status, backupv1alpha1.RecoveryStepPhaseCompleted,
+					fmt.Sprintf("Restored backup %s into PVC %s", status.BackupName, step.TargetPVC))
+				return 0, nil
+			}
+			status.Phase = backupv1alpha1.RecoveryStepPhasePostRestore
+			status.CurrentAction = 0
+			return r.runActions(ctx, execution, step, status)
+		case backupv1alpha1.RestorePhaseFailed, backupv1alpha1.RestorePhaseCancelled:
+			message := fmt.Sprintf("Restore %s %s", restore.Name, strings.ToLower(string(restore.Status.Phase)))
+			if ready := meta.FindStatusCondition(restore.Status.Conditions, backupv1alpha1.ConditionReady); ready != nil && ready.Message != "" {
+				message += ": " + ready.Message
+			}
+			r.finishStep(execution, status, backupv1alpha1.RecoveryStepPhaseFailed, message)
+		}
+		return 0, nil
+
+	case backupv1alpha1.RecoveryStepPhasePostRestore:
+		return r.runActions(ctx, execution, step, status)
+	}
+	return 0, nil
+}
+
+// startStep resolves the Backup a step restores and creates its Restore
+func (r *DRExecutionReconciler) startStep(ctx context.Context, execution *backupv1alpha1.DRExecution,
+	step *backupv1alpha1.RecoveryStep, status *backupv1alpha1.RecoveryStepStatus) error {
+	namespace := stepNamespace(execution, step)
+	backupName := step.BackupName
+	if backupName == "" {
+		backup, err := latestCompletedBackup(ctx, r.Client, namespace, step.PolicyRef)
+		if err != nil {
+			return err
+		}
+		if backup == nil {
+			r.finishStep(execution, status, backupv1alpha1.RecoveryStepPhaseFailed,
+				fmt.Sprintf("BackupPolicy %s has no completed backup in namespace %s", step.PolicyRef, namespace))
+			return nil
+		}
+		backupName = backup.Name
+	}
+
+	restore := &backupv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      boundedName(execution.Name+"-"+step.Name, maxJobNameLength),
+			Namespace: execution.Namespace,
+			Labels: map[string]string{
+				backupv1alpha1.DRExecutionLabel: execution.Name,
+				backupv1alpha1.DRStepLabel:      step.Name,
+			},
+			OwnerReferences: executionOwnerReferences(execution),
+		},
+		Spec: backupv1alpha1.RestoreSpec{
+			BackupName:      backupName,
+			TargetPVC:       step.TargetPVC,
+			TargetNamespace: namespace,
+			BackupNamespace: namespace,
+			Retry:           step.Retry.DeepCopy(),
+		},
+	}
+	if err := r.Create(ctx, restore); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	logf.FromContext(ctx).Info("Created Restore for recovery step", "step", step.Name, "restore", restore.Name, "backup", backupName)
+
+	status.Phase = backupv1alpha1.RecoveryStepPhaseRestoring
+	status.BackupName = backupName
+	status.RestoreName = restore.Name
+	status.Message = fmt.Sprintf("Restoring backup %s into PVC %s", backupName, step.TargetPVC)
+	return nil
+}
+
+// runActions runs the step's post-restore actions in order, starting at the
+// current one, until one is still in progress or all are done
+func (r *DRExecutionReconciler) runActions(ctx context.Context, execution *backupv1alpha1.DRExecution,
+	step *backupv1alpha1.RecoveryStep, status *backupv1alpha1.RecoveryStepStatus) (time.Duration, error) {
+	now := r.Clock.Now()
+	for int(status.CurrentAction) < len(step.PostRestore) {
+		index := int(status.CurrentAction)
+		action := &step.PostRestore[index]
+		if status.ActionStartTime == nil {
+			status.ActionStartTime = &metav1.Time{Time: now}
+		}
+		timeout := defaultActionTimeout
+		if action.Timeout != nil {
+			timeout = action.Timeout.Duration
+		}
+
+		var done bool
+		var failure string
+		var err error
+		pollAfter := time.Duration(0)
+		switch {
+		case action.Scale != nil:
+			done, failure, err = r.scaleWorkload(ctx, stepNamespace(execution, step), action.Scale)
+			pollAfter = workloadPollInterval
+			status.Message = fmt.Sprintf("Waiting for %s %s to have %d ready replicas", action.Scale.Kind, action.Scale.Name, action.Scale.Replicas)
+		case action.ReadinessCheck != nil:
+			done, failure, err = r.runReadinessCheck(ctx, execution, step, index, action.ReadinessCheck, timeout)
+			status.Message = fmt.Sprintf("Running readiness check %d", index+1)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if failure != "" {
+			r.finishStep(execution, status, backupv1alpha1.RecoveryStepPhaseFailed,
+				fmt.Sprintf("Post-restore action %d failed: %s", index+1, failure))
+			return 0, nil
+		}
+		if !done {
+			remaining := status.ActionStartTime.Add(timeout).Sub(now)
+			if remaining <= 0 {
+				r.finishStep(execution, status, backupv1alpha1.RecoveryStepPhaseFailed,
+					fmt.Sprintf("Post-restore action %d did not finish within %s", index+1, timeout))
+				return 0, nil
+			}
+			if pollAfter > 0 {
+				return min(pollAfter, remaining), nil
+			}
+			return remaining, nil
+		}
+
+		status.CurrentAction++
+		status.ActionStartTime = nil
+	}
+
+	r.finishStep(execution, status, backupv1alpha1.RecoveryStepPhaseCompleted,
+		fmt.Sprintf("Restored backup %s into PVC %s and ran %d post-restore actions", status.BackupName, step.TargetPVC, len(step.PostRestore)))
+	return 0, nil
+}
+
+// scaleWorkload sets the replicas of a Deployment or StatefulSet and reports
+// whether they are all ready, or why the workload cannot be scaled
+func (r *DRExecutionReconciler) scaleWorkload(ctx context.Context, namespace string, scale *backupv1alpha1.ScaleAction) (bool, string, error) {
+	if scale.Namespace != "" {
+		namespace = scale.Namespace
+	}
+	key := client.ObjectKey{Name: scale.Name, Namespace: namespace}
+
+	var workload client.Object
+	var replicas **int32
+	var ready func() bool
+	switch scale.Kind {
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		workload, replicas = statefulSet, &statefulSet.Spec.Replicas
+		ready = func() bool {
+			return workloadReady(statefulSet.Generation, statefulSet.Status.ObservedGeneration,
+				statefulSet.Status.Replicas, statefulSet.Status.ReadyReplicas, scale.Replicas)
+		}
+	default:
+		deployment := &appsv1.Deployment{}
+		workload, replicas = deployment, &deployment.Spec.Replicas
+		ready = func() bool {
+			return workloadReady(deployment.Generation, deployment.Status.ObservedGeneration,
+				deployment.Status.Replicas, deployment.Status.ReadyReplicas, scale.Replicas)
+		}
+	}
+
+	if err := r.Get(ctx, key, workload); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, fmt.Sprintf("%s %s/%s not found", scale.Kind, namespace, scale.Name), nil
+		}
+		return false, "", err
+	}
+	if *replicas == nil || **replicas != scale.Replicas {
+		patch := client.MergeFrom(workload.DeepCopyObject().(client.Object))
+		*replicas = ptr.To(scale.Replicas)
+		if err := r.Patch(ctx, workload, patch); err != nil {
+			return false, "", err
+		}
+		logf.FromContext(ctx).Info("Scaled workload", "kind", scale.Kind, "name", scale.Name, "replicas", scale.Replicas)
+		return false, "", nil
+	}
+	return ready(), "", nil
+}
+
+// workloadReady reports whether a workload's controller has caught up with
+// its spec and runs exactly the wanted number of ready replicas
+func workloadReady(generation, observedGeneration int64, replicas, readyReplicas, want int32) bool {
+	return observedGeneration >= generation && replicas == want && readyReplicas >= want
+}
+
+// runReadinessCheck runs a check in a Job and reports whether it passed, or
+// why it failed
+func (r *DRExecutionReconciler) runReadinessCheck(ctx context.Context, execution *backupv1alpha1.DRExecution,
+	step *backupv1alpha1.RecoveryStep, index int, check *backupv1alpha1.ReadinessCheck, timeout time.Duration) (bool, string, error) {
+	var job batchv1.Job
+	name := checkJobName(execution, step.Name, index)
+	err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: execution.Namespace}, &job)
+	if apierrors.IsNotFound(err) {
+		job := r.createCheckJob(execution, step.Name, name, check, timeout)
+		if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, "", err
+		}
+		logf.FromContext(ctx).Info("Created readiness check Job", "step", step.Name, "jobName", name)
+		return false, "", nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+
+	if jobCondition(&job, batchv1.JobComplete) != nil {
+		return true, "", nil
+	}
+	if failed := jobCondition(&job, batchv1.JobFailed); failed != nil {
+		return false, describeJobFailure(ctx, r.Pods, &job, failed, r.Config.Get().FailureLogLines()), nil
+	}
+	return false, "", nil
+}
+
+// createCheckJob builds the Job of a readiness check. It runs once, within
+// the action's timeout.
+func (r *DRExecutionReconciler) createCheckJob(execution *backupv1alpha1.DRExecution, step, name string,
+	check *backupv1alpha1.ReadinessCheck, timeout time.Duration) *batchv1.Job {
+	cfg := r.Config.Get()
+	image := check.Image
+	if image == "" {
+		image = cfg.MoverImage
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: execution.Namespace,
+			Labels: map[string]string{
+				backupv1alpha1.DRExecutionLabel: execution.Name,
+				backupv1alpha1.DRStepLabel:      step,
+			},
+			OwnerReferences: executionOwnerReferences(execution),
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "check",
+							Image:   image,
+							Command: check.Command,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	applyJobTemplates(&job.Spec.Template.Spec, cfg.DefaultJobTemplate)
+	applyJobLimits(job, &metav1.Duration{Duration: timeout}, cfg.JobTTL())
+	useFallbackTerminationMessages(&job.Spec.Template.Spec)
+	return job
+}
+
+// finishStep moves a step to a terminal phase and records an event for it
+func (r *DRExecutionReconciler) finishStep(execution *backupv1alpha1.DRExecution, status *backupv1alpha1.RecoveryStepStatus,
+	phase backupv1alpha1.RecoveryStepPhase, message string) {
+	now := metav1.NewTime(r.Clock.Now())
+	status.Phase = phase
+	status.CompletionTime = &now
+	status.ActionStartTime = nil
+	status.Message = message
+
+	eventType, reason := corev1.EventTypeNormal, "StepCompleted"
+	switch phase {
+	case backupv1alpha1.RecoveryStepPhaseFailed:
+		eventType, reason = corev1.EventTypeWarning, "StepFailed"
+	case backupv1alpha1.RecoveryStepPhaseSkipped:
+		eventType, reason = corev1.EventTypeWarning, "StepSkipped"
+	}
+	r.Recorder.Event(
+		execution,
+		eventType,
+		reason,
+		eventMessage("Step "+status.Name+": "+message),
+	)
+}
+
+// summarizeExecution aggregates the steps into the execution's phase and conditions
+func (r *DRExecutionReconciler) summarizeExecution(execution *backupv1alpha1.DRExecution) {
+	var completed int32
+	var running, failed, skipped []string
+	for _, step := range execution.Status.Steps {
+		switch step.Phase {
+		case backupv1alpha1.RecoveryStepPhaseCompleted:
+			completed++
+		case backupv1alpha1.RecoveryStepPhaseFailed:
+			failed = append(failed, step.Name)
+		case backupv1alpha1.RecoveryStepPhaseSkipped:
+			skipped = append(skipped, step.Name)
+		default:
+			running = append(running, step.Name)
+		}
+	}
+	execution.Status.CompletedSteps = completed
+
+	degraded := metav1.ConditionFalse
+	if len(failed) > 0 {
+		degraded = metav1.ConditionTrue
+	}
+	if len(running) > 0 {
+		message := fmt.Sprintf("%d of %d steps completed, in progress: %s", completed, len(execution.Status.Steps), strings.Join(running, ", "))
+		if len(failed) > 0 {
+			message += "; failed: " + strings.Join(failed, ", ")
+		}
+		setConditions(&execution.Status.Conditions, execution.Generation, "Running", message,
+			metav1.ConditionFalse, metav1.ConditionTrue, degraded)
+		return
+	}
+
+	now := metav1.NewTime(r.Clock.Now())
+	execution.Status.CompletionTime = &now
+	if len(failed) == 0 && len(skipped) == 0 {
+		execution.Status.Phase = backupv1alpha1.DRExecutionPhaseCompleted
+		message := fmt.Sprintf("All %d steps completed", completed)
+		setConditions(&execution.Status.Conditions, execution.Generation, "ExecutionCompleted", message,
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+		r.Recorder.Event(
+			execution,
+			corev1.EventTypeNormal,
+			"ExecutionCompleted",
+			message,
+		)
+		return
+	}
+
+	execution.Status.Phase = backupv1alpha1.DRExecutionPhaseFailed
+	message := fmt.Sprintf("%d of %d steps completed; failed: %s", completed, len(execution.Status.Steps), strings.Join(failed, ", "))
+	if len(skipped) > 0 {
+		message += "; skipped: " + strings.Join(skipped, ", ")
+	}
+	setConditions(&execution.Status.Conditions, execution.Generation, "ExecutionFailed", message,
+		metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+	r.Recorder.Event(
+		execution,
+		corev1.EventTypeWarning,
+		"ExecutionFailed",
+		eventMessage(message),
+	)
+}
+
+// failExecution fails an execution whose plan cannot be run
+func (r *DRExecutionReconciler) failExecution(ctx context.Context, execution *backupv1alpha1.DRExecution, reason, message string) error {
+	base := execution.DeepCopy()
+	execution.Status.Phase = backupv1alpha1.DRExecutionPhaseFailed
+	now := metav1.NewTime(r.Clock.Now())
+	execution.Status.CompletionTime = &now
+	setConditions(&execution.Status.Conditions, execution.Generation, reason, message,
+		metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+	if err := patchExecutionStatus(ctx, r.Client, execution, base); err != nil {
+		return err
+	}
+	r.Recorder.Event(
+		execution,
+		corev1.EventTypeWarning,
+		reason,
+		eventMessage(message),
+	)
+	return nil
+}
+
+// latestCompletedBackup returns the newest completed Backup of a policy, or nil
+func latestCompletedBackup(ctx context.Context, c client.Reader, namespace, policy string) (*backupv1alpha1.Backup, error) {
+	var backups backupv1alpha1.BackupList
+	if err := c.List(ctx, &backups, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	var latest *backupv1alpha1.Backup
+	for i := range backups.Items {
+		backup := &backups.Items[i]
+		if backup.Spec.PolicyRef != policy || backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
+			continue
+		}
+		if latest == nil || backup.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = backup
+		}
+	}
+	return latest, nil
+}
+
+// stepNamespace is where a step's PVC and Backup live
+func stepNamespace(execution *backupv1alpha1.DRExecution, step *backupv1alpha1.RecoveryStep) string {
+	if step.TargetNamespace != "" {
+		return step.TargetNamespace
+	}
+	return execution.Namespace
+}
+
+// findStep returns the status of the named step, or nil
+func findStep(steps []backupv1alpha1.RecoveryStepStatus, name string) *backupv1alpha1.RecoveryStepStatus {
+	for i := range steps {
+		if steps[i].Name == name {
+			return &steps[i]
+		}
+	}
+	return nil
+}
+
+// executionFinished reports whether a DRExecution phase is terminal
+func executionFinished(phase backupv1alpha1.DRExecutionPhase) bool {
+	return phase == backupv1alpha1.DRExecutionPhaseCompleted || phase == backupv1alpha1.DRExecutionPhaseFailed
+}
+
+// checkJobName is the name of the Job running a step's readiness check
+func checkJobName(execution *backupv1alpha1.DRExecution, step string, index int) string {
+	return boundedName(fmt.Sprintf("%s-%s-check-%d", execution.Name, step, index+1), maxJobNameLength)
+}
+
+// executionOwnerReferences makes a Restore or Job owned and garbage collected with the execution
+func executionOwnerReferences(execution *backupv1alpha1.DRExecution) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: backupv1alpha1.GroupVersion.String(),
+			Kind:       "DRExecution",
+			Name:       execution.Name,
+			UID:        execution.UID,
+			Controller: ptr.To(true),
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DRExecutionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor("drexecution-controller")
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&backupv1alpha1.DRExecution{}).
+		Owns(&backupv1alpha1.Restore{}).
+		Owns(&batchv1.Job{}).
+		Named("drexecution").
+		Complete(r)
+}
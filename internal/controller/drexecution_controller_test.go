@@ -0,0 +1,290 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestDRExecution(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "recovery", Namespace: "default"}
+
+	plan := &backupv1alpha1.DisasterRecoveryPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec: backupv1alpha1.DisasterRecoveryPlanSpec{
+			Steps: []backupv1alpha1.RecoveryStep{
+				{
+					Name:      "app",
+					PolicyRef: "uploads",
+					TargetPVC: "uploads",
+					DependsOn: []string{"db"},
+				},
+				{
+					Name:       "db",
+					BackupName: "db-nightly",
+					TargetPVC:  "postgres-data",
+					PostRestore: []backupv1alpha1.PostRestoreAction{
+						{Scale: &backupv1alpha1.ScaleAction{Kind: "StatefulSet", Name: "postgres", Replicas: 1}},
+						{ReadinessCheck: &backupv1alpha1.ReadinessCheck{Image: "postgres:16", Command: []string{"pg_isready"}}},
+					},
+				},
+			},
+		},
+	}
+	uploadsBackup := func(name string, created time.Time, phase backupv1alpha1.BackupPhase) *backupv1alpha1.Backup {
+		return &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(created)},
+			Spec:       backupv1alpha1.BackupSpec{PolicyRef: "uploads", Target: backupv1alpha1.BackupTarget{PVCName: "uploads"}},
+			Status:     backupv1alpha1.BackupStatus{Phase: phase},
+		}
+	}
+
+	newReconciler := func(objs ...client.Object) (*DRExecutionReconciler, *clocktesting.FakeClock, *record.FakeRecorder) {
+		clock := clocktesting.NewFakeClock(transitionStart)
+		recorder := record.NewFakeRecorder(100)
+		objs = append(objs, &backupv1alpha1.DRExecution{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       backupv1alpha1.DRExecutionSpec{PlanRef: "prod"},
+		})
+		return &DRExecutionReconciler{
+			Client:   newTransitionClient(t, objs...),
+			Recorder: recorder,
+			Clock:    clock,
+		}, clock, recorder
+	}
+	fetch := func(r *DRExecutionReconciler) *backupv1alpha1.DRExecution {
+		t.Helper()
+		var execution backupv1alpha1.DRExecution
+		if err := r.Get(ctx, key, &execution); err != nil {
+			t.Fatal(err)
+		}
+		return &execution
+	}
+	expectStep := func(r *DRExecutionReconciler, name string, phase backupv1alpha1.RecoveryStepPhase) *backupv1alpha1.RecoveryStepStatus {
+		t.Helper()
+		step := findStep(fetch(r).Status.Steps, name)
+		if step == nil || step.Phase != phase {
+			t.Fatalf("step %s = %+v, want %s", name, step, phase)
+		}
+		return step
+	}
+	finishRestore := func(r *DRExecutionReconciler, name string, phase backupv1alpha1.RestorePhase, message string) {
+		t.Helper()
+		var restore backupv1alpha1.Restore
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &restore); err != nil {
+			t.Fatalf("fetching Restore %s: %v", name, err)
+		}
+		restore.Status.Phase = phase
+		meta.SetStatusCondition(&restore.Status.Conditions, metav1.Condition{
+			Type: backupv1alpha1.ConditionReady, Status: metav1.ConditionFalse, Reason: string(phase), Message: message,
+		})
+		if err := r.Status().Update(ctx, &restore); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reconcile := func(r *DRExecutionReconciler) ctrl.Result {
+		t.Helper()
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		return result
+	}
+
+	t.Run("restores steps in dependency order and runs post-restore actions", func(t *testing.T) {
+		statefulSet := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(0))},
+		}
+		r, clock, recorder := newReconciler(plan.DeepCopy(), statefulSet,
+			uploadsBackup("uploads-1", transitionStart.Add(-48*time.Hour), backupv1alpha1.BackupPhaseCompleted),
+			uploadsBackup("uploads-2", transitionStart.Add(-24*time.Hour), backupv1alpha1.BackupPhaseCompleted),
+			uploadsBackup("uploads-3", transitionStart.Add(-time.Hour), backupv1alpha1.BackupPhaseFailed),
+		)
+
+		expectNoRequeue(t, reconcile(r), nil)
+		execution := fetch(r)
+		if execution.Status.Phase != backupv1alpha1.DRExecutionPhaseRunning || execution.Status.TotalSteps != 2 ||
+			execution.Status.Steps[0].Name != "db" || execution.Status.Steps[1].Name != "app" {
+			t.Fatalf("status = %+v", execution.Status)
+		}
+		if app := expectStep(r, "app", backupv1alpha1.RecoveryStepPhasePending); app.Message != "Waiting for db" {
+			t.Errorf("app message = %q", app.Message)
+		}
+		var restore backupv1alpha1.Restore
+		if err := r.Get(ctx, types.NamespacedName{Name: "recovery-db", Namespace: "default"}, &restore); err != nil {
+			t.Fatalf("Restore not created: %v", err)
+		}
+		if restore.Spec.BackupName != "db-nightly" || restore.Spec.TargetPVC != "postgres-data" ||
+			restore.Labels[backupv1alpha1.DRStepLabel] != "db" || !metav1.IsControlledBy(&restore, execution) {
+			t.Errorf("Restore = %+v", restore.ObjectMeta)
+		}
+		expectEvents(t, recorder, "ExecutionStarted")
+
+		// The restore completes and the workload is scaled up
+		finishRestore(r, "recovery-db", backupv1alpha1.RestorePhaseCompleted, "")
+		if result := reconcile(r); result.RequeueAfter != workloadPollInterval {
+			t.Errorf("RequeueAfter = %v, want the workload poll interval", result.RequeueAfter)
+		}
+		expectStep(r, "db", backupv1alpha1.RecoveryStepPhasePostRestore)
+		if err := r.Get(ctx, client.ObjectKeyFromObject(statefulSet), statefulSet); err != nil {
+			t.Fatal(err)
+		}
+		if *statefulSet.Spec.Replicas != 1 {
+			t.Errorf("replicas = %d, want 1", *statefulSet.Spec.Replicas)
+		}
+
+		// Ready replicas move on to the readiness check
+		statefulSet.Status = appsv1.StatefulSetStatus{ObservedGeneration: statefulSet.Generation, Replicas: 1, ReadyReplicas: 1}
+		if err := r.Status().Update(ctx, statefulSet); err != nil {
+			t.Fatal(err)
+		}
+		clock.Step(time.Minute)
+		if result := reconcile(r); result.RequeueAfter != defaultActionTimeout {
+			t.Errorf("RequeueAfter = %v, want the action timeout", result.RequeueAfter)
+		}
+		if db := expectStep(r, "db", backupv1alpha1.RecoveryStepPhasePostRestore); db.CurrentAction != 1 {
+			t.Errorf("currentAction = %d, want 1", db.CurrentAction)
+		}
+		var job batchv1.Job
+		if err := r.Get(ctx, types.NamespacedName{Name: "recovery-db-check-2", Namespace: "default"}, &job); err != nil {
+			t.Fatalf("readiness check Job not created: %v", err)
+		}
+		if container := job.Spec.Template.Spec.Containers[0]; container.Image != "postgres:16" || container.Command[0] != "pg_isready" {
+			t.Errorf("readiness check container = %+v", container)
+		}
+
+		// The check passes, so the dependent step restores the newest completed backup of its policy
+		setJobCondition(t, r.Client, "recovery-db-check-2", batchv1.JobComplete, clock.Now())
+		expectNoRequeue(t, reconcile(r), nil)
+		expectStep(r, "db", backupv1alpha1.RecoveryStepPhaseCompleted)
+		if app := expectStep(r, "app", backupv1alpha1.RecoveryStepPhaseRestoring); app.BackupName != "uploads-2" {
+			t.Errorf("app restores %s, want uploads-2", app.BackupName)
+		}
+		expectEvents(t, recorder, "StepCompleted")
+
+		finishRestore(r, "recovery-app", backupv1alpha1.RestorePhaseCompleted, "")
+		expectNoRequeue(t, reconcile(r), nil)
+		execution = fetch(r)
+		if execution.Status.Phase != backupv1alpha1.DRExecutionPhaseCompleted || execution.Status.CompletedSteps != 2 ||
+			!meta.IsStatusConditionTrue(execution.Status.Conditions, backupv1alpha1.ConditionReady) {
+			t.Errorf("status = %+v", execution.Status)
+		}
+		expectEvents(t, recorder, "StepCompleted", "ExecutionCompleted")
+	})
+
+	t.Run("skips steps whose dependencies failed", func(t *testing.T) {
+		r, _, recorder := newReconciler(plan.DeepCopy())
+
+		reconcile(r)
+		finishRestore(r, "recovery-db", backupv1alpha1.RestorePhaseFailed, "Job failed after 3 attempts")
+		expectNoRequeue(t, reconcile(r), nil)
+
+		if db := expectStep(r, "db", backupv1alpha1.RecoveryStepPhaseFailed); db.Message != "Restore recovery-db failed: Job failed after 3 attempts" {
+			t.Errorf("db message = %q", db.Message)
+		}
+		expectStep(r, "app", backupv1alpha1.RecoveryStepPhaseSkipped)
+		execution := fetch(r)
+		if execution.Status.Phase != backupv1alpha1.DRExecutionPhaseFailed ||
+			!meta.IsStatusConditionTrue(execution.Status.Conditions, backupv1alpha1.ConditionDegraded) {
+			t.Errorf("status = %+v", execution.Status)
+		}
+		expectEvents(t, recorder, "ExecutionStarted", "StepFailed", "StepSkipped", "ExecutionFailed")
+	})
+
+	t.Run("fails an action that does not finish in time", func(t *testing.T) {
+		r, clock, _ := newReconciler(plan.DeepCopy(), &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+		})
+
+		reconcile(r)
+		finishRestore(r, "recovery-db", backupv1alpha1.RestorePhaseCompleted, "")
+		reconcile(r)
+		clock.Step(defaultActionTimeout - 5*time.Second)
+		if result := reconcile(r); result.RequeueAfter != 5*time.Second {
+			t.Errorf("RequeueAfter = %v, want the rest of the timeout", result.RequeueAfter)
+		}
+		clock.Step(5 * time.Second)
+		reconcile(r)
+		if db := expectStep(r, "db", backupv1alpha1.RecoveryStepPhaseFailed); db.Message != "Post-restore action 1 did not finish within 10m0s" {
+			t.Errorf("db message = %q", db.Message)
+		}
+	})
+
+	t.Run("restores into the step's target namespace", func(t *testing.T) {
+		crossNamespace := &backupv1alpha1.DisasterRecoveryPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+			Spec: backupv1alpha1.DisasterRecoveryPlanSpec{
+				Steps: []backupv1alpha1.RecoveryStep{
+					{Name: "app", PolicyRef: "uploads", TargetPVC: "uploads", TargetNamespace: "app"},
+				},
+			},
+		}
+		backup := uploadsBackup("uploads-1", transitionStart.Add(-time.Hour), backupv1alpha1.BackupPhaseCompleted)
+		backup.Namespace = "app"
+		r, _, _ := newReconciler(crossNamespace, backup)
+
+		expectNoRequeue(t, reconcile(r), nil)
+		expectStep(r, "app", backupv1alpha1.RecoveryStepPhaseRestoring)
+
+		// The Restore lives beside the execution but restores into the step's namespace
+		restores := &RestoreReconciler{Client: r.Client, Recorder: record.NewFakeRecorder(100), Clock: r.Clock}
+		restoreKey := types.NamespacedName{Name: "recovery-app", Namespace: "default"}
+		result, err := restores.Reconcile(ctx, ctrl.Request{NamespacedName: restoreKey})
+		expectNoRequeue(t, result, err)
+
+		var restore backupv1alpha1.Restore
+		if err := r.Get(ctx, restoreKey, &restore); err != nil {
+			t.Fatal(err)
+		}
+		if restore.Status.Phase != backupv1alpha1.RestorePhaseRunning ||
+			restore.Status.TargetNamespace != "app" || restore.Status.TargetPVC != "uploads" {
+			t.Fatalf("Restore status = %+v", restore.Status)
+		}
+		var job batchv1.Job
+		if err := r.Get(ctx, types.NamespacedName{Name: "recovery-app-job", Namespace: "app"}, &job); err != nil {
+			t.Fatalf("restore Job not created in the step's namespace: %v", err)
+		}
+	})
+
+	t.Run("fails when the plan does not exist", func(t *testing.T) {
+		r, _, recorder := newReconciler()
+
+		expectNoRequeue(t, reconcile(r), nil)
+		if execution := fetch(r); execution.Status.Phase != backupv1alpha1.DRExecutionPhaseFailed {
+			t.Errorf("phase = %s, want Failed", execution.Status.Phase)
+		}
+		expectEvents(t, recorder, "PlanNotFound")
+	})
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestBackupExpiration(t *testing.T) {
+	tests := []struct {
+		name string
+		spec backupv1alpha1.BackupSpec
+		hold bool
+		// wantExpiry is the expiration time as an offset from completion, if any
+		wantExpiry *time.Duration
+	}{
+		{name: "no ttl"},
+		{name: "ttl from completion", spec: backupv1alpha1.BackupSpec{TTL: &metav1.Duration{Duration: time.Hour}}, wantExpiry: ptr.To(time.Hour)},
+		{
+			name: "expiresAt wins over ttl",
+			spec: backupv1alpha1.BackupSpec{
+				TTL:       &metav1.Duration{Duration: time.Hour},
+				ExpiresAt: &metav1.Time{Time: transitionStart.Add(2 * time.Hour)},
+			},
+			wantExpiry: ptr.To(2 * time.Hour),
+		},
+		{name: "held", spec: backupv1alpha1.BackupSpec{TTL: &metav1.Duration{Duration: time.Hour}, Hold: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "adhoc", Namespace: "default"}
+			tt.spec.Target = backupv1alpha1.BackupTarget{PVCName: "data"}
+			clock := clocktesting.NewFakeClock(transitionStart.Add(30 * time.Minute))
+			recorder := record.NewFakeRecorder(100)
+			r := &BackupReconciler{
+				Client: newTransitionClient(t, &backupv1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec:       tt.spec,
+					Status: backupv1alpha1.BackupStatus{
+						Phase:          backupv1alpha1.BackupPhaseCompleted,
+						CompletionTime: &metav1.Time{Time: transitionStart},
+					},
+				}),
+				Recorder: recorder,
+				Clock:    clock,
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var backup backupv1alpha1.Backup
+			if err := r.Get(ctx, key, &backup); err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantExpiry == nil {
+				expectNoRequeue(t, result, err)
+				if backup.Status.ExpirationTime != nil {
+					t.Errorf("expirationTime = %v, want none", backup.Status.ExpirationTime)
+				}
+				return
+			}
+			expiry := transitionStart.Add(*tt.wantExpiry)
+			if backup.Status.ExpirationTime == nil || !backup.Status.ExpirationTime.Time.Equal(expiry) {
+				t.Errorf("expirationTime = %v, want %s", backup.Status.ExpirationTime, expiry)
+			}
+			if want := expiry.Sub(clock.Now()); result.RequeueAfter != want {
+				t.Errorf("RequeueAfter = %v, want %v until the expiry", result.RequeueAfter, want)
+			}
+
+			clock.SetTime(expiry)
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.Get(ctx, key, &backup); !apierrors.IsNotFound(err) {
+				t.Errorf("expired Backup not deleted: %v", err)
+			}
+			expectEvents(t, recorder, "Expired")
+		})
+	}
+}
+
+func TestFailedBackupsHistoryLimit(t *testing.T) {
+	failedBackup := func(name string, age time.Duration, hold bool) *backupv1alpha1.Backup {
+		return &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(transitionStart.Add(-age)),
+			},
+			Spec: backupv1alpha1.BackupSpec{
+				Target:    backupv1alpha1.BackupTarget{PVCName: "data"},
+				PolicyRef: "nightly",
+				Hold:      hold,
+			},
+			Status: backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseFailed},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		dryRun      bool
+		wantBackups []string
+		events      []string
+	}{
+		{name: "limit enforced", wantBackups: []string{"held", "newest"}, events: []string{"CleanupTriggered"}},
+		{name: "dry run", dryRun: true, wantBackups: []string{"held", "middle", "newest", "oldest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			// Failed on-demand backups of a suspended policy, which never reaches a schedule tick
+			policy := schedulePolicy(func(policy *backupv1alpha1.BackupPolicy) {
+				policy.Spec.Suspend = true
+				policy.Spec.Retention = &backupv1alpha1.RetentionPolicy{FailedBackupsHistoryLimit: ptr.To(1), DryRun: tt.dryRun}
+			})
+			recorder := record.NewFakeRecorder(100)
+			r := &BackupReconciler{
+				Client: newTransitionClient(t, policy,
+					failedBackup("oldest", 3*time.Hour, false),
+					failedBackup("held", 4*time.Hour, true),
+					failedBackup("middle", 2*time.Hour, false),
+					failedBackup("newest", time.Hour, false),
+				),
+				Recorder: recorder,
+				Clock:    clocktesting.NewFakeClock(transitionStart),
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "newest", Namespace: "default"}})
+			expectNoRequeue(t, result, err)
+
+			var backups backupv1alpha1.BackupList
+			if err := r.List(ctx, &backups, client.InNamespace("default")); err != nil {
+				t.Fatal(err)
+			}
+			names := backupNames(backups.Items)
+			slices.Sort(names)
+			if !slices.Equal(names, tt.wantBackups) {
+				t.Errorf("backups = %v, want %v", names, tt.wantBackups)
+			}
+			expectEvents(t, recorder, tt.events...)
+		})
+	}
+}
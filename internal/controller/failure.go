@@ -0,0 +1,188 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// maxFailureLogBytes bounds the log tail copied into status so conditions stay small
+	maxFailureLogBytes = 4096
+
+	// maxEventMessageLength is the longest message the API server accepts for an event
+	maxEventMessageLength = 1024
+)
+
+// containerFailure describes the first mover container of a pod that exited unsuccessfully
+type containerFailure struct {
+	Pod       string
+	Container string
+	ExitCode  int32
+	Reason    string
+	Message   string
+	Logs      string
+}
+
+// String formats the failure for a condition message, e.g.
+// `container "backup" exited with code 2 (Error): tar: /data: Permission denied`
+func (f *containerFailure) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "container %q exited with code %d", f.Container, f.ExitCode)
+	if f.Reason != "" {
+		fmt.Fprintf(&b, " (%s)", f.Reason)
+	}
+	if message := strings.TrimSpace(f.Message); message != "" {
+		fmt.Fprintf(&b, ": %s", message)
+	}
+	if logs := strings.TrimSpace(f.Logs); logs != "" && !strings.Contains(f.Message, logs) {
+		fmt.Fprintf(&b, "\nlast log lines:\n%s", logs)
+	}
+	return b.String()
+}
+
+// describeJobFailure explains why a mover Job failed. It starts from the Job's
+// Failed condition and adds the termination message, exit code and, when
+// tailLines is positive, the last log lines of the failed container. Pod
+// lookups are best effort: without pods the Job condition alone is returned.
+func describeJobFailure(ctx context.Context, pods corev1client.PodsGetter, job *batchv1.Job, failed *batchv1.JobCondition, tailLines int64) string {
+	reason := jobFailureReason(failed)
+	if pods == nil {
+		return reason
+	}
+
+	failure, err := findContainerFailure(ctx, pods, job)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "unable to inspect pods of failed Job", "jobName", job.Name)
+		return reason
+	}
+	if failure == nil {
+		return reason
+	}
+
+	if tailLines > 0 {
+		logs, err := pods.Pods(job.Namespace).GetLogs(failure.Pod, &corev1.PodLogOptions{
+			Container: failure.Container,
+			TailLines: ptr.To(tailLines),
+		}).DoRaw(ctx)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "unable to read logs of failed mover container", "pod", failure.Pod)
+		} else {
+			failure.Logs = lastBytes(string(logs), maxFailureLogBytes)
+		}
+	}
+	return reason + "; " + failure.String()
+}
+
+// findContainerFailure returns the first container of the Job's pods that
+// terminated with a non-zero exit code, checking init containers first
+func findContainerFailure(ctx context.Context, pods corev1client.PodsGetter, job *batchv1.Job) (*containerFailure, error) {
+	podList, err := pods.Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: batchv1.JobNameLabel + "=" + job.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range podList.Items {
+		for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, status := range statuses {
+				terminated := status.State.Terminated
+				if terminated == nil {
+					terminated = status.LastTerminationState.Terminated
+				}
+				if terminated == nil || terminated.ExitCode == 0 {
+					continue
+				}
+				return &containerFailure{
+					Pod:       pod.Name,
+					Container: status.Name,
+					ExitCode:  terminated.ExitCode,
+					Reason:    terminated.Reason,
+					Message:   lastBytes(terminated.Message, maxFailureLogBytes),
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// jobLogs returns the logs of a container of a finished Job's pod. The API
+// server bounds them to the last tailLines lines and then to limitBytes, so a
+// chatty container cannot make the operator read an arbitrarily large log;
+// a bound of 0 is not applied.
+func jobLogs(ctx context.Context, pods corev1client.PodsGetter, job *batchv1.Job, container string, tailLines, limitBytes int64) (string, error) {
+	podList, err := pods.Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: batchv1.JobNameLabel + "=" + job.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing pods of Job %s: %w", job.Name, err)
+	}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		options := &corev1.PodLogOptions{Container: container}
+		if tailLines > 0 {
+			options.TailLines = ptr.To(tailLines)
+		}
+		if limitBytes > 0 {
+			options.LimitBytes = ptr.To(limitBytes)
+		}
+		logs, err := pods.Pods(job.Namespace).GetLogs(pod.Name, options).DoRaw(ctx)
+		if err != nil {
+			return "", fmt.Errorf("reading logs of pod %s: %w", pod.Name, err)
+		}
+		return string(logs), nil
+	}
+	return "", fmt.Errorf("job %s has no succeeded pod", job.Name)
+}
+
+// useFallbackTerminationMessages makes failed mover containers report the
+// tail of their logs as termination message when they write none themselves
+func useFallbackTerminationMessages(podSpec *corev1.PodSpec) {
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			containers[i].TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+		}
+	}
+}
+
+// eventMessage shortens a message so it fits in an event
+func eventMessage(message string) string {
+	if len(message) <= maxEventMessageLength {
+		return message
+	}
+	return message[:maxEventMessageLength-3] + "..."
+}
+
+// lastBytes keeps the end of s, where the most recent log lines are
+func lastBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return "..." + s[len(s)-n:]
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// failedMoverPod builds a pod of the given Job whose init container succeeded
+// and whose main container exited with code 2
+func failedMoverPod(jobName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{batchv1.JobNameLabel: jobName},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "prepare",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+				},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "backup",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 2,
+						Reason:   "Error",
+						Message:  "tar: /data/secret: Permission denied\n",
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestDescribeJobFailure(t *testing.T) {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "nightly-job", Namespace: "default"}}
+	failed := &batchv1.JobCondition{
+		Type:    batchv1.JobFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  "BackoffLimitExceeded",
+		Message: "Job has reached the specified backoff limit",
+	}
+
+	t.Run("without pod access only the Job condition is reported", func(t *testing.T) {
+		got := describeJobFailure(context.Background(), nil, job, failed, 20)
+		if got != "BackoffLimitExceeded: Job has reached the specified backoff limit" {
+			t.Errorf("describeJobFailure() = %q", got)
+		}
+	})
+
+	t.Run("failed container details are added", func(t *testing.T) {
+		pods := fake.NewClientset(failedMoverPod("nightly-job"), failedMoverPod("other-job")).CoreV1()
+		got := describeJobFailure(context.Background(), pods, job, failed, 0)
+
+		for _, want := range []string{
+			"BackoffLimitExceeded",
+			`container "backup" exited with code 2 (Error)`,
+			"Permission denied",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("describeJobFailure() = %q, missing %q", got, want)
+			}
+		}
+		if strings.Contains(got, "last log lines") {
+			t.Errorf("logs must not be read when tailLines is 0: %q", got)
+		}
+	})
+
+	t.Run("log tail is added when requested", func(t *testing.T) {
+		pods := fake.NewClientset(failedMoverPod("nightly-job")).CoreV1()
+		got := describeJobFailure(context.Background(), pods, job, failed, 20)
+
+		// The fake clientset serves "fake logs" for every container
+		if !strings.Contains(got, "last log lines:\nfake logs") {
+			t.Errorf("describeJobFailure() = %q, missing the log tail", got)
+		}
+	})
+
+	t.Run("no failed container falls back to the Job condition", func(t *testing.T) {
+		pods := fake.NewClientset().CoreV1()
+		got := describeJobFailure(context.Background(), pods, job, failed, 20)
+		if got != "BackoffLimitExceeded: Job has reached the specified backoff limit" {
+			t.Errorf("describeJobFailure() = %q", got)
+		}
+	})
+}
+
+func TestEventMessage(t *testing.T) {
+	short := "Backup failed"
+	if got := eventMessage(short); got != short {
+		t.Errorf("eventMessage(%q) = %q", short, got)
+	}
+
+	long := strings.Repeat("x", 2*maxEventMessageLength)
+	got := eventMessage(long)
+	if len(got) != maxEventMessageLength || !strings.HasSuffix(got, "...") {
+		t.Errorf("eventMessage() returned %d bytes, want %d ending in ...", len(got), maxEventMessageLength)
+	}
+}
+
+func TestBackupSummary(t *testing.T) {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "nightly-job", Namespace: "default"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nightly-job-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{batchv1.JobNameLabel: "nightly-job"},
+		},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "backup",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "size=3145728 bytes=8388608 files=42\n"}},
+		}}},
+	}
+
+	got := backupSummary(context.Background(), fake.NewClientset(pod).CoreV1(), job)
+	if got["size"] != 3145728 || got["bytes"] != 8388608 || got["files"] != 42 {
+		t.Errorf("backupSummary() = %v", got)
+	}
+	if got := backupSummary(context.Background(), fake.NewClientset().CoreV1(), job); got != nil {
+		t.Errorf("backupSummary() without pods = %v, want nil", got)
+	}
+	if got := backupSummary(context.Background(), nil, job); got != nil {
+		t.Errorf("backupSummary() without pod access = %v, want nil", got)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+const (
+	// defaultRetryBackoff is the delay before the second attempt when the retry policy sets none
+	defaultRetryBackoff = 10 * time.Second
+
+	// maxRetryBackoff caps the exponential backoff between attempts
+	maxRetryBackoff = time.Hour
+)
+
+// maxAttempts returns how many times a mover Job may run; without a retry policy it runs once
+func maxAttempts(retry *backupv1alpha1.RetryPolicy) int32 {
+	if retry == nil || retry.MaxAttempts == nil || *retry.MaxAttempts < 1 {
+		return 1
+	}
+	return *retry.MaxAttempts
+}
+
+// retryBackoff returns the delay between the failure of the given attempt and
+// the start of the next one. It doubles with every attempt.
+func retryBackoff(retry *backupv1alpha1.RetryPolicy, attempt int32) time.Duration {
+	backoff := defaultRetryBackoff
+	if retry != nil && retry.Backoff != nil {
+		backoff = retry.Backoff.Duration
+	}
+
+	for i := int32(1); i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
+
+// attemptOutcome is what a reconciler should do next about a mover attempt
+type attemptOutcome int
+
+const (
+	// attemptRunning means the Job has not finished; its next status change triggers a reconcile
+	attemptRunning attemptOutcome = iota
+	// attemptSucceeded means the Job completed
+	attemptSucceeded
+	// attemptRetryPending means the Job failed and the next attempt starts after a backoff
+	attemptRetryPending
+	// attemptRetry means the Job failed and the backoff is over, so the next attempt can start
+	attemptRetry
+	// attemptFailed means the Job failed and no attempts are left
+	attemptFailed
+)
+
+// attemptState is the outcome of a mover attempt, computed from its Job's conditions
+type attemptState struct {
+	Outcome attemptOutcome
+
+	// Failed is the Job's Failed condition, set for every failure outcome
+	Failed *batchv1.JobCondition
+
+	// RetryAfter is how long until the next attempt may start, set for attemptRetryPending
+	RetryAfter time.Duration
+}
+
+// evaluateAttempt derives the next step for a mover attempt from its Job
+func evaluateAttempt(job *batchv1.Job, attempt int32, retry *backupv1alpha1.RetryPolicy, now time.Time) attemptState {
+	if jobCondition(job, batchv1.JobComplete) != nil {
+		return attemptState{Outcome: attemptSucceeded}
+	}
+
+	failed := jobCondition(job, batchv1.JobFailed)
+	if failed == nil {
+		return attemptState{Outcome: attemptRunning}
+	}
+	if attempt >= maxAttempts(retry) {
+		return attemptState{Outcome: attemptFailed, Failed: failed}
+	}
+
+	retryAt := failed.LastTransitionTime.Add(retryBackoff(retry, attempt))
+	if wait := retryAt.Sub(now); wait > 0 {
+		return attemptState{Outcome: attemptRetryPending, Failed: failed, RetryAfter: wait}
+	}
+	return attemptState{Outcome: attemptRetry, Failed: failed}
+}
+
+// attemptJobName names the Job of a given attempt. The first attempt keeps the
+// plain name so Jobs created before retries existed are still found.
+func attemptJobName(baseName string, attempt int32) string {
+	if attempt <= 1 {
+		return baseName
+	}
+	return boundedName(fmt.Sprintf("%s-%d", baseName, attempt), maxJobNameLength)
+}
+
+// attemptJobLost reports whether the Job of an attempt that was already
+// started is gone before its outcome was recorded, e.g. because it was garbage
+// collected while the operator was down. Creating it again would silently rerun
+// a failed attempt or overwrite the archive of a succeeded one, so the caller
+// counts the attempt as failed instead. A Job is only created again when the
+// attempt never started one or was preempted. The Job is looked up with the
+// uncached reader so one the cache has not seen yet is not taken for lost.
+func attemptJobLost(ctx context.Context, reader client.Reader, conditions []metav1.Condition, attempts, attempt int32,
+	key client.ObjectKey) (bool, error) {
+	if attempts < attempt {
+		return false, nil
+	}
+	progressing := meta.FindStatusCondition(conditions, backupv1alpha1.ConditionProgressing)
+	if progressing == nil || (progressing.Reason != "JobRunning" && progressing.Reason != "RetryPending") {
+		return false, nil
+	}
+
+	err := reader.Get(ctx, key, &batchv1.Job{})
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// lostJobFailure describes an attempt whose Job is gone
+func lostJobFailure(jobName string, attempt int32) string {
+	return fmt.Sprintf("Job %s of attempt %d no longer exists, so its outcome is unknown", jobName, attempt)
+}
+
+// jobCondition returns the Job condition of the given type if it is true
+func jobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		condition := &job.Status.Conditions[i]
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
+// jobFailureReason describes a Failed Job condition, e.g.
+// "DeadlineExceeded: Job was active longer than specified deadline"
+func jobFailureReason(condition *batchv1.JobCondition) string {
+	if condition.Message == "" {
+		return condition.Reason
+	}
+	return condition.Reason + ": " + condition.Message
+}
+
+// applyJobLimits makes Kubernetes run a mover Job exactly once, within the
+// timeout, and clean it up after ttl. Retries are driven by the reconcilers so
+// the backoff and attempt count are under the user's control.
+func applyJobLimits(job *batchv1.Job, timeout *metav1.Duration, ttl time.Duration) {
+	job.Spec.BackoffLimit = ptr.To[int32](0)
+	if timeout != nil && timeout.Duration > 0 {
+		job.Spec.ActiveDeadlineSeconds = ptr.To(max(1, int64(timeout.Duration.Seconds())))
+	}
+	if ttl > 0 {
+		job.Spec.TTLSecondsAfterFinished = ptr.To(int32(ttl.Seconds()))
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   *backupv1alpha1.RetryPolicy
+		attempt int32
+		want    time.Duration
+	}{
+		{name: "default backoff after the first attempt", attempt: 1, want: 10 * time.Second},
+		{name: "default backoff doubles", attempt: 3, want: 40 * time.Second},
+		{
+			name:    "configured backoff",
+			retry:   &backupv1alpha1.RetryPolicy{Backoff: &metav1.Duration{Duration: time.Minute}},
+			attempt: 2,
+			want:    2 * time.Minute,
+		},
+		{
+			name:    "backoff is capped",
+			retry:   &backupv1alpha1.RetryPolicy{Backoff: &metav1.Duration{Duration: 30 * time.Minute}},
+			attempt: 40,
+			want:    time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryBackoff(tt.retry, tt.attempt); got != tt.want {
+				t.Errorf("retryBackoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxAttempts(t *testing.T) {
+	if got := maxAttempts(nil); got != 1 {
+		t.Errorf("maxAttempts(nil) = %d, want 1", got)
+	}
+	if got := maxAttempts(&backupv1alpha1.RetryPolicy{MaxAttempts: ptr.To[int32](4)}); got != 4 {
+		t.Errorf("maxAttempts(4) = %d, want 4", got)
+	}
+}
+
+func TestAttemptJobName(t *testing.T) {
+	if got := attemptJobName("nightly-job", 1); got != "nightly-job" {
+		t.Errorf("first attempt = %q, want nightly-job", got)
+	}
+	if got := attemptJobName("nightly-job", 3); got != "nightly-job-3" {
+		t.Errorf("third attempt = %q, want nightly-job-3", got)
+	}
+}
+
+func TestJobCondition(t *testing.T) {
+	job := &batchv1.Job{
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded"},
+				{Type: batchv1.JobComplete, Status: corev1.ConditionFalse},
+				{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "DeadlineExceeded",
+					Message: "Job was active longer than specified deadline",
+				},
+			},
+		},
+	}
+
+	if jobCondition(job, batchv1.JobComplete) != nil {
+		t.Errorf("a false Complete condition must not count as completed")
+	}
+	failed := jobCondition(job, batchv1.JobFailed)
+	if failed == nil {
+		t.Fatalf("expected the Failed condition")
+	}
+	want := "DeadlineExceeded: Job was active longer than specified deadline"
+	if got := jobFailureReason(failed); got != want {
+		t.Errorf("jobFailureReason() = %q, want %q", got, want)
+	}
+}
+
+func TestApplyJobLimits(t *testing.T) {
+	job := &batchv1.Job{}
+	applyJobLimits(job, &metav1.Duration{Duration: 90 * time.Minute}, 24*time.Hour)
+
+	if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != 0 {
+		t.Errorf("BackoffLimit = %v, want 0", job.Spec.BackoffLimit)
+	}
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != 5400 {
+		t.Errorf("ActiveDeadlineSeconds = %v, want 5400", job.Spec.ActiveDeadlineSeconds)
+	}
+	if job.Spec.TTLSecondsAfterFinished == nil || *job.Spec.TTLSecondsAfterFinished != 86400 {
+		t.Errorf("TTLSecondsAfterFinished = %v, want 86400", job.Spec.TTLSecondsAfterFinished)
+	}
+
+	job = &batchv1.Job{}
+	applyJobLimits(job, nil, 0)
+	if job.Spec.ActiveDeadlineSeconds != nil || job.Spec.TTLSecondsAfterFinished != nil {
+		t.Errorf("no timeout or TTL should leave the Job unbounded")
+	}
+}
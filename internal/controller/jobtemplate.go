@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// applyJobTemplates merges mover templates into a pod spec. Templates are
+// applied in order, so later ones (the per-object override) win over earlier
+// ones (the operator defaults). Nil templates are skipped.
+func applyJobTemplates(podSpec *corev1.PodSpec, templates ...*backupv1alpha1.MoverJobTemplate) {
+	for _, template := range templates {
+		if template != nil {
+			applyJobTemplate(podSpec, template)
+		}
+	}
+}
+
+// applyJobTemplate copies every field set on the template into the pod spec
+func applyJobTemplate(podSpec *corev1.PodSpec, template *backupv1alpha1.MoverJobTemplate) {
+	if template.NodeSelector != nil {
+		podSpec.NodeSelector = template.NodeSelector
+	}
+	if template.Affinity != nil {
+		podSpec.Affinity = template.Affinity.DeepCopy()
+	}
+	if template.Tolerations != nil {
+		podSpec.Tolerations = template.Tolerations
+	}
+	if template.PriorityClassName != "" {
+		podSpec.PriorityClassName = template.PriorityClassName
+	}
+	if template.ServiceAccountName != "" {
+		podSpec.ServiceAccountName = template.ServiceAccountName
+	}
+	if template.ImagePullSecrets != nil {
+		podSpec.ImagePullSecrets = template.ImagePullSecrets
+	}
+	if template.PodSecurityContext != nil {
+		podSpec.SecurityContext = template.PodSecurityContext.DeepCopy()
+	}
+
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			if template.Resources != nil {
+				containers[i].Resources = *template.Resources.DeepCopy()
+			}
+			if template.SecurityContext != nil {
+				containers[i].SecurityContext = template.SecurityContext.DeepCopy()
+			}
+		}
+	}
+}
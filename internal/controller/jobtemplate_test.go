@@ -0,0 +1,64 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+func TestApplyJobTemplates(t *testing.T) {
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "prepare"}},
+		Containers:     []corev1.Container{{Name: "mover"}},
+	}
+
+	override := &backupv1alpha1.MoverJobTemplate{
+		NodeSelector:      map[string]string{"node-role": "backup"},
+		PriorityClassName: "low",
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+		},
+		SecurityContext: &corev1.SecurityContext{RunAsUser: ptr.To[int64](1000)},
+	}
+
+	applyJobTemplates(&podSpec, config.RestrictedMoverJobTemplate(), nil, override)
+
+	if podSpec.SecurityContext == nil || !ptr.Deref(podSpec.SecurityContext.RunAsNonRoot, false) {
+		t.Errorf("pod security context = %+v, want the restricted template", podSpec.SecurityContext)
+	}
+	if podSpec.NodeSelector["node-role"] != "backup" || podSpec.PriorityClassName != "low" {
+		t.Errorf("scheduling fields were not overridden: %+v", podSpec)
+	}
+
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		if got := container.Resources.Limits[corev1.ResourceMemory]; got.String() != "256Mi" {
+			t.Errorf("container %s memory limit = %s, want 256Mi", container.Name, got.String())
+		}
+		// A container security context override replaces the default as a whole
+		sc := container.SecurityContext
+		if sc == nil || ptr.Deref(sc.RunAsUser, 0) != 1000 || sc.Capabilities != nil {
+			t.Errorf("container %s security context = %+v, want only the override", container.Name, sc)
+		}
+	}
+}
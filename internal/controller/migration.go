@@ -0,0 +1,253 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// recordedSourcePVC keeps the part of a PVC's spec a migration restore needs
+// to provision a copy of it
+func recordedSourcePVC(spec *corev1.PersistentVolumeClaimSpec) *backupv1alpha1.SourcePVCSpec {
+	if spec == nil {
+		return nil
+	}
+	recorded := &backupv1alpha1.SourcePVCSpec{
+		AccessModes: spec.AccessModes,
+	}
+	if spec.StorageClassName != nil {
+		recorded.StorageClassName = *spec.StorageClassName
+	}
+	if size, ok := spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		recorded.Size = &size
+	}
+	return recorded
+}
+
+// migrationPVC builds the PVC a migration restore creates: the source PVC's
+// spec recorded by the backup with the restore's overrides applied. It fails
+// when the result is incomplete or too small for the backup's data.
+func migrationPVC(restore *backupv1alpha1.Restore, backup *backupv1alpha1.Backup, target restoreTarget) (*corev1.PersistentVolumeClaim, error) {
+	migration := restore.Spec.Migration
+	source := backup.Status.SourcePVC
+	if source == nil {
+		source = &backupv1alpha1.SourcePVCSpec{}
+	}
+
+	spec := corev1.PersistentVolumeClaimSpec{
+		AccessModes: source.AccessModes,
+	}
+	if len(migration.AccessModes) > 0 {
+		spec.AccessModes = migration.AccessModes
+	}
+	if source.StorageClassName != "" {
+		spec.StorageClassName = &source.StorageClassName
+	}
+	if migration.StorageClassName != nil {
+		spec.StorageClassName = migration.StorageClassName
+	}
+	size := source.Size
+	if migration.Size != nil {
+		size = migration.Size
+	}
+
+	if len(spec.AccessModes) == 0 || size == nil {
+		return nil, fmt.Errorf("backup %s did not record the source PVC's spec, so migration must set size and accessModes", backup.Name)
+	}
+	if backup.Status.DataSize != nil && size.Cmp(*backup.Status.DataSize) < 0 {
+		return nil, fmt.Errorf("size %s cannot hold the %s of data in backup %s", size, backup.Status.DataSize, backup.Name)
+	}
+	spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: *size}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.PVC,
+			Namespace: target.Namespace,
+			Labels: map[string]string{
+				backupv1alpha1.TargetLabel: labelValue(backup.Spec.Target.PVCName),
+			},
+			Annotations: map[string]string{
+				backupv1alpha1.RestoreAnnotation: restore.Name,
+			},
+		},
+		Spec: spec,
+	}, nil
+}
+
+// provisionTargetPVC creates the PVC of a migration restore and records it in
+// status. The PVC is not owned by the Restore, so it outlives it. It reports
+// whether the restore was rejected because the PVC cannot be created.
+func (r *RestoreReconciler) provisionTargetPVC(ctx context.Context, restore *backupv1alpha1.Restore, backup *backupv1alpha1.Backup,
+	target restoreTarget) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	pvc, err := migrationPVC(restore, backup, target)
+	if err != nil {
+		return true, r.rejectRestore(ctx, restore, "InvalidMigration", err.Error())
+	}
+
+	// A workload that cannot be swapped is found before any data is copied
+	if ref := restore.Spec.Migration.SwapWorkload; ref != nil && ref.Kind == "StatefulSet" {
+		var statefulSet appsv1.StatefulSet
+		err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: target.Namespace}, &statefulSet)
+		if client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		if message := claimTemplateMessage(&statefulSet, backup.Spec.Target.PVCName); message != "" {
+			return true, r.rejectRestore(ctx, restore, "InvalidMigration", message)
+		}
+	}
+
+	var existing corev1.PersistentVolumeClaim
+	err = r.Get(ctx, client.ObjectKeyFromObject(pvc), &existing)
+	switch {
+	case err == nil:
+		// A PVC this restore created before its status was recorded is reused;
+		// any other PVC would be overwritten, so the restore is rejected
+		if existing.Annotations[backupv1alpha1.RestoreAnnotation] != restore.Name {
+			return true, r.rejectRestore(ctx, restore, "TargetPVCExists",
+				fmt.Sprintf("PVC %s already exists; a migration restore creates its target PVC", pvc.Name))
+		}
+	case apierrors.IsNotFound(err):
+		if err := r.Create(ctx, pvc); err != nil {
+			log.Error(err, "unable to create target PVC", "pvc", pvc.Name)
+			return false, err
+		}
+		log.Info("Created target PVC", "pvc", pvc.Name)
+	default:
+		return false, err
+	}
+
+	base := restore.DeepCopy()
+	restore.Status.ProvisionedPVC = pvc.Name
+	if err := patchRestoreStatus(ctx, r.Client, restore, base); err != nil {
+		log.Error(err, "unable to record provisioned PVC")
+		return false, err
+	}
+	r.Recorder.Eventf(
+		restore,
+		corev1.EventTypeNormal,
+		"PVCProvisioned",
+		"Created PVC %s with storage class %s and size %s",
+		pvc.Name,
+		storageClassOf(pvc),
+		pvc.Spec.Resources.Requests.Storage(),
+	)
+	return false, nil
+}
+
+// storageClassOf names the storage class of a PVC for messages
+func storageClassOf(pvc *corev1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName == nil {
+		return "(default)"
+	}
+	return *pvc.Spec.StorageClassName
+}
+
+// swapWorkloadVolume points the volumes of a workload's pod template that use
+// the source PVC at the restored one. A message is returned when the swap is
+// not possible; a workload already using the restored PVC is left as it is.
+func (r *RestoreReconciler) swapWorkloadVolume(ctx context.Context, restore *backupv1alpha1.Restore, backup *backupv1alpha1.Backup,
+	target restoreTarget) (string, error) {
+	ref := restore.Spec.Migration.SwapWorkload
+	key := client.ObjectKey{Name: ref.Name, Namespace: target.Namespace}
+
+	var workload client.Object
+	var template *corev1.PodTemplateSpec
+	switch ref.Kind {
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		workload, template = statefulSet, &statefulSet.Spec.Template
+	default:
+		deployment := &appsv1.Deployment{}
+		workload, template = deployment, &deployment.Spec.Template
+	}
+	if err := r.Get(ctx, key, workload); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("%s %s not found", ref.Kind, ref.Name), nil
+		}
+		return "", err
+	}
+
+	patch := client.MergeFrom(workload.DeepCopyObject().(client.Object))
+	swapped, current := swapClaim(template.Spec.Volumes, backup.Spec.Target.PVCName, target.PVC)
+	if swapped == 0 {
+		if current {
+			return "", nil
+		}
+		if statefulSet, ok := workload.(*appsv1.StatefulSet); ok {
+			if message := claimTemplateMessage(statefulSet, backup.Spec.Target.PVCName); message != "" {
+				return message, nil
+			}
+		}
+		return fmt.Sprintf("%s %s has no volume using PVC %s", ref.Kind, ref.Name, backup.Spec.Target.PVCName), nil
+	}
+	if err := r.Patch(ctx, workload, patch); err != nil {
+		return "", err
+	}
+	logf.FromContext(ctx).Info("Swapped workload volume", "kind", ref.Kind, "name", ref.Name, "pvc", target.PVC)
+	return "", nil
+}
+
+// claimTemplateMessage explains why a StatefulSet that gets pvc from one of
+// its volumeClaimTemplates cannot be swapped: the claims of those PVCs are
+// named <template>-<statefulset>-<ordinal> and cannot be changed. It is empty
+// for PVCs that do not come from a template.
+func claimTemplateMessage(statefulSet *appsv1.StatefulSet, pvc string) string {
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		ordinal, found := strings.CutPrefix(pvc, template.Name+"-"+statefulSet.Name+"-")
+		if _, err := strconv.Atoi(ordinal); found && err == nil {
+			return fmt.Sprintf("StatefulSet %s gets PVC %s from its volumeClaimTemplate %s, which cannot be pointed at "+
+				"another PVC; scale the StatefulSet down and restore into PVC %s without migration instead",
+				statefulSet.Name, pvc, template.Name, pvc)
+		}
+	}
+	return ""
+}
+
+// swapClaim changes the volumes using claim from to use claim to. It returns
+// how many were changed and whether a volume already uses to.
+func swapClaim(volumes []corev1.Volume, from, to string) (int, bool) {
+	swapped := 0
+	current := false
+	for i := range volumes {
+		source := volumes[i].PersistentVolumeClaim
+		if source == nil {
+			continue
+		}
+		switch source.ClaimName {
+		case from:
+			source.ClaimName = to
+			swapped++
+		case to:
+			current = true
+		}
+	}
+	return swapped, current
+}
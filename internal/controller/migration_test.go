@@ -0,0 +1,272 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestMigrationPVC(t *testing.T) {
+	backup := &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+		Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+		Status: backupv1alpha1.BackupStatus{
+			DataSize: ptr.To(resource.MustParse("3Gi")),
+			SourcePVC: &backupv1alpha1.SourcePVCSpec{
+				StorageClassName: "standard",
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Size:             ptr.To(resource.MustParse("5Gi")),
+			},
+		},
+	}
+	target := restoreTarget{Namespace: "default", PVC: "data-ssd"}
+	restore := func(migration backupv1alpha1.MigrationSpec) *backupv1alpha1.Restore {
+		return &backupv1alpha1.Restore{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+			Spec:       backupv1alpha1.RestoreSpec{BackupName: "nightly", TargetPVC: "data-ssd", Migration: &migration},
+		}
+	}
+
+	pvc, err := migrationPVC(restore(backupv1alpha1.MigrationSpec{}), backup, target)
+	if err != nil {
+		t.Fatalf("migrationPVC() without overrides error = %v", err)
+	}
+	if *pvc.Spec.StorageClassName != "standard" || pvc.Spec.Resources.Requests.Storage().String() != "5Gi" ||
+		pvc.Spec.AccessModes[0] != corev1.ReadWriteOnce || pvc.Annotations[backupv1alpha1.RestoreAnnotation] != "migrate" {
+		t.Errorf("migrationPVC() without overrides = %+v", pvc)
+	}
+
+	pvc, err = migrationPVC(restore(backupv1alpha1.MigrationSpec{
+		StorageClassName: ptr.To("fast-ssd"),
+		Size:             ptr.To(resource.MustParse("20Gi")),
+		AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+	}), backup, target)
+	if err != nil {
+		t.Fatalf("migrationPVC() with overrides error = %v", err)
+	}
+	if *pvc.Spec.StorageClassName != "fast-ssd" || pvc.Spec.Resources.Requests.Storage().String() != "20Gi" ||
+		pvc.Spec.AccessModes[0] != corev1.ReadWriteMany {
+		t.Errorf("migrationPVC() with overrides = %+v", pvc.Spec)
+	}
+
+	if _, err := migrationPVC(restore(backupv1alpha1.MigrationSpec{Size: ptr.To(resource.MustParse("1Gi"))}), backup, target); err == nil {
+		t.Error("migrationPVC() accepted a size smaller than the backup's data")
+	}
+
+	backup.Status.SourcePVC = nil
+	if _, err := migrationPVC(restore(backupv1alpha1.MigrationSpec{Size: ptr.To(resource.MustParse("5Gi"))}), backup, target); err == nil {
+		t.Error("migrationPVC() accepted a migration without access modes or a recorded source PVC")
+	}
+}
+
+func TestMigrationRestore(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "migrate", Namespace: "default"}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+						{Name: "data", VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+						}},
+					},
+				},
+			},
+		},
+	}
+	newReconciler := func() (*RestoreReconciler, *clocktesting.FakeClock, *record.FakeRecorder) {
+		clock := clocktesting.NewFakeClock(transitionStart)
+		recorder := record.NewFakeRecorder(100)
+		return &RestoreReconciler{
+			Client: newTransitionClient(t,
+				&backupv1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+					Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+					Status: backupv1alpha1.BackupStatus{
+						Phase: backupv1alpha1.BackupPhaseCompleted,
+						SourcePVC: &backupv1alpha1.SourcePVCSpec{
+							StorageClassName: "standard",
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							Size:             ptr.To(resource.MustParse("5Gi")),
+						},
+					},
+				},
+				&backupv1alpha1.Restore{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec: backupv1alpha1.RestoreSpec{
+						BackupName: "nightly",
+						TargetPVC:  "data-ssd",
+						Migration: &backupv1alpha1.MigrationSpec{
+							StorageClassName: ptr.To("fast-ssd"),
+							SwapWorkload:     &backupv1alpha1.WorkloadReference{Kind: "Deployment", Name: "app"},
+						},
+					},
+				},
+				deployment.DeepCopy(),
+			),
+			Recorder: recorder,
+			Clock:    clock,
+		}, clock, recorder
+	}
+	reconcile := func(r *RestoreReconciler) {
+		t.Helper()
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+	}
+	fetch := func(r *RestoreReconciler) *backupv1alpha1.Restore {
+		t.Helper()
+		var restore backupv1alpha1.Restore
+		if err := r.Get(ctx, key, &restore); err != nil {
+			t.Fatal(err)
+		}
+		return &restore
+	}
+
+	t.Run("provisions the PVC and swaps the workload", func(t *testing.T) {
+		r, clock, recorder := newReconciler()
+
+		reconcile(r)
+		var pvc corev1.PersistentVolumeClaim
+		if err := r.Get(ctx, types.NamespacedName{Name: "data-ssd", Namespace: "default"}, &pvc); err != nil {
+			t.Fatalf("target PVC not created: %v", err)
+		}
+		if *pvc.Spec.StorageClassName != "fast-ssd" || pvc.Spec.Resources.Requests.Storage().String() != "5Gi" || len(pvc.OwnerReferences) != 0 {
+			t.Errorf("target PVC = %+v", pvc)
+		}
+		if restore := fetch(r); restore.Status.ProvisionedPVC != "data-ssd" || restore.Status.Phase != backupv1alpha1.RestorePhaseRunning {
+			t.Fatalf("status = %+v", restore.Status)
+		}
+		expectEvents(t, recorder, "PVCProvisioned", "RestoreStarted", "RestoreJobCreated")
+
+		clock.Step(time.Minute)
+		setJobCondition(t, r.Client, "migrate-job", batchv1.JobComplete, clock.Now())
+		reconcile(r)
+		restore := fetch(r)
+		if restore.Status.Phase != backupv1alpha1.RestorePhaseCompleted || restore.Status.SwappedWorkload != "Deployment/app" {
+			t.Fatalf("status = %+v", restore.Status)
+		}
+		var swapped appsv1.Deployment
+		if err := r.Get(ctx, types.NamespacedName{Name: "app", Namespace: "default"}, &swapped); err != nil {
+			t.Fatal(err)
+		}
+		if claim := swapped.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName; claim != "data-ssd" {
+			t.Errorf("workload claim = %s, want data-ssd", claim)
+		}
+		expectEvents(t, recorder, "WorkloadSwapped", "RestoreCompleted")
+	})
+
+	t.Run("rejects an existing PVC", func(t *testing.T) {
+		r, _, recorder := newReconciler()
+		if err := r.Create(ctx, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-ssd", Namespace: "default"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		reconcile(r)
+		if restore := fetch(r); restore.Status.Phase != backupv1alpha1.RestorePhaseFailed || restore.Status.ProvisionedPVC != "" {
+			t.Fatalf("status = %+v", restore.Status)
+		}
+		expectEvents(t, recorder, "TargetPVCExists")
+	})
+
+	t.Run("rejects a StatefulSet whose PVC comes from a volumeClaimTemplate", func(t *testing.T) {
+		r, _, recorder := newReconciler()
+		var backup backupv1alpha1.Backup
+		if err := r.Get(ctx, types.NamespacedName{Name: "nightly", Namespace: "default"}, &backup); err != nil {
+			t.Fatal(err)
+		}
+		backup.Spec.Target.PVCName = "data-db-0"
+		var restore backupv1alpha1.Restore
+		if err := r.Get(ctx, key, &restore); err != nil {
+			t.Fatal(err)
+		}
+		restore.Spec.Migration.SwapWorkload = &backupv1alpha1.WorkloadReference{Kind: "StatefulSet", Name: "db"}
+		for _, obj := range []client.Object{&backup, &restore} {
+			if err := r.Update(ctx, obj); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := r.Create(ctx, &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Rejected before the PVC is provisioned or any data is copied
+		reconcile(r)
+		restored := fetch(r)
+		if ready := meta.FindStatusCondition(restored.Status.Conditions, backupv1alpha1.ConditionReady); restored.Status.Phase != backupv1alpha1.RestorePhaseFailed ||
+			ready == nil || !strings.Contains(ready.Message, "volumeClaimTemplate data") {
+			t.Fatalf("phase = %s, Ready = %+v; want Failed on the volumeClaimTemplate", restored.Status.Phase, ready)
+		}
+		var pvc corev1.PersistentVolumeClaim
+		if err := r.Get(ctx, types.NamespacedName{Name: "data-ssd", Namespace: "default"}, &pvc); !apierrors.IsNotFound(err) {
+			t.Errorf("target PVC was provisioned: %v", err)
+		}
+		expectJobGone(t, r.Client, "migrate-job")
+		expectEvents(t, recorder, "InvalidMigration")
+	})
+
+	t.Run("fails when the workload does not use the source PVC", func(t *testing.T) {
+		r, clock, recorder := newReconciler()
+		var app appsv1.Deployment
+		if err := r.Get(ctx, types.NamespacedName{Name: "app", Namespace: "default"}, &app); err != nil {
+			t.Fatal(err)
+		}
+		app.Spec.Template.Spec.Volumes = app.Spec.Template.Spec.Volumes[:1]
+		if err := r.Update(ctx, &app); err != nil {
+			t.Fatal(err)
+		}
+
+		reconcile(r)
+		drainEvents(recorder)
+		clock.Step(time.Minute)
+		setJobCondition(t, r.Client, "migrate-job", batchv1.JobComplete, clock.Now())
+		reconcile(r)
+		if restore := fetch(r); restore.Status.Phase != backupv1alpha1.RestorePhaseFailed || restore.Status.SwappedWorkload != "" {
+			t.Fatalf("status = %+v", restore.Status)
+		}
+		expectEvents(t, recorder, "WorkloadSwapFailed")
+	})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+)
+
+// operatorContainer is the name of the operator's container in its pod
+const operatorContainer = "manager"
+
+// addMoverTools makes the mover binary of the operator image available to
+// every container of a mover pod: an init container running before the others
+// copies it into a shared volume.
+func addMoverTools(podSpec *corev1.PodSpec, image string) {
+	mount := corev1.VolumeMount{Name: archive.ToolsVolume, MountPath: archive.ToolsDir}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			containers[i].VolumeMounts = append(containers[i].VolumeMounts, mount)
+		}
+	}
+
+	install := corev1.Container{
+		Name:         archive.ToolsVolume,
+		Image:        image,
+		Command:      []string{"/mover", "install", archive.Tool},
+		VolumeMounts: []corev1.VolumeMount{mount},
+	}
+	podSpec.InitContainers = append([]corev1.Container{install}, podSpec.InitContainers...)
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         archive.ToolsVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+}
+
+// moverToolsUnavailable explains why an archive format cannot be written or
+// read when the operator does not know its own image
+func moverToolsUnavailable(format archiveFormat) string {
+	return fmt.Sprintf("%s compression runs the operator's mover binary, but the operator image is unknown; "+
+		"set the POD_NAME and POD_NAMESPACE environment variables of the operator", format.Algorithm)
+}
+
+// OperatorImage returns the image of the operator's own container, which
+// holds the mover binary mover pods copy for zstd and lz4 archives and to
+// report their progress
+func OperatorImage(ctx context.Context, pods corev1client.PodsGetter, namespace, name string) (string, error) {
+	pod, err := pods.Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == operatorContainer {
+			return container.Image, nil
+		}
+	}
+	if len(pod.Spec.Containers) == 1 {
+		return pod.Spec.Containers[0].Image, nil
+	}
+	return "", fmt.Errorf("pod %s/%s has no container named %s", namespace, name, operatorContainer)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+const (
+	// maxJobNameLength keeps Job names usable as the value of the job-name
+	// label Kubernetes puts on their pods
+	maxJobNameLength = validation.LabelValueMaxLength
+
+	// maxBackupNameLength leaves room for the Job suffixes added to Backup names
+	maxBackupNameLength = maxJobNameLength - len("-job-999")
+
+	// nameHashLength is the number of hex digits of the hash added to generated names
+	nameHashLength = 8
+
+	// tickNameFormat is how a schedule tick appears in Backup names
+	tickNameFormat = "20060102-150405"
+
+	// tickLabelFormat is how a schedule tick appears in the tick label
+	tickLabelFormat = "20060102T150405Z"
+)
+
+// nameHash returns a short, stable hash of the given parts
+func nameHash(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "/")))
+	return hex.EncodeToString(sum[:])[:nameHashLength]
+}
+
+// boundedName returns name unchanged if it fits in maxLength. Longer names
+// are cut and end in a hash of the full name, so two long names sharing a
+// prefix still differ.
+func boundedName(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	return withSuffix(name, "-"+nameHash(name), maxLength)
+}
+
+// withSuffix cuts prefix so that prefix+suffix fits in maxLength. The cut
+// never leaves a trailing '-' or '.', which names and label values must not end in.
+func withSuffix(prefix, suffix string, maxLength int) string {
+	if keep := maxLength - len(suffix); len(prefix) > keep {
+		prefix = strings.TrimRight(prefix[:max(keep, 0)], "-.")
+	}
+	return prefix + suffix
+}
+
+// labelValue makes a name usable as a label value
+func labelValue(name string) string {
+	return boundedName(name, validation.LabelValueMaxLength)
+}
+
+// scheduledBackupName names the Backup of a policy for a schedule tick. The
+// name only depends on the policy and the tick, so every reconcile of the
+// same tick arrives at the same Backup.
+func scheduledBackupName(policyName string, tick time.Time) string {
+	tick = tick.UTC()
+	suffix := "-" + tick.Format(tickNameFormat) + "-" + nameHash(policyName, strconv.FormatInt(tick.Unix(), 10))
+	return withSuffix(policyName, suffix, maxBackupNameLength)
+}
+
+// manualBackupName returns a stable Backup name for a trigger-now token
+func manualBackupName(policyName, token string) string {
+	return withSuffix(policyName, "-manual-"+nameHash(token), maxBackupNameLength)
+}
+
+// groupMemberName names the member Backup of a consistency group for one of its PVCs
+func groupMemberName(group, pvcName string) string {
+	return boundedName(group+"-"+pvcName, maxBackupNameLength)
+}
+
+// tickLabelValue formats a schedule tick for the tick label
+func tickLabelValue(tick time.Time) string {
+	return tick.UTC().Format(tickLabelFormat)
+}
+
+// backupLabels returns the policy, group, tick and target labels of a Backup, for
+// the Backup itself and for the Jobs it runs
+func backupLabels(backup *backupv1alpha1.Backup) map[string]string {
+	labels := map[string]string{
+		backupv1alpha1.TargetLabel: labelValue(backup.Spec.Target.PVCName),
+	}
+	if backup.Spec.PolicyRef != "" {
+		labels[backupv1alpha1.PolicyLabel] = labelValue(backup.Spec.PolicyRef)
+	}
+	if backup.Spec.Group != "" {
+		labels[backupv1alpha1.GroupLabel] = labelValue(backup.Spec.Group)
+	}
+	if tick, ok := backup.Labels[backupv1alpha1.TickLabel]; ok {
+		labels[backupv1alpha1.TickLabel] = tick
+	}
+	return labels
+}
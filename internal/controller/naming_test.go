@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestScheduledBackupName(t *testing.T) {
+	tick := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+
+	name := scheduledBackupName("nightly", tick)
+	if !strings.HasPrefix(name, "nightly-20260301-020000-") {
+		t.Errorf("scheduledBackupName() = %q, want the policy name and tick", name)
+	}
+	if again := scheduledBackupName("nightly", tick.In(time.FixedZone("CET", 3600))); again != name {
+		t.Errorf("the same tick gave %q and %q", name, again)
+	}
+	if next := scheduledBackupName("nightly", tick.Add(time.Minute)); next == name {
+		t.Errorf("different ticks gave the same name %q", name)
+	}
+
+	// Policies whose names only differ after the cut must not collide
+	long := strings.Repeat("a", 80)
+	first, second := scheduledBackupName(long+"-one", tick), scheduledBackupName(long+"-two", tick)
+	if first == second {
+		t.Errorf("long policy names collide: %q", first)
+	}
+	for _, got := range []string{first, second, manualBackupName(long, "token")} {
+		if len(got) > maxBackupNameLength {
+			t.Errorf("%q is %d characters, want at most %d", got, len(got), maxBackupNameLength)
+		}
+		if errs := validation.IsDNS1123Subdomain(got); len(errs) > 0 {
+			t.Errorf("%q is not a valid name: %v", got, errs)
+		}
+	}
+}
+
+func TestManualBackupName(t *testing.T) {
+	// Names of Backups created before names were bounded must not change
+	if got := manualBackupName("nightly", "1700000000"); got != "nightly-manual-"+nameHash("1700000000") {
+		t.Errorf("manualBackupName() = %q", got)
+	}
+}
+
+func TestBoundedName(t *testing.T) {
+	if got := boundedName("nightly-job", maxJobNameLength); got != "nightly-job" {
+		t.Errorf("short names must not change, got %q", got)
+	}
+
+	// A cut that lands on a dash must not leave it at the end
+	long := strings.Repeat("a", 54) + "-" + strings.Repeat("b", 20)
+	got := boundedName(long, maxJobNameLength)
+	if len(got) > maxJobNameLength || strings.Contains(got, "--") {
+		t.Errorf("boundedName() = %q", got)
+	}
+	if errs := validation.IsValidLabelValue(got); len(errs) > 0 {
+		t.Errorf("%q is not a valid label value: %v", got, errs)
+	}
+
+	backup := &backupv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("x", 250)}}
+	for _, name := range []string{backupJobName(backup), attemptJobName(backupJobName(backup), 12), verificationName(backup)} {
+		if len(name) > maxJobNameLength {
+			t.Errorf("Job name %q is %d characters", name, len(name))
+		}
+	}
+}
+
+func TestFindTickBackup(t *testing.T) {
+	ctx := context.Background()
+	tick := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	policy := &backupv1alpha1.BackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+		Spec:       backupv1alpha1.BackupPolicySpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+	}
+
+	// A Backup for the tick created under a different name is still found
+	existing := newPolicyBackup(policy, "nightly-created-earlier")
+	existing.Labels[backupv1alpha1.TickLabel] = tickLabelValue(tick)
+	other := newPolicyBackup(policy, "nightly-other-tick")
+	other.Labels[backupv1alpha1.TickLabel] = tickLabelValue(tick.Add(-24 * time.Hour))
+
+	r := &BackupPolicyReconciler{Client: newTransitionClient(t, existing, other)}
+
+	got, err := r.findTickBackup(ctx, policy, tick)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.Name != existing.Name {
+		t.Errorf("findTickBackup() = %v, want %s", got, existing.Name)
+	}
+
+	if got, err := r.findTickBackup(ctx, policy, tick.Add(time.Hour)); err != nil || got != nil {
+		t.Errorf("findTickBackup() for a new tick = %v, %v, want nothing", got, err)
+	}
+
+	wantLabels := map[string]string{
+		backupv1alpha1.PolicyLabel: "nightly",
+		backupv1alpha1.TickLabel:   "20260301T020000Z",
+		backupv1alpha1.TargetLabel: "data",
+	}
+	for key, value := range wantLabels {
+		if existing.Labels[key] != value {
+			t.Errorf("label %s = %q, want %q", key, existing.Labels[key], value)
+		}
+	}
+}
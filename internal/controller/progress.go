@@ -0,0 +1,304 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+// Movers report progress by printing lines such as
+//
+//	progress bytesDone=1048576 filesDone=12 bytesTotal=4194304 filesTotal=40
+//
+// every progress interval, which the mover binary stores in a ConfigMap
+// owned by the Job, see createProgressConfigMap. The operator watches those
+// ConfigMaps and copies the latest line into status.progress.
+const (
+	// progressPrefix starts every progress line
+	progressPrefix = "progress "
+
+	// progressKey holds the latest progress line in a progress ConfigMap
+	progressKey = "progress"
+
+	// progressOwnerAnnotation holds the namespace/name of the Backup or
+	// Restore a progress ConfigMap reports on
+	progressOwnerAnnotation = "backup.manuchim.dev/progress-of"
+
+	// moverExitFile is where the background mover command records its exit code
+	moverExitFile = "/tmp/mover-exit"
+
+	// moverFilesFile collects the paths tar lists as it works, one per line
+	moverFilesFile = "/tmp/mover-files"
+)
+
+// progressSeconds returns how often movers report their progress, 0 if they
+// report none. Reports are written by the mover binary, so they need the
+// operator image.
+func progressSeconds(cfg *config.OperatorConfig, moverToolsImage string) int {
+	interval := cfg.ProgressSampleInterval()
+	if moverToolsImage == "" || interval <= 0 {
+		return 0
+	}
+	return max(int(interval/time.Second), 1)
+}
+
+// withProgress runs a shell command in the background and, every seconds
+// until it exits, pipes the output of sample, which must print a progress
+// line, into the report command. The snippet is a single group that exits
+// with the command's status, so it can be chained with && like any other
+// command.
+func withProgress(command, sample, report string, seconds int) string {
+	var reporting string
+	if seconds > 0 {
+		reporting = "if [ $((i % " + strconv.Itoa(seconds) + ")) -eq 0 ]; then " + sample + " | " + report + "; fi; "
+	}
+	// Waiting on a marker file instead of the PID works in every shell,
+	// including those that keep exited children around until they are reaped
+	return "{ rm -f " + moverExitFile + "; : > " + moverFilesFile + "; " +
+		"(" + command + "; echo $? > " + moverExitFile + ".tmp && mv " + moverExitFile + ".tmp " + moverExitFile + ") & " +
+		"i=0; while [ ! -f " + moverExitFile + " ]; do " + reporting +
+		"i=$((i + 1)); sleep 1; done; " +
+		"[ \"$(cat " + moverExitFile + ")\" -eq 0 ]; }"
+}
+
+// progressConfigMapName returns the name of the ConfigMap a mover Job
+// reports its progress to
+func progressConfigMapName(jobName string) string {
+	return withSuffix(jobName, "-progress", validation.DNS1123SubdomainMaxLength)
+}
+
+// reportCommand is the mover command storing the progress lines it reads in
+// the ConfigMap of a Job
+func reportCommand(namespace, jobName string) string {
+	return archive.Tool + " report -namespace " + namespace + " -configmap " + progressConfigMapName(jobName)
+}
+
+// diskUsageOf is a shell expression for the disk usage of a path in bytes. It
+// is empty if du fails, which leaves the value out of the progress line.
+func diskUsageOf(path string) string {
+	return "$(du -sk " + path + " 2>/dev/null | awk '{ printf \"%d\", $1 * 1024 }')"
+}
+
+// filesDone is a shell expression for the number of paths tar listed so far
+func filesDone() string {
+	return "$(wc -l < " + moverFilesFile + ")"
+}
+
+// parseCounters reads the integer key=value pairs of a progress line or a
+// mover termination message; anything else is ignored
+func parseCounters(line string) map[string]int64 {
+	counters := map[string]int64{}
+	for _, field := range strings.Fields(line) {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			continue
+		}
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil && n >= 0 {
+			counters[key] = n
+		}
+	}
+	return counters
+}
+
+// createProgressConfigMap creates the ConfigMap a mover Job reports its
+// progress to and the Role letting the Job's pods update it. They are owned
+// by the Job, so they are removed with it. mover is backupMover or
+// restoreMover, owner the Backup or Restore the Job runs for.
+func createProgressConfigMap(ctx context.Context, c client.Client, apiReader client.Reader, job *batchv1.Job,
+	mover string, owner types.NamespacedName) error {
+	// A Job created by an earlier reconcile has no UID in the object built
+	// for it, and may not be in the cache yet
+	if job.UID == "" {
+		if err := apiReader.Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+			return err
+		}
+	}
+
+	name := progressConfigMapName(job.Name)
+	meta := func() metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       job.Namespace,
+			Labels:          map[string]string{backupv1alpha1.MoverLabel: mover},
+			Annotations:     map[string]string{progressOwnerAnnotation: owner.String()},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(job, batchv1.SchemeGroupVersion.WithKind("Job"))},
+		}
+	}
+	serviceAccount := job.Spec.Template.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+
+	objects := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: meta()},
+		&rbacv1.Role{
+			ObjectMeta: meta(),
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{name},
+				Verbs:         []string{"get", "patch"},
+			}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: meta(),
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      serviceAccount,
+				Namespace: job.Namespace,
+			}},
+		},
+	}
+	for _, object := range objects {
+		if err := c.Create(ctx, object); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// readProgress returns the counters of the latest progress line a mover Job
+// reported, or nil if there is none yet
+func readProgress(ctx context.Context, c client.Reader, job *batchv1.Job) map[string]int64 {
+	var configMap corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKey{Name: progressConfigMapName(job.Name), Namespace: job.Namespace}, &configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logf.FromContext(ctx).Error(err, "unable to read mover progress", "jobName", job.Name)
+		}
+		return nil
+	}
+	return latestProgressLine(configMap.Data[progressKey])
+}
+
+// progressOwner maps a progress ConfigMap to the Backup or Restore it
+// reports on
+func progressOwner(_ context.Context, obj client.Object) []reconcile.Request {
+	namespace, name, found := strings.Cut(obj.GetAnnotations()[progressOwnerAnnotation], "/")
+	if !found || namespace == "" || name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}}
+}
+
+// reportsOn selects the progress ConfigMaps of one kind of mover
+func reportsOn(mover string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetLabels()[backupv1alpha1.MoverLabel] == mover
+	})
+}
+
+// latestProgressLine returns the counters of the last progress line in logs
+func latestProgressLine(logs string) map[string]int64 {
+	lines := strings.Split(logs, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line, found := strings.CutPrefix(strings.TrimSpace(lines[i]), progressPrefix); found {
+			return parseCounters(line)
+		}
+	}
+	return nil
+}
+
+// newProgress builds status.progress from the counters of a progress line.
+// The completion time is extrapolated from the rate since started.
+func newProgress(counters map[string]int64, started *metav1.Time, now time.Time) *backupv1alpha1.Progress {
+	progress := &backupv1alpha1.Progress{
+		BytesDone:      counters["bytesDone"],
+		BytesTotal:     counters["bytesTotal"],
+		FilesDone:      counters["filesDone"],
+		FilesTotal:     counters["filesTotal"],
+		LastUpdateTime: metav1.NewTime(now),
+	}
+
+	var fraction float64
+	switch {
+	case progress.BytesTotal > 0 && progress.BytesDone > 0:
+		fraction = float64(progress.BytesDone) / float64(progress.BytesTotal)
+	case progress.FilesTotal > 0:
+		fraction = float64(progress.FilesDone) / float64(progress.FilesTotal)
+	default:
+		return progress
+	}
+	// A running mover is never done: the target may already have held data,
+	// and the last files are still being written
+	fraction = min(fraction, 0.99)
+	progress.Percentage = ptr.To(int32(fraction * 100))
+
+	if started != nil && fraction > 0 {
+		elapsed := now.Sub(started.Time)
+		remaining := time.Duration(float64(elapsed) * (1 - fraction) / fraction)
+		progress.EstimatedCompletionTime = ptr.To(metav1.NewTime(now.Add(remaining).Truncate(time.Second)))
+	}
+	return progress
+}
+
+// progressChanged reports whether a new sample differs from the recorded
+// progress in anything but its timestamps, so unchanged samples cause no writes
+func progressChanged(recorded, sample *backupv1alpha1.Progress) bool {
+	if recorded == nil {
+		return true
+	}
+	return recorded.BytesDone != sample.BytesDone || recorded.BytesTotal != sample.BytesTotal ||
+		recorded.FilesDone != sample.FilesDone || recorded.FilesTotal != sample.FilesTotal ||
+		!ptr.Equal(recorded.Percentage, sample.Percentage)
+}
+
+// completedProgress marks all work done once the mover Job succeeded. Known
+// totals replace those of the last sample, which may be missing when the
+// Job finished before it was first sampled.
+func completedProgress(progress *backupv1alpha1.Progress, bytesTotal, filesTotal int64, now time.Time) *backupv1alpha1.Progress {
+	done := &backupv1alpha1.Progress{}
+	if progress != nil {
+		done = progress.DeepCopy()
+	}
+	if bytesTotal > 0 {
+		done.BytesTotal = bytesTotal
+	}
+	if filesTotal > 0 {
+		done.FilesTotal = filesTotal
+	}
+	if done.BytesTotal > 0 {
+		done.BytesDone = done.BytesTotal
+	}
+	if done.FilesTotal > 0 {
+		done.FilesDone = done.FilesTotal
+	}
+	done.Percentage = ptr.To[int32](100)
+	done.EstimatedCompletionTime = nil
+	done.LastUpdateTime = metav1.NewTime(now)
+	return done
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestLatestProgressLine(t *testing.T) {
+	logs := "Starting backup of PVC: data\n" +
+		"progress filesDone=3 filesTotal=40 bytesTotal=4096\n" +
+		"progress bytesDone=1024 filesDone=12 filesTotal=40 bytesTotal=4096 note=x\n" +
+		"tar: removing leading '/'\n"
+
+	got := latestProgressLine(logs)
+	want := map[string]int64{"bytesDone": 1024, "filesDone": 12, "filesTotal": 40, "bytesTotal": 4096}
+	if len(got) != len(want) {
+		t.Fatalf("latestProgressLine() = %v, want %v", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %d, want %d", key, got[key], value)
+		}
+	}
+
+	if got := latestProgressLine("Starting restore operation...\n"); got != nil {
+		t.Errorf("latestProgressLine() without progress = %v, want nil", got)
+	}
+	// Empty values, as printed when du fails, are left out
+	if got := parseCounters("bytesDone= filesDone=2"); len(got) != 1 || got["filesDone"] != 2 {
+		t.Errorf("parseCounters() = %v, want only filesDone", got)
+	}
+}
+
+func TestNewProgress(t *testing.T) {
+	started := metav1.NewTime(transitionStart)
+	now := transitionStart.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		counters    map[string]int64
+		wantPercent *int32
+		wantETA     *time.Time
+	}{
+		{
+			name:        "bytes take precedence over files",
+			counters:    map[string]int64{"bytesDone": 250, "bytesTotal": 1000, "filesDone": 9, "filesTotal": 10},
+			wantPercent: ptr.To[int32](25),
+			wantETA:     ptr.To(now.Add(3 * time.Minute)),
+		},
+		{
+			name:        "files when bytes are unknown",
+			counters:    map[string]int64{"filesDone": 5, "filesTotal": 10, "bytesTotal": 1000},
+			wantPercent: ptr.To[int32](50),
+			wantETA:     ptr.To(now.Add(time.Minute)),
+		},
+		{
+			name:        "a running mover is never complete",
+			counters:    map[string]int64{"bytesDone": 2000, "bytesTotal": 1000},
+			wantPercent: ptr.To[int32](99),
+			wantETA:     ptr.To(now),
+		},
+		{
+			name:     "no totals",
+			counters: map[string]int64{"filesDone": 5},
+		},
+		{
+			name:        "no work done yet",
+			counters:    map[string]int64{"filesDone": 0, "filesTotal": 10},
+			wantPercent: ptr.To[int32](0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := newProgress(tt.counters, &started, now)
+			if !ptr.Equal(progress.Percentage, tt.wantPercent) {
+				t.Errorf("percentage = %v, want %v", ptr.Deref(progress.Percentage, -1), ptr.Deref(tt.wantPercent, -1))
+			}
+			switch {
+			case tt.wantETA == nil && progress.EstimatedCompletionTime != nil:
+				t.Errorf("estimatedCompletionTime = %v, want none", progress.EstimatedCompletionTime)
+			case tt.wantETA != nil && (progress.EstimatedCompletionTime == nil || !progress.EstimatedCompletionTime.Time.Equal(*tt.wantETA)):
+				t.Errorf("estimatedCompletionTime = %v, want %v", progress.EstimatedCompletionTime, *tt.wantETA)
+			}
+			if !progress.LastUpdateTime.Time.Equal(now) {
+				t.Errorf("lastUpdateTime = %v, want %v", progress.LastUpdateTime, now)
+			}
+		})
+	}
+
+	sample := newProgress(map[string]int64{"filesDone": 5, "filesTotal": 10}, &started, now)
+	later := newProgress(map[string]int64{"filesDone": 5, "filesTotal": 10}, &started, now.Add(time.Minute))
+	if progressChanged(sample, later) {
+		t.Error("progressChanged() = true for a sample differing only in time")
+	}
+	if !progressChanged(sample, newProgress(map[string]int64{"filesDone": 6, "filesTotal": 10}, &started, now)) {
+		t.Error("progressChanged() = false for a sample with more files done")
+	}
+}
+
+func TestCompletedProgress(t *testing.T) {
+	now := transitionStart.Add(time.Hour)
+	running := &backupv1alpha1.Progress{
+		BytesDone:               512,
+		FilesDone:               7,
+		FilesTotal:              10,
+		Percentage:              ptr.To[int32](51),
+		EstimatedCompletionTime: ptr.To(metav1.NewTime(now)),
+	}
+
+	done := completedProgress(running, 2048, 0, now)
+	if done.BytesDone != 2048 || done.BytesTotal != 2048 || done.FilesDone != 10 || done.FilesTotal != 10 {
+		t.Errorf("completedProgress() counters = %+v", done)
+	}
+	if ptr.Deref(done.Percentage, 0) != 100 || done.EstimatedCompletionTime != nil || !done.LastUpdateTime.Time.Equal(now) {
+		t.Errorf("completedProgress() = %+v, want 100%% without an estimate", done)
+	}
+	if running.BytesDone != 512 {
+		t.Error("completedProgress() modified the recorded progress")
+	}
+
+	// A Job that finished before it was sampled still completes
+	if done := completedProgress(nil, 0, 0, now); ptr.Deref(done.Percentage, 0) != 100 {
+		t.Errorf("completedProgress(nil) = %+v, want 100%%", done)
+	}
+}
+
+func TestProgressReports(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+	backup := &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+	}
+	r := &BackupReconciler{
+		Client:          newTransitionClient(t, backup),
+		Recorder:        record.NewFakeRecorder(100),
+		Clock:           clocktesting.NewFakeClock(transitionStart),
+		MoverToolsImage: "operator:v1",
+	}
+
+	// The first reconcile creates the Job with the ConfigMap its pods may
+	// report to
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	jobName := attemptJobName(backupJobName(backup), 1)
+	progressKey := types.NamespacedName{Name: jobName + "-progress", Namespace: key.Namespace}
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, progressKey, &configMap); err != nil {
+		t.Fatal(err)
+	}
+	if owners := configMap.OwnerReferences; len(owners) != 1 || owners[0].Kind != "Job" || owners[0].Name != jobName {
+		t.Errorf("ConfigMap owners = %+v, want the Job", owners)
+	}
+	if requests := progressOwner(ctx, &configMap); len(requests) != 1 || requests[0].NamespacedName != key {
+		t.Errorf("progressOwner() = %v, want %v", requests, key)
+	}
+	if !reportsOn(backupMover).Generic(event.GenericEvent{Object: &configMap}) || reportsOn(restoreMover).Generic(event.GenericEvent{Object: &configMap}) {
+		t.Errorf("ConfigMap labels = %v, want only backups to watch it", configMap.Labels)
+	}
+	var role rbacv1.Role
+	if err := r.Get(ctx, progressKey, &role); err != nil {
+		t.Fatal(err)
+	}
+	if rule := role.Rules[0]; len(rule.ResourceNames) != 1 || rule.ResourceNames[0] != progressKey.Name {
+		t.Errorf("Role rules = %+v, want only the progress ConfigMap", role.Rules)
+	}
+	var binding rbacv1.RoleBinding
+	if err := r.Get(ctx, progressKey, &binding); err != nil {
+		t.Fatal(err)
+	}
+	if subjects := binding.Subjects; len(subjects) != 1 || subjects[0].Name != "default" {
+		t.Errorf("RoleBinding subjects = %+v, want the default service account", subjects)
+	}
+
+	// Nothing is recorded before the mover reports, and nothing is polled
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	var got backupv1alpha1.Backup
+	if err := r.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Progress != nil {
+		t.Errorf("progress = %+v, want none before the first report", got.Status.Progress)
+	}
+
+	// A report triggers a reconcile that records it
+	configMap.Data = map[string]string{"progress": "progress filesDone=5 filesTotal=10"}
+	if err := r.Update(ctx, &configMap); err != nil {
+		t.Fatal(err)
+	}
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	if err := r.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Progress == nil || got.Status.Progress.FilesDone != 5 || ptr.Deref(got.Status.Progress.Percentage, 0) != 50 {
+		t.Errorf("progress = %+v, want 5 of 10 files", got.Status.Progress)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	script := withProgress("tar -cf a.tar /data", "echo progress", "/mover-tools/mover report", 30)
+	if !strings.Contains(script, "if [ $((i % 30)) -eq 0 ]; then echo progress | /mover-tools/mover report; fi") {
+		t.Errorf("withProgress() does not report every 30s: %s", script)
+	}
+	if script := withProgress("tar -cf a.tar /data", "echo progress", "/mover-tools/mover report", 0); strings.Contains(script, "progress") {
+		t.Errorf("withProgress() reports with reporting disabled: %s", script)
+	}
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+// The operator's backup queue is computed from the Backups and Restores in
+// the cache on every reconcile rather than held in memory, so it survives
+// restarts and leader changes. Status writes reach the cache with a delay, so
+// concurrent reconciles can briefly run more backups than the caps allow.
+// Queued backups are reconciled again when a Backup or Restore changes phase,
+// see queueMembers, and optionally on the timer of backupQueue.pollInterval.
+
+// queueLoad counts the slots taken under the queue's caps
+type queueLoad struct {
+	limits       *config.QueueLimits
+	running      int
+	perNamespace map[string]int
+}
+
+// fits reports whether one more backup or restore in namespace stays within the caps
+func (l *queueLoad) fits(namespace string) bool {
+	return (l.limits.MaxRunning == 0 || l.running < l.limits.MaxRunning) &&
+		(l.limits.MaxRunningPerNamespace == 0 || l.perNamespace[namespace] < l.limits.MaxRunningPerNamespace)
+}
+
+// take counts one more backup or restore running in namespace
+func (l *queueLoad) take(namespace string) {
+	l.running++
+	l.perNamespace[namespace]++
+}
+
+// queueLimits returns the caps of the backup queue, or nil when there are none
+func (r *BackupReconciler) queueLimits() *config.QueueLimits {
+	limits := r.Config.Get().BackupQueue
+	if limits == nil || (limits.MaxRunning == 0 && limits.MaxRunningPerNamespace == 0) {
+		return nil
+	}
+	return limits
+}
+
+// queueable reports whether the queue orders and preempts a backup. Members of
+// a consistency group start together after their pre-hook, so they are never
+// held back, and imported backups never run.
+func queueable(backup *backupv1alpha1.Backup) bool {
+	return backup.Spec.Group == "" && !isImported(backup)
+}
+
+// waitingPhase reports whether a backup in phase has not been admitted yet
+func waitingPhase(phase backupv1alpha1.BackupPhase) bool {
+	return phase == "" || phase == backupv1alpha1.BackupPhasePending || phase == backupv1alpha1.BackupPhaseQueued
+}
+
+// queueOrder orders waiting backups: higher priority first, then oldest first
+func queueOrder(a, b backupv1alpha1.Backup) int {
+	return cmp.Or(
+		cmp.Compare(b.Spec.Priority, a.Spec.Priority),
+		a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+// keepOrder orders running backups by how much they are kept over others:
+// backups the queue cannot preempt first, then higher priority, then the
+// longest running, so the backups that lose the least work are preempted
+func keepOrder(a, b backupv1alpha1.Backup) int {
+	startOf := func(backup backupv1alpha1.Backup) time.Time {
+		if backup.Status.StartTime == nil {
+			return time.Time{}
+		}
+		return backup.Status.StartTime.Time
+	}
+	preemptible := func(backup backupv1alpha1.Backup) int {
+		if queueable(&backup) {
+			return 1
+		}
+		return 0
+	}
+	return cmp.Or(
+		cmp.Compare(preemptible(a), preemptible(b)),
+		cmp.Compare(b.Spec.Priority, a.Spec.Priority),
+		startOf(a).Compare(startOf(b)),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+// queueSnapshot lists what the queue's caps apply to: the backups waiting to
+// be admitted, in queue order, the running backups, in keep order, and the
+// running restores per namespace
+type queueSnapshot struct {
+	waiting  []backupv1alpha1.Backup
+	running  []backupv1alpha1.Backup
+	restores map[string]int
+}
+
+// snapshotQueue reads the queue's state from the cache. backup replaces its
+// cached copy, which may be older.
+func (r *BackupReconciler) snapshotQueue(ctx context.Context, backup *backupv1alpha1.Backup) (*queueSnapshot, error) {
+	var backups backupv1alpha1.BackupList
+	if err := r.List(ctx, &backups); err != nil {
+		return nil, err
+	}
+	var restores backupv1alpha1.RestoreList
+	if err := r.List(ctx, &restores); err != nil {
+		return nil, err
+	}
+
+	snapshot := &queueSnapshot{restores: map[string]int{}}
+	for _, item := range backups.Items {
+		if item.Namespace == backup.Namespace && item.Name == backup.Name {
+			item = *backup
+		}
+		switch {
+		case item.Status.Phase == backupv1alpha1.BackupPhaseRunning:
+			snapshot.running = append(snapshot.running, item)
+		case waitingPhase(item.Status.Phase) && queueable(&item) && !item.Spec.Cancel:
+			snapshot.waiting = append(snapshot.waiting, item)
+		}
+	}
+	// A group restore runs no Job of its own; its members are counted
+	for _, restore := range restores.Items {
+		if restore.Status.Phase == backupv1alpha1.RestorePhaseRunning && restore.Spec.Group == "" {
+			snapshot.restores[restore.Namespace]++
+		}
+	}
+	slices.SortFunc(snapshot.waiting, queueOrder)
+	slices.SortFunc(snapshot.running, keepOrder)
+	return snapshot, nil
+}
+
+// admitBackup decides whether a backup that has not started may start now.
+// Waiting backups are admitted in queue order while they fit within the
+// caps; a backup that has to wait is moved to the Queued phase.
+func (r *BackupReconciler) admitBackup(ctx context.Context, backup *backupv1alpha1.Backup) (bool, error) {
+	limits := r.queueLimits()
+	if limits == nil || !queueable(backup) {
+		return true, nil
+	}
+
+	snapshot, err := r.snapshotQueue(ctx, backup)
+	if err != nil {
+		return false, err
+	}
+	load := &queueLoad{limits: limits, perNamespace: map[string]int{}}
+	for _, running := range snapshot.running {
+		load.take(running.Namespace)
+	}
+	for namespace, count := range snapshot.restores {
+		load.running += count
+		load.perNamespace[namespace] += count
+	}
+
+	// Backups ahead of this one in the queue take the free slots first
+	position := 1
+	for _, waiting := range snapshot.waiting {
+		if queueOrder(waiting, *backup) >= 0 {
+			break
+		}
+		if load.fits(waiting.Namespace) {
+			load.take(waiting.Namespace)
+		} else {
+			position++
+		}
+	}
+	if load.fits(backup.Namespace) {
+		return true, nil
+	}
+	return false, r.markQueued(ctx, backup, position)
+}
+
+// queueMembers maps a Backup or Restore changing phase to the backups whose
+// place in the queue may change with it: a finished one frees a slot for the
+// waiting backups, and a running restore may preempt running backups.
+func (r *BackupReconciler) queueMembers(ctx context.Context, obj client.Object) []reconcile.Request {
+	if r.queueLimits() == nil {
+		return nil
+	}
+	_, restore := obj.(*backupv1alpha1.Restore)
+
+	var backups backupv1alpha1.BackupList
+	if err := r.List(ctx, &backups); err != nil {
+		logf.FromContext(ctx).Error(err, "unable to list queued Backups")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, backup := range backups.Items {
+		if !queueable(&backup) || (!restore && backup.Namespace == obj.GetNamespace() && backup.Name == obj.GetName()) {
+			continue
+		}
+		waiting := waitingPhase(backup.Status.Phase) && !backup.Spec.Cancel
+		if waiting || (restore && backup.Status.Phase == backupv1alpha1.BackupPhaseRunning) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&backup)})
+		}
+	}
+	return requests
+}
+
+// phaseChanged passes the creation and deletion of Backups and Restores, and
+// updates that change their phase, so progress updates do not wake the queue
+var phaseChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return objectPhase(e.ObjectOld) != objectPhase(e.ObjectNew)
+	},
+}
+
+// objectPhase returns the phase of a Backup or Restore
+func objectPhase(obj client.Object) string {
+	switch obj := obj.(type) {
+	case *backupv1alpha1.Backup:
+		return string(obj.Status.Phase)
+	case *backupv1alpha1.Restore:
+		return string(obj.Status.Phase)
+	}
+	return ""
+}
+
+// markQueued moves a backup to the Queued phase at position
+func (r *BackupReconciler) markQueued(ctx context.Context, backup *backupv1alpha1.Backup, position int) error {
+	if backup.Status.Phase == backupv1alpha1.BackupPhaseQueued && backup.Status.QueuePosition == int32(position) {
+		return nil
+	}
+	newlyQueued := backup.Status.Phase != backupv1alpha1.BackupPhaseQueued
+
+	base := backup.DeepCopy()
+	backup.Status.Phase = backupv1alpha1.BackupPhaseQueued
+	backup.Status.QueuePosition = int32(position)
+	message := fmt.Sprintf("Waiting for a free slot at position %d of the backup queue", position)
+	setConditions(&backup.Status.Conditions, backup.Generation, "Queued", message,
+		metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionFalse)
+	if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+		return err
+	}
+	if newlyQueued {
+		r.Recorder.Event(
+			backup,
+			corev1.EventTypeNormal,
+			"BackupQueued",
+			message,
+		)
+	}
+	return nil
+}
+
+// preempted reports whether running restores push a running backup out of
+// the caps. Backups are only preempted to make room for restores, never
+// because the caps were lowered, and in reverse keep order.
+func (r *BackupReconciler) preempted(ctx context.Context, backup *backupv1alpha1.Backup) (bool, error) {
+	limits := r.queueLimits()
+	if limits == nil || !queueable(backup) {
+		return false, nil
+	}
+
+	snapshot, err := r.snapshotQueue(ctx, backup)
+	if err != nil {
+		return false, err
+	}
+	restores := 0
+	for _, count := range snapshot.restores {
+		restores += count
+	}
+	if restores == 0 {
+		return false, nil
+	}
+
+	// rank is the backup's place in keep order among backups, and out of
+	// range of the preemptible ranks when it is not running
+	rank := func(backups []backupv1alpha1.Backup) int {
+		return slices.IndexFunc(backups, func(running backupv1alpha1.Backup) bool {
+			return running.Namespace == backup.Namespace && running.Name == backup.Name
+		})
+	}
+	overflows := func(backups []backupv1alpha1.Backup, limit, restores int) bool {
+		index := rank(backups)
+		return limit > 0 && restores > 0 && index >= 0 && index >= max(limit, len(backups))-restores
+	}
+
+	var namespace []backupv1alpha1.Backup
+	for _, running := range snapshot.running {
+		if running.Namespace == backup.Namespace {
+			namespace = append(namespace, running)
+		}
+	}
+	return overflows(snapshot.running, limits.MaxRunning, restores) ||
+		overflows(namespace, limits.MaxRunningPerNamespace, snapshot.restores[backup.Namespace]), nil
+}
+
+// preemptBackup deletes the running Job of a preempted backup and returns the
+// backup to the queue. The attempt is not counted as failed: its Job is
+// created again, once the deleted one is gone, when the backup is admitted.
+func (r *BackupReconciler) preemptBackup(ctx context.Context, backup *backupv1alpha1.Backup, jobName string) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if _, err := stopJob(ctx, r.Client, backup.Namespace, jobName); err != nil {
+		if errors.Is(err, errJobSucceeded) {
+			// Too late to preempt: the backup is recorded as completed as usual
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to delete preempted Backup Job", "jobName", jobName)
+		return ctrl.Result{}, err
+	}
+
+	base := backup.DeepCopy()
+	backup.Status.Phase = backupv1alpha1.BackupPhaseQueued
+	backup.Status.StartTime = nil
+	backup.Status.Progress = nil
+	message := fmt.Sprintf("Preempted by running restores; attempt %d runs again once admitted", max(backup.Status.Attempts, 1))
+	setConditions(&backup.Status.Conditions, backup.Generation, "Preempted", message,
+		metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionFalse)
+	if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+		log.Error(err, "unable to return preempted Backup to the queue")
+		return ctrl.Result{}, err
+	}
+	log.Info("Backup preempted", "jobName", jobName)
+	r.Recorder.Event(
+		backup,
+		corev1.EventTypeWarning,
+		"BackupPreempted",
+		message,
+	)
+	return ctrl.Result{RequeueAfter: r.Config.Get().QueuePollInterval()}, nil
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+// queuedBackup is a Backup of PVC data created at offset after transitionStart
+func queuedBackup(namespace, name string, priority int32, offset time.Duration, phase backupv1alpha1.BackupPhase) *backupv1alpha1.Backup {
+	backup := &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(transitionStart.Add(offset)),
+		},
+		Spec: backupv1alpha1.BackupSpec{
+			Target:   backupv1alpha1.BackupTarget{PVCName: "data"},
+			Priority: priority,
+		},
+		Status: backupv1alpha1.BackupStatus{Phase: phase},
+	}
+	if phase == backupv1alpha1.BackupPhaseRunning {
+		backup.Status.StartTime = &backup.CreationTimestamp
+		backup.Status.Attempts = 1
+	}
+	return backup
+}
+
+// newQueueReconciler returns a BackupReconciler with the given queue caps
+func newQueueReconciler(t *testing.T, limits config.QueueLimits, objs ...client.Object) (*BackupReconciler, *record.FakeRecorder) {
+	cfg := config.Default()
+	cfg.BackupQueue = &limits
+	recorder := record.NewFakeRecorder(100)
+	return &BackupReconciler{
+		Client:   newTransitionClient(t, objs...),
+		Recorder: recorder,
+		Clock:    clocktesting.NewFakeClock(transitionStart.Add(time.Hour)),
+		Config:   config.NewStore(cfg),
+	}, recorder
+}
+
+// reconcileBackup reconciles a Backup and returns its status afterwards
+func reconcileBackup(t *testing.T, r *BackupReconciler, namespace, name string) (ctrl.Result, backupv1alpha1.BackupStatus) {
+	t.Helper()
+	ctx := context.Background()
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var backup backupv1alpha1.Backup
+	if err := r.Get(ctx, key, &backup); err != nil {
+		t.Fatal(err)
+	}
+	return result, backup.Status
+}
+
+func TestBackupQueue(t *testing.T) {
+	ctx := context.Background()
+	r, recorder := newQueueReconciler(t, config.QueueLimits{MaxRunning: 1},
+		queuedBackup("default", "running", 0, 0, backupv1alpha1.BackupPhaseRunning),
+		queuedBackup("default", "oldest", 0, time.Minute, ""),
+		queuedBackup("default", "urgent", 10, 2*time.Minute, ""),
+	)
+
+	result, status := reconcileBackup(t, r, "default", "oldest")
+	if status.Phase != backupv1alpha1.BackupPhaseQueued || status.QueuePosition != 2 {
+		t.Errorf("oldest: phase %s at %d, want Queued behind the higher priority backup", status.Phase, status.QueuePosition)
+	}
+	// Backups and restores finishing wake the queue, so it is not polled by default
+	if result.RequeueAfter != 0 {
+		t.Errorf("RequeueAfter = %v, want none without a pollInterval", result.RequeueAfter)
+	}
+	_, status = reconcileBackup(t, r, "default", "urgent")
+	if status.Phase != backupv1alpha1.BackupPhaseQueued || status.QueuePosition != 1 {
+		t.Errorf("urgent: phase %s at %d, want Queued first", status.Phase, status.QueuePosition)
+	}
+	expectEvents(t, recorder, "BackupQueued", "BackupQueued")
+
+	// The running backup finishing frees the slot for the first in the queue
+	var running backupv1alpha1.Backup
+	if err := r.Get(ctx, types.NamespacedName{Name: "running", Namespace: "default"}, &running); err != nil {
+		t.Fatal(err)
+	}
+	running.Status.Phase = backupv1alpha1.BackupPhaseCompleted
+	if err := r.Status().Update(ctx, &running); err != nil {
+		t.Fatal(err)
+	}
+
+	_, status = reconcileBackup(t, r, "default", "oldest")
+	if status.Phase != backupv1alpha1.BackupPhaseQueued || status.QueuePosition != 1 {
+		t.Errorf("oldest: phase %s at %d, want still Queued behind the higher priority backup", status.Phase, status.QueuePosition)
+	}
+	_, status = reconcileBackup(t, r, "default", "urgent")
+	if status.Phase != backupv1alpha1.BackupPhaseRunning || status.QueuePosition != 0 {
+		t.Errorf("urgent: phase %s at %d, want Running", status.Phase, status.QueuePosition)
+	}
+	expectEvents(t, recorder, "BackupStarted", "JobCreated")
+}
+
+func TestBackupQueuePerNamespace(t *testing.T) {
+	r, _ := newQueueReconciler(t, config.QueueLimits{MaxRunningPerNamespace: 1},
+		queuedBackup("team-a", "running", 0, 0, backupv1alpha1.BackupPhaseRunning),
+		queuedBackup("team-a", "waiting", 0, time.Minute, ""),
+		queuedBackup("team-b", "nightly", 0, time.Minute, ""),
+	)
+
+	if _, status := reconcileBackup(t, r, "team-a", "waiting"); status.Phase != backupv1alpha1.BackupPhaseQueued {
+		t.Errorf("team-a backup phase = %s, want Queued", status.Phase)
+	}
+	if _, status := reconcileBackup(t, r, "team-b", "nightly"); status.Phase != backupv1alpha1.BackupPhaseRunning {
+		t.Errorf("team-b backup phase = %s, want Running", status.Phase)
+	}
+}
+
+func TestRestorePreemptsBackup(t *testing.T) {
+	ctx := context.Background()
+	restore := &backupv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{Name: "recover", Namespace: "default"},
+		Spec:       backupv1alpha1.RestoreSpec{BackupName: "yesterday", TargetPVC: "data"},
+		Status:     backupv1alpha1.RestoreStatus{Phase: backupv1alpha1.RestorePhaseRunning},
+	}
+	important := queuedBackup("default", "important", 5, time.Minute, backupv1alpha1.BackupPhaseRunning)
+	routine := queuedBackup("default", "routine", 0, 0, backupv1alpha1.BackupPhaseRunning)
+	r, recorder := newQueueReconciler(t, config.QueueLimits{MaxRunning: 2}, restore, important, routine,
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "important-job", Namespace: "default"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "routine-job", Namespace: "default"}},
+	)
+
+	if _, status := reconcileBackup(t, r, "default", "important"); status.Phase != backupv1alpha1.BackupPhaseRunning {
+		t.Errorf("higher priority backup phase = %s, want Running", status.Phase)
+	}
+	_, status := reconcileBackup(t, r, "default", "routine")
+	if status.Phase != backupv1alpha1.BackupPhaseQueued || status.Attempts != 1 {
+		t.Errorf("preempted backup phase %s after %d attempt(s), want Queued with its attempt kept", status.Phase, status.Attempts)
+	}
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: "routine-job", Namespace: "default"}, &job); !apierrors.IsNotFound(err) {
+		t.Errorf("preempted backup's Job not deleted: %v", err)
+	}
+	expectEvents(t, recorder, "BackupPreempted")
+
+	_, status = reconcileBackup(t, r, "default", "routine")
+	if status.Phase != backupv1alpha1.BackupPhaseQueued || status.QueuePosition != 1 {
+		t.Errorf("phase %s at %d while the restore runs, want Queued first", status.Phase, status.QueuePosition)
+	}
+
+	restore.Status.Phase = backupv1alpha1.RestorePhaseCompleted
+	if err := r.Status().Update(ctx, restore); err != nil {
+		t.Fatal(err)
+	}
+	_, status = reconcileBackup(t, r, "default", "routine")
+	if status.Phase != backupv1alpha1.BackupPhaseRunning || status.Attempts != 1 {
+		t.Errorf("phase %s after %d attempt(s) once the restore finished, want the same attempt Running", status.Phase, status.Attempts)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "routine-job", Namespace: "default"}, &job); err != nil {
+		t.Errorf("preempted attempt's Job not created again: %v", err)
+	}
+	expectEvents(t, recorder, "BackupStarted", "JobCreated")
+}
+
+func TestQueuePollInterval(t *testing.T) {
+	r, _ := newQueueReconciler(t, config.QueueLimits{MaxRunning: 1, PollInterval: &metav1.Duration{Duration: time.Minute}},
+		queuedBackup("default", "running", 0, 0, backupv1alpha1.BackupPhaseRunning),
+		queuedBackup("default", "waiting", 0, time.Minute, ""),
+	)
+	result, status := reconcileBackup(t, r, "default", "waiting")
+	if status.Phase != backupv1alpha1.BackupPhaseQueued || result.RequeueAfter != time.Minute {
+		t.Errorf("phase %s, RequeueAfter %v; want Queued and checked again after the pollInterval", status.Phase, result.RequeueAfter)
+	}
+}
+
+func TestQueueMembers(t *testing.T) {
+	cancelled := queuedBackup("default", "cancelled", 0, time.Minute, backupv1alpha1.BackupPhaseQueued)
+	cancelled.Spec.Cancel = true
+	member := queuedBackup("default", "db-data", 0, time.Minute, "")
+	member.Spec.Group = "db"
+	finished := queuedBackup("default", "finished", 0, 0, backupv1alpha1.BackupPhaseCompleted)
+	restore := &backupv1alpha1.Restore{
+		ObjectMeta: metav1.ObjectMeta{Name: "recover", Namespace: "default"},
+		Status:     backupv1alpha1.RestoreStatus{Phase: backupv1alpha1.RestorePhaseRunning},
+	}
+	r, _ := newQueueReconciler(t, config.QueueLimits{MaxRunning: 1},
+		queuedBackup("default", "running", 0, 0, backupv1alpha1.BackupPhaseRunning),
+		queuedBackup("default", "queued", 0, time.Minute, backupv1alpha1.BackupPhaseQueued),
+		queuedBackup("team-b", "new", 0, time.Minute, ""),
+		cancelled, member, finished, restore,
+	)
+	names := func(requests []reconcile.Request) []string {
+		var names []string
+		for _, request := range requests {
+			names = append(names, request.String())
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	// A finished backup frees a slot for the waiting backups
+	if got, want := names(r.queueMembers(context.Background(), finished)), []string{"default/queued", "team-b/new"}; !slices.Equal(got, want) {
+		t.Errorf("a finished backup enqueues %v, want %v", got, want)
+	}
+	// A running restore may preempt running backups too
+	want := []string{"default/queued", "default/running", "team-b/new"}
+	if got := names(r.queueMembers(context.Background(), restore)); !slices.Equal(got, want) {
+		t.Errorf("a running restore enqueues %v, want %v", got, want)
+	}
+
+	// Without caps there is no queue to wake
+	r.Config = nil
+	if got := r.queueMembers(context.Background(), finished); len(got) != 0 {
+		t.Errorf("without queue caps a finished backup enqueues %v", got)
+	}
+
+	// Only phase changes wake the queue, not progress updates
+	progressed := finished.DeepCopy()
+	progressed.Status.Progress = &backupv1alpha1.Progress{FilesDone: 10}
+	if phaseChanged.Update(event.UpdateEvent{ObjectOld: finished, ObjectNew: progressed}) {
+		t.Error("an update without a phase change passed the predicate")
+	}
+	running := finished.DeepCopy()
+	running.Status.Phase = backupv1alpha1.BackupPhaseRunning
+	if !phaseChanged.Update(event.UpdateEvent{ObjectOld: running, ObjectNew: finished}) {
+		t.Error("a phase change did not pass the predicate")
+	}
+}
@@ -0,0 +1,421 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+)
+
+// defaultReplicationRetry is used for copies, and their removal, of Backups
+// without a retry policy. A copy is cheap to repeat, and a missing one leaves the archive in a
+// single place.
+var defaultReplicationRetry = &backupv1alpha1.RetryPolicy{MaxAttempts: ptr.To[int32](3)}
+
+// reconcileReplication copies a completed Backup's archive to every replica
+// location in its spec and removes the copies retention asked to prune. Each
+// location is handled on its own, so one that is unavailable does not hold up
+// the others. It returns how long until a failed copy is retried, or zero when
+// the next step is triggered by a Job watch event.
+func (r *BackupReconciler) reconcileReplication(ctx context.Context, backup *backupv1alpha1.Backup) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+
+	if backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted || isImported(backup) || len(backup.Spec.Replicas) == 0 {
+		return 0, nil
+	}
+
+	base := backup.DeepCopy()
+	prune := replicasToPrune(backup)
+	var retryAfter time.Duration
+	var firstErr error
+	for _, location := range backup.Spec.Replicas {
+		replica := replicaStatus(backup, location)
+
+		var after time.Duration
+		var err error
+		if prune[location] && replicaCopied(replica) {
+			after, err = r.pruneReplica(ctx, backup, replica)
+		} else {
+			after, err = r.replicate(ctx, backup, replica)
+		}
+		retryAfter = earliestRequeue(retryAfter, after)
+		if err != nil {
+			log.Error(err, "unable to replicate Backup", "storageLocation", location)
+			replica.Message = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	setReplicatedCondition(backup)
+	if !equality.Semantic.DeepEqual(base.Status, backup.Status) {
+		if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+			log.Error(err, "unable to update Backup replicas")
+			return 0, err
+		}
+	}
+	return retryAfter, firstErr
+}
+
+// replicate advances the copy of the archive to one replica location and
+// returns how long until a failed copy is retried
+func (r *BackupReconciler) replicate(ctx context.Context, backup *backupv1alpha1.Backup, replica *backupv1alpha1.ReplicaStatus) (time.Duration, error) {
+	switch replica.Phase {
+	case backupv1alpha1.ReplicaPhaseCompleted, backupv1alpha1.ReplicaPhaseFailed,
+		backupv1alpha1.ReplicaPhasePruned, backupv1alpha1.ReplicaPhasePruneFailed:
+		return 0, nil
+	}
+
+	attempt := max(replica.Attempts, 1)
+	var job batchv1.Job
+	jobName := attemptJobName(replicaJobName(backup, replica.StorageLocation), attempt)
+	err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: backup.Namespace}, &job)
+	if apierrors.IsNotFound(err) {
+		return 0, r.startReplicaAttempt(ctx, backup, replica, attempt)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	state := evaluateAttempt(&job, attempt, replicationRetry(backup), r.Clock.Now())
+	switch state.Outcome {
+	case attemptSucceeded:
+		now := metav1.NewTime(r.Clock.Now())
+		replica.Phase = backupv1alpha1.ReplicaPhaseCompleted
+		replica.CompletionTime = &now
+		replica.Message = ""
+		r.Recorder.Eventf(
+			backup,
+			corev1.EventTypeNormal,
+			"ReplicaCompleted",
+			"Archive copied to storage location %s",
+			replica.StorageLocation,
+		)
+
+	case attemptRetryPending:
+		// Record the failure once per attempt; later reconciles during the backoff only requeue
+		if replica.Message == "" {
+			details := describeJobFailure(ctx, r.Pods, &job, state.Failed, r.Config.Get().FailureLogLines())
+			replica.Message = fmt.Sprintf("Attempt %d failed: %s", attempt, details)
+		}
+		return state.RetryAfter, nil
+
+	case attemptRetry:
+		return 0, r.startReplicaAttempt(ctx, backup, replica, attempt+1)
+
+	case attemptFailed:
+		details := describeJobFailure(ctx, r.Pods, &job, state.Failed, r.Config.Get().FailureLogLines())
+		replica.Phase = backupv1alpha1.ReplicaPhaseFailed
+		replica.Message = fmt.Sprintf("Copy failed after %d attempt(s): %s", attempt, details)
+		r.Recorder.Event(
+			backup,
+			corev1.EventTypeWarning,
+			"ReplicationFailed",
+			eventMessage(fmt.Sprintf("Copy to storage location %s failed: %s", replica.StorageLocation, replica.Message)),
+		)
+	}
+	return 0, nil
+}
+
+// startReplicaAttempt creates the copy Job of the given attempt
+func (r *BackupReconciler) startReplicaAttempt(ctx context.Context, backup *backupv1alpha1.Backup, replica *backupv1alpha1.ReplicaStatus, attempt int32) error {
+	cfg := r.Config.Get()
+	sourcePVC, err := storagePVCName(ctx, r.Client, cfg, backup.Namespace, backup.Spec.StorageLocation)
+	if err != nil {
+		return err
+	}
+	replicaPVC, err := storagePVCName(ctx, r.Client, cfg, backup.Namespace, replica.StorageLocation)
+	if err != nil {
+		return err
+	}
+	if replicaPVC == sourcePVC {
+		replica.Phase = backupv1alpha1.ReplicaPhaseFailed
+		replica.Message = "PVC " + replicaPVC + " already holds the archive"
+		return nil
+	}
+
+	job := r.createReplicaJob(backup, replica.StorageLocation, attempt, sourcePVC, replicaPVC)
+	if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	logf.FromContext(ctx).Info("Created replication Job", "jobName", job.Name, "storageLocation", replica.StorageLocation)
+	replica.Phase = backupv1alpha1.ReplicaPhaseReplicating
+	replica.Attempts = attempt
+	replica.Message = ""
+	return nil
+}
+
+// pruneReplica removes the archive's copy from a replica location whose
+// keepLast no longer selects it. Failed removals are retried like copies; the
+// replica stays Completed until its copy is gone, or becomes PruneFailed when
+// no attempts are left. It returns how long until a failed removal is retried.
+func (r *BackupReconciler) pruneReplica(ctx context.Context, backup *backupv1alpha1.Backup, replica *backupv1alpha1.ReplicaStatus) (time.Duration, error) {
+	if replica.Phase != backupv1alpha1.ReplicaPhaseCompleted {
+		return 0, nil
+	}
+
+	attempt := max(replica.PruneAttempts, 1)
+	var job batchv1.Job
+	jobName := attemptJobName(pruneJobName(backup, replica.StorageLocation), attempt)
+	err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: backup.Namespace}, &job)
+	if apierrors.IsNotFound(err) {
+		return 0, r.startPruneAttempt(ctx, backup, replica, attempt)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	state := evaluateAttempt(&job, attempt, replicationRetry(backup), r.Clock.Now())
+	switch state.Outcome {
+	case attemptSucceeded:
+		replica.Phase = backupv1alpha1.ReplicaPhasePruned
+		replica.Message = "Copy removed by the location's retention"
+		r.Recorder.Eventf(
+			backup,
+			corev1.EventTypeNormal,
+			"ReplicaPruned",
+			"Removed copy from storage location %s",
+			replica.StorageLocation,
+		)
+
+	case attemptRetryPending:
+		// Record the failure once per attempt; later reconciles during the backoff only requeue
+		if replica.Message == "" {
+			details := describeJobFailure(ctx, r.Pods, &job, state.Failed, r.Config.Get().FailureLogLines())
+			replica.Message = fmt.Sprintf("Removing the copy failed in attempt %d: %s", attempt, details)
+		}
+		return state.RetryAfter, nil
+
+	case attemptRetry:
+		return 0, r.startPruneAttempt(ctx, backup, replica, attempt+1)
+
+	case attemptFailed:
+		details := describeJobFailure(ctx, r.Pods, &job, state.Failed, r.Config.Get().FailureLogLines())
+		replica.Phase = backupv1alpha1.ReplicaPhasePruneFailed
+		replica.Message = fmt.Sprintf("Removing the copy failed after %d attempt(s), %s remains in the location: %s",
+			attempt, archive.Name(backup), details)
+		r.Recorder.Event(
+			backup,
+			corev1.EventTypeWarning,
+			"ReplicaPruneFailed",
+			eventMessage(fmt.Sprintf("Removing copy from storage location %s failed: %s", replica.StorageLocation, replica.Message)),
+		)
+	}
+	return 0, nil
+}
+
+// startPruneAttempt creates the Job of the given attempt to remove a copy
+func (r *BackupReconciler) startPruneAttempt(ctx context.Context, backup *backupv1alpha1.Backup, replica *backupv1alpha1.ReplicaStatus, attempt int32) error {
+	replicaPVC, err := storagePVCName(ctx, r.Client, r.Config.Get(), backup.Namespace, replica.StorageLocation)
+	if err != nil {
+		return err
+	}
+	jobName := attemptJobName(pruneJobName(backup, replica.StorageLocation), attempt)
+	if err := r.Create(ctx, r.createCleanupJob(backup, jobName, replicaPVC)); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	logf.FromContext(ctx).Info("Created Job to prune replica", "jobName", jobName, "storageLocation", replica.StorageLocation)
+	replica.PruneAttempts = attempt
+	replica.Message = ""
+	return nil
+}
+
+// replicationRetry is the retry policy of the Jobs that copy and remove replicas
+func replicationRetry(backup *backupv1alpha1.Backup) *backupv1alpha1.RetryPolicy {
+	if backup.Spec.Retry != nil {
+		return backup.Spec.Retry
+	}
+	return defaultReplicationRetry
+}
+
+// replicaCopied reports whether a replica location holds a complete copy of
+// the archive or held one that was pruned
+func replicaCopied(replica *backupv1alpha1.ReplicaStatus) bool {
+	switch replica.Phase {
+	case backupv1alpha1.ReplicaPhaseCompleted, backupv1alpha1.ReplicaPhasePruned, backupv1alpha1.ReplicaPhasePruneFailed:
+		return true
+	}
+	return false
+}
+
+// createReplicaJob builds the Job of a copy attempt. It copies the archive and
+// its checksum from sourcePVC to replicaPVC, checks the copy against the
+// checksum, then copies the manifest last so the replica location only ever
+// imports complete copies.
+func (r *BackupReconciler) createReplicaJob(backup *backupv1alpha1.Backup, location string, attempt int32, sourcePVC, replicaPVC string) *batchv1.Job {
+	cfg := r.Config.Get()
+	archiveFile := archive.Name(backup)
+	manifest := backup.Name + ".json"
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            attemptJobName(replicaJobName(backup, location), attempt),
+			Namespace:       backup.Namespace,
+			Labels:          backupLabels(backup),
+			OwnerReferences: backupOwnerReferences(backup),
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "replicate",
+							Image: cfg.MoverImage,
+							Command: []string{
+								"sh",
+								"-c",
+								"set -e && cd /backup-source && " +
+									"cp " + archiveFile + " /replica/" + archiveFile + ".tmp && " +
+									"mv /replica/" + archiveFile + ".tmp /replica/" + archiveFile + " && " +
+									"if [ -f " + archiveFile + ".sha256 ]; then " +
+									"cp " + archiveFile + ".sha256 /replica/ && (cd /replica && sha256sum -c " + archiveFile + ".sha256); " +
+									"else echo 'No checksum recorded for " + archiveFile + ", skipping checksum check'; fi && " +
+									"if [ -f " + manifest + " ]; then " +
+									"cp " + manifest + " /replica/" + manifest + ".tmp && mv /replica/" + manifest + ".tmp /replica/" + manifest + "; fi && " +
+									"echo 'Archive copied to storage location " + location + "'",
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "backup-source",
+									MountPath: "/backup-source",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "replica",
+									MountPath: "/replica",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "backup-source",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: sourcePVC,
+									ReadOnly:  true,
+								},
+							},
+						},
+						{
+							Name: "replica",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: replicaPVC,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	applyJobTemplates(&job.Spec.Template.Spec, cfg.DefaultJobTemplate, backup.Spec.JobTemplate)
+	applyJobLimits(job, backup.Spec.Timeout, cfg.JobTTL())
+	useFallbackTerminationMessages(&job.Spec.Template.Spec)
+	return job
+}
+
+// setReplicatedCondition summarizes the copies to the locations in the
+// Backup's spec. Pruned copies and copies that could not be pruned count as replicated.
+func setReplicatedCondition(backup *backupv1alpha1.Backup) {
+	var copying, failed []string
+	for _, location := range backup.Spec.Replicas {
+		replica := findReplica(backup.Status.Replicas, location)
+		switch {
+		case replica == nil:
+			copying = append(copying, location)
+		case replica.Phase == backupv1alpha1.ReplicaPhaseFailed:
+			failed = append(failed, location)
+		case !replicaCopied(replica):
+			copying = append(copying, location)
+		}
+	}
+
+	status, reason := metav1.ConditionTrue, "Replicated"
+	message := fmt.Sprintf("Archive copied to %d storage location(s)", len(backup.Spec.Replicas))
+	switch {
+	case len(failed) > 0:
+		status, reason = metav1.ConditionFalse, "ReplicationFailed"
+		message = "Copy to " + strings.Join(failed, ", ") + " failed"
+	case len(copying) > 0:
+		status, reason = metav1.ConditionFalse, "Replicating"
+		message = "Copying archive to " + strings.Join(copying, ", ")
+	}
+	setCondition(&backup.Status.Conditions, backup.Generation, backupv1alpha1.ConditionReplicated, status, reason, message)
+}
+
+// replicaStatus returns the status of the copy in location, adding a
+// Pending entry if the location has none yet
+func replicaStatus(backup *backupv1alpha1.Backup, location string) *backupv1alpha1.ReplicaStatus {
+	if replica := findReplica(backup.Status.Replicas, location); replica != nil {
+		return replica
+	}
+	backup.Status.Replicas = append(backup.Status.Replicas, backupv1alpha1.ReplicaStatus{
+		StorageLocation: location,
+		Phase:           backupv1alpha1.ReplicaPhasePending,
+	})
+	return &backup.Status.Replicas[len(backup.Status.Replicas)-1]
+}
+
+// findReplica returns the status of the copy in location, or nil
+func findReplica(replicas []backupv1alpha1.ReplicaStatus, location string) *backupv1alpha1.ReplicaStatus {
+	for i := range replicas {
+		if replicas[i].StorageLocation == location {
+			return &replicas[i]
+		}
+	}
+	return nil
+}
+
+// replicasToPrune returns the locations listed in the prune-replicas annotation
+func replicasToPrune(backup *backupv1alpha1.Backup) map[string]bool {
+	prune := map[string]bool{}
+	for _, location := range strings.Split(backup.Annotations[backupv1alpha1.PruneReplicasAnnotation], ",") {
+		if location = strings.TrimSpace(location); location != "" {
+			prune[location] = true
+		}
+	}
+	return prune
+}
+
+// replicaJobName is the base name of the Jobs that copy the archive to a replica location
+func replicaJobName(backup *backupv1alpha1.Backup, location string) string {
+	return boundedName(backup.Name+"-replica-"+location, maxJobNameLength)
+}
+
+// pruneJobName is the name of the Job that removes the archive's copy from a replica location
+func pruneJobName(backup *backupv1alpha1.Backup, location string) string {
+	return boundedName(backup.Name+"-prune-"+location, maxJobNameLength)
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestReplication(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+
+	newReconciler := func(replicas ...string) (*BackupReconciler, *clocktesting.FakeClock, *record.FakeRecorder) {
+		clock := clocktesting.NewFakeClock(transitionStart)
+		recorder := record.NewFakeRecorder(100)
+		return &BackupReconciler{
+			Client: newTransitionClient(t,
+				&backupv1alpha1.Backup{
+					ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+					Spec: backupv1alpha1.BackupSpec{
+						Target:   backupv1alpha1.BackupTarget{PVCName: "data"},
+						Replicas: replicas,
+					},
+					Status: backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+				},
+				&backupv1alpha1.BackupStorageLocation{
+					ObjectMeta: metav1.ObjectMeta{Name: "offsite", Namespace: "default"},
+					Spec:       backupv1alpha1.BackupStorageLocationSpec{PVCName: "offsite-storage"},
+				},
+				&backupv1alpha1.BackupStorageLocation{
+					ObjectMeta: metav1.ObjectMeta{Name: "dr", Namespace: "default"},
+					Spec:       backupv1alpha1.BackupStorageLocationSpec{PVCName: "dr-storage"},
+				},
+			),
+			Recorder: recorder,
+			Clock:    clock,
+		}, clock, recorder
+	}
+	fetch := func(r *BackupReconciler) *backupv1alpha1.Backup {
+		t.Helper()
+		var backup backupv1alpha1.Backup
+		if err := r.Get(ctx, key, &backup); err != nil {
+			t.Fatal(err)
+		}
+		return &backup
+	}
+	expectReplica := func(r *BackupReconciler, location string, phase backupv1alpha1.ReplicaPhase, attempts int32) {
+		t.Helper()
+		replica := findReplica(fetch(r).Status.Replicas, location)
+		if replica == nil || replica.Phase != phase || replica.Attempts != attempts {
+			t.Errorf("replica %s = %+v, want %s after %d attempt(s)", location, replica, phase, attempts)
+		}
+	}
+
+	t.Run("copies to every location and retries failed copies", func(t *testing.T) {
+		r, clock, recorder := newReconciler("offsite", "dr")
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		expectReplica(r, "offsite", backupv1alpha1.ReplicaPhaseReplicating, 1)
+		expectReplica(r, "dr", backupv1alpha1.ReplicaPhaseReplicating, 1)
+		if replicated := meta.FindStatusCondition(fetch(r).Status.Conditions, backupv1alpha1.ConditionReplicated); replicated == nil ||
+			replicated.Status != metav1.ConditionFalse || replicated.Message != "Copying archive to offsite, dr" {
+			t.Errorf("Replicated condition = %+v", replicated)
+		}
+
+		var job batchv1.Job
+		if err := r.Get(ctx, types.NamespacedName{Name: "nightly-replica-offsite", Namespace: "default"}, &job); err != nil {
+			t.Fatalf("replication Job not created: %v", err)
+		}
+		volumes := job.Spec.Template.Spec.Volumes
+		if volumes[0].PersistentVolumeClaim.ClaimName != "backup-storage" || !volumes[0].PersistentVolumeClaim.ReadOnly ||
+			volumes[1].PersistentVolumeClaim.ClaimName != "offsite-storage" {
+			t.Errorf("replication Job volumes = %+v", volumes)
+		}
+		script := job.Spec.Template.Spec.Containers[0].Command[2]
+		if !strings.Contains(script, "sha256sum -c nightly.tar.gz.sha256") ||
+			!strings.HasSuffix(script, "mv /replica/nightly.json.tmp /replica/nightly.json; fi && echo 'Archive copied to storage location offsite'") {
+			t.Errorf("replication script = %s", script)
+		}
+		expectEvents(t, recorder)
+
+		setJobCondition(t, r.Client, "nightly-replica-offsite", batchv1.JobComplete, clock.Now())
+		setJobCondition(t, r.Client, "nightly-replica-dr", batchv1.JobFailed, clock.Now())
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.RequeueAfter != defaultRetryBackoff {
+			t.Errorf("RequeueAfter = %v, want the retry backoff", result.RequeueAfter)
+		}
+		expectReplica(r, "offsite", backupv1alpha1.ReplicaPhaseCompleted, 1)
+		expectReplica(r, "dr", backupv1alpha1.ReplicaPhaseReplicating, 1)
+		if replica := findReplica(fetch(r).Status.Replicas, "dr"); !strings.HasPrefix(replica.Message, "Attempt 1 failed") {
+			t.Errorf("dr message = %q", replica.Message)
+		}
+		expectEvents(t, recorder, "ReplicaCompleted")
+
+		clock.Step(defaultRetryBackoff)
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		expectReplica(r, "dr", backupv1alpha1.ReplicaPhaseReplicating, 2)
+
+		setJobCondition(t, r.Client, "nightly-replica-dr-2", batchv1.JobComplete, clock.Now())
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		expectReplica(r, "dr", backupv1alpha1.ReplicaPhaseCompleted, 2)
+		if !meta.IsStatusConditionTrue(fetch(r).Status.Conditions, backupv1alpha1.ConditionReplicated) {
+			t.Errorf("Replicated condition should be True once every copy completed")
+		}
+		expectEvents(t, recorder, "ReplicaCompleted")
+	})
+
+	// requestPrune completes the copy to offsite, then asks for it to be pruned
+	requestPrune := func(r *BackupReconciler, clock *clocktesting.FakeClock, recorder *record.FakeRecorder) {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		setJobCondition(t, r.Client, "nightly-replica-offsite", batchv1.JobComplete, clock.Now())
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		drainEvents(recorder)
+
+		backup := fetch(r)
+		patch := client.MergeFrom(backup.DeepCopy())
+		metav1.SetMetaDataAnnotation(&backup.ObjectMeta, backupv1alpha1.PruneReplicasAnnotation, "offsite")
+		if err := r.Patch(ctx, backup, patch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("prunes the copies retention asks for", func(t *testing.T) {
+		r, clock, recorder := newReconciler("offsite")
+		requestPrune(r, clock, recorder)
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		var job batchv1.Job
+		if err := r.Get(ctx, types.NamespacedName{Name: "nightly-prune-offsite", Namespace: "default"}, &job); err != nil {
+			t.Fatalf("prune Job not created: %v", err)
+		}
+		if claim := job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName; claim != "offsite-storage" {
+			t.Errorf("prune Job PVC = %s, want offsite-storage", claim)
+		}
+
+		setJobCondition(t, r.Client, "nightly-prune-offsite", batchv1.JobComplete, clock.Now())
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		expectReplica(r, "offsite", backupv1alpha1.ReplicaPhasePruned, 1)
+		expectEvents(t, recorder, "ReplicaPruned")
+	})
+
+	t.Run("retries failed prunes and keeps the copy until it is gone", func(t *testing.T) {
+		r, clock, recorder := newReconciler("offsite")
+		requestPrune(r, clock, recorder)
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		for attempt := int32(1); attempt < 3; attempt++ {
+			setJobCondition(t, r.Client, attemptJobName("nightly-prune-offsite", attempt), batchv1.JobFailed, clock.Now())
+			backoff := retryBackoff(defaultReplicationRetry, attempt)
+			result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.RequeueAfter != backoff {
+				t.Errorf("RequeueAfter = %v, want the retry backoff %v", result.RequeueAfter, backoff)
+			}
+			replica := findReplica(fetch(r).Status.Replicas, "offsite")
+			if replica.Phase != backupv1alpha1.ReplicaPhaseCompleted || replica.PruneAttempts != attempt ||
+				!strings.HasPrefix(replica.Message, "Removing the copy failed in attempt") {
+				t.Fatalf("replica = %+v, want Completed with the failure", replica)
+			}
+
+			clock.Step(backoff)
+			result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			expectNoRequeue(t, result, err)
+			var job batchv1.Job
+			if err := r.Get(ctx, types.NamespacedName{Name: attemptJobName("nightly-prune-offsite", attempt+1), Namespace: "default"}, &job); err != nil {
+				t.Fatalf("prune Job of attempt %d not created: %v", attempt+1, err)
+			}
+		}
+
+		setJobCondition(t, r.Client, "nightly-prune-offsite-3", batchv1.JobFailed, clock.Now())
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		backup := fetch(r)
+		if replica := findReplica(backup.Status.Replicas, "offsite"); replica.Phase != backupv1alpha1.ReplicaPhasePruneFailed ||
+			!strings.Contains(replica.Message, "nightly.tar.gz remains in the location") {
+			t.Errorf("replica = %+v, want PruneFailed", replica)
+		}
+		if !meta.IsStatusConditionTrue(backup.Status.Conditions, backupv1alpha1.ConditionReplicated) {
+			t.Errorf("Replicated condition should stay True while the copy remains")
+		}
+		expectEvents(t, recorder, "ReplicaPruneFailed")
+	})
+
+	t.Run("unknown location is reported on the replica", func(t *testing.T) {
+		r, _, _ := newReconciler("missing")
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err == nil {
+			t.Fatal("Reconcile() should fail for an unknown storage location")
+		}
+		replica := findReplica(fetch(r).Status.Replicas, "missing")
+		if replica == nil || replica.Phase != backupv1alpha1.ReplicaPhasePending || !strings.Contains(replica.Message, "not found") {
+			t.Errorf("replica = %+v, want Pending with the lookup error", replica)
+		}
+	})
+
+	t.Run("imported backups are not copied", func(t *testing.T) {
+		r, _, _ := newReconciler("offsite")
+		backup := fetch(r)
+		backup.Labels = map[string]string{backupv1alpha1.OriginLabel: backupv1alpha1.OriginImported}
+		if err := r.Update(ctx, backup); err != nil {
+			t.Fatal(err)
+		}
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		if replicas := fetch(r).Status.Replicas; len(replicas) != 0 {
+			t.Errorf("replicas = %+v, want none", replicas)
+		}
+		expectJobGone(t, r.Client, "nightly-replica-offsite")
+	})
+}
+
+func TestReplicaRetention(t *testing.T) {
+	ctx := context.Background()
+	policy := &backupv1alpha1.BackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+		Spec: backupv1alpha1.BackupPolicySpec{
+			Retention: &backupv1alpha1.RetentionPolicy{KeepLast: ptr.To(2)},
+			Replicas: []backupv1alpha1.ReplicaLocation{
+				{StorageLocation: "offsite", KeepLast: ptr.To(1)},
+				{StorageLocation: "archive", KeepLast: ptr.To(3)},
+			},
+		},
+	}
+	var objs []client.Object
+	for i, name := range []string{"nightly-1", "nightly-2", "nightly-3", "nightly-4"} {
+		objs = append(objs, &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(transitionStart.Add(time.Duration(i) * time.Hour)),
+			},
+			Spec: backupv1alpha1.BackupSpec{PolicyRef: "nightly", Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+			Status: backupv1alpha1.BackupStatus{
+				Phase: backupv1alpha1.BackupPhaseCompleted,
+				Replicas: []backupv1alpha1.ReplicaStatus{
+					{StorageLocation: "offsite", Phase: backupv1alpha1.ReplicaPhaseCompleted},
+					{StorageLocation: "archive", Phase: backupv1alpha1.ReplicaPhaseCompleted},
+				},
+			},
+		})
+	}
+	c := newTransitionClient(t, objs...)
+	r := &BackupPolicyReconciler{Client: c, Recorder: record.NewFakeRecorder(100)}
+
+	if err := r.cleanupOldBackups(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	// The policy keeps two backups and archive keeps a third; only offsite's newest copy stays
+	want := map[string]string{
+		"nightly-2": "offsite",
+		"nightly-3": "offsite",
+		"nightly-4": "",
+	}
+	var backups backupv1alpha1.BackupList
+	if err := c.List(ctx, &backups); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, backup := range backups.Items {
+		got[backup.Name] = backup.Annotations[backupv1alpha1.PruneReplicasAnnotation]
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("backups and their pruned replicas = %v, want %v", got, want)
+	}
+}
@@ -20,18 +20,27 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"fmt"
+	"strconv"
 	"time"
 
 	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -41,14 +50,39 @@ type RestoreReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// Config holds the operator-level defaults; nil means built-in defaults
+	Config *config.Store
+
+	// Pods reads mover pods and their logs to explain failures; nil skips the details
+	Pods corev1client.PodsGetter
+
+	// Clock is used for phase transition timestamps and retry backoff; set to the
+	// real clock by SetupWithManager
+	Clock clock.Clock
+
+	// APIReader reads mover Jobs past the cache; set to the manager's by
+	// SetupWithManager, nil reads through Client
+	APIReader client.Reader
+
+	// MoverToolsImage is the operator image, whose mover binary decompresses
+	// zstd and lz4 archives and reports progress, see OperatorImage; empty
+	// rejects their restores and leaves the others without progress
+	MoverToolsImage string
 }
 
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=restores,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=restores/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=restores/finalizers,verbs=update
 // +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=backup.manuchim.dev,resources=backupstoragelocations,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -71,59 +105,63 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// If restore is already completed or failed, nothing to do
-	if restore.Status.Phase == backupv1alpha1.RestorePhaseCompleted ||
-		restore.Status.Phase == backupv1alpha1.RestorePhaseFailed {
+	// A group restore only creates and watches the Restores of its members
+	if restore.Spec.Group != "" {
+		return r.reconcileGroupRestore(ctx, &restore)
+	}
+
+	if done, err := r.reconcileRestoreCancel(ctx, &restore); done || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// If restore is already finished, nothing to do
+	if restoreFinished(restore.Status.Phase) {
 		log.Info("Restore already in terminal state", "phase", restore.Status.Phase)
 		return ctrl.Result{}, nil
 	}
 
-	// Validate that the Backup exists and is completed
-	targetNamespace := restore.Spec.TargetNamespace
-	if targetNamespace == "" {
-		targetNamespace = restore.Namespace
+	if restore.Spec.BackupName == "" || restore.Spec.TargetPVC == "" {
+		return ctrl.Result{}, r.rejectRestore(ctx, &restore, "InvalidSpec", "backupName and targetPVC must be set unless group is set")
+	}
+	if err := validateThrottle(restore.Spec.Throttle); err != nil {
+		return ctrl.Result{}, r.rejectRestore(ctx, &restore, "InvalidThrottle", err.Error())
 	}
 
+	// Validate that the Backup exists and is completed
+	backupNamespace := restoreBackupNamespace(&restore)
+
 	var backup backupv1alpha1.Backup
-	backupKey := client.ObjectKey{Name: restore.Spec.BackupName, Namespace: targetNamespace}
+	backupKey := client.ObjectKey{Name: restore.Spec.BackupName, Namespace: backupNamespace}
 	if err := r.Get(ctx, backupKey, &backup); err != nil {
 		log.Error(err, "unable to fetch Backup", "backupName", restore.Spec.BackupName)
+		base := restore.DeepCopy()
 		restore.Status.Phase = backupv1alpha1.RestorePhaseFailed
-		restore.Status.Conditions = []metav1.Condition{
-			{
-				Type:               "Ready",
-				Status:             metav1.ConditionFalse,
-				Reason:             "BackupNotFound",
-				Message:            fmt.Sprintf("Backup %s not found", restore.Spec.BackupName),
-				LastTransitionTime: metav1.Now(),
-			},
-		}
+		setConditions(&restore.Status.Conditions, restore.Generation, "BackupNotFound",
+			fmt.Sprintf("Backup %s not found", restore.Spec.BackupName),
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
 		r.Recorder.Eventf(
 			&restore,
 			corev1.EventTypeWarning,
 			"BackupNotFound",
 			"Backup %s not found in namespace %s",
 			restore.Spec.BackupName,
-			targetNamespace,
+			backupNamespace,
 		)
-		
-		r.Status().Update(ctx, &restore)
+
+		if statusErr := patchRestoreStatus(ctx, r.Client, &restore, base); statusErr != nil {
+			log.Error(statusErr, "unable to update Restore status to Failed")
+		}
 		return ctrl.Result{}, err
 	}
 
 	// Verify backup is completed
 	if backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
 		log.Info("Backup not completed yet", "backupPhase", backup.Status.Phase)
+		base := restore.DeepCopy()
 		restore.Status.Phase = backupv1alpha1.RestorePhaseFailed
-		restore.Status.Conditions = []metav1.Condition{
-			{
-				Type:               "Ready",
-				Status:             metav1.ConditionFalse,
-				Reason:             "BackupNotReady",
-				Message:            fmt.Sprintf("Backup is in phase %s, not Completed", backup.Status.Phase),
-				LastTransitionTime: metav1.Now(),
-			},
-		}
+		setConditions(&restore.Status.Conditions, restore.Generation, "BackupNotReady",
+			fmt.Sprintf("Backup is in phase %s, not Completed", backup.Status.Phase),
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
 		r.Recorder.Eventf(
 			&restore,
 			corev1.EventTypeWarning,
@@ -132,170 +170,328 @@ func (r *RestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			backup.Name,
 			backup.Status.Phase,
 		)
-		
-		r.Status().Update(ctx, &restore)
+
+		if err := patchRestoreStatus(ctx, r.Client, &restore, base); err != nil {
+			log.Error(err, "unable to update Restore status to Failed")
+			return ctrl.Result{}, err
+		}
 		return ctrl.Result{}, nil
 	}
 
+	if format := formatOf(&backup); restore.Status.Attempts == 0 && format.NeedsMoverTools() && r.MoverToolsImage == "" {
+		return ctrl.Result{}, r.rejectRestore(ctx, &restore, "MoverToolsUnavailable", moverToolsUnavailable(format))
+	}
+
+	// The restore Job runs beside the PVC it writes to, after namespace mapping
+	// and name transforms
+	target, err := resolveRestoreTarget(&restore, &backup)
+	if err != nil {
+		return ctrl.Result{}, r.rejectRestore(ctx, &restore, "InvalidTarget", err.Error())
+	}
+
+	// A migration restore creates its target PVC before the restore Job mounts it
+	if restore.Spec.Migration != nil && restore.Status.ProvisionedPVC == "" {
+		if rejected, err := r.provisionTargetPVC(ctx, &restore, &backup, target); rejected || err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Set phase to Running if not already set
 	if restore.Status.Phase == "" {
+		base := restore.DeepCopy()
 		restore.Status.Phase = backupv1alpha1.RestorePhaseRunning
-		now := metav1.Now()
+		now := metav1.NewTime(r.Clock.Now())
 		restore.Status.StartTime = &now
-		restore.Status.Conditions = []metav1.Condition{
-			{
-				Type:               "Progressing",
-				Status:             metav1.ConditionTrue,
-				Reason:             "RestoreStarted",
-				Message:            "Restore job is being created",
-				LastTransitionTime: metav1.Now(),
-			},
-		}
+		restore.Status.TargetNamespace = target.Namespace
+		restore.Status.TargetPVC = target.PVC
+		setConditions(&restore.Status.Conditions, restore.Generation, "RestoreStarted", "Restore job is being created",
+			metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionFalse)
 		r.Recorder.Event(
 			&restore,
 			corev1.EventTypeNormal,
 			"RestoreStarted",
 			"Restore job creation started",
 		)
-		if err := r.Status().Update(ctx, &restore); err != nil {
+		if err := patchRestoreStatus(ctx, r.Client, &restore, base); err != nil {
 			log.Error(err, "unable to update Restore status to Running")
 			return ctrl.Result{}, err
 		}
 		log.Info("Updated Restore status to Running")
 	}
 
-	// Check if Job already exists
+	// Check if the Job of the current attempt already exists
+	attempt := max(restore.Status.Attempts, 1)
 	var existingJob batchv1.Job
-	jobName := restore.Name + "-job"
-	err := r.Get(ctx, client.ObjectKey{Name: jobName, Namespace: restore.Namespace}, &existingJob)
-	if err == nil {
-		// Job exists, check its status
-		if existingJob.Status.Succeeded > 0 {
-			log.Info("Restore Job completed successfully")
-			restore.Status.Phase = backupv1alpha1.RestorePhaseCompleted
-			now := metav1.Now()
-			restore.Status.CompletionTime = &now
-			restore.Status.Conditions = []metav1.Condition{
-				{
-					Type:               "Ready",
-					Status:             metav1.ConditionTrue,
-					Reason:             "RestoreCompleted",
-					Message:            fmt.Sprintf("Successfully restored from backup %s", backup.Name),
-					LastTransitionTime: metav1.Now(),
-				},
-			}
-			if err := r.Status().Update(ctx, &restore); err != nil {
+	jobName := attemptJobName(restoreJobName(&restore), attempt)
+	jobKey := client.ObjectKey{Name: jobName, Namespace: target.Namespace}
+	err = r.Get(ctx, jobKey, &existingJob)
+	if apierrors.IsNotFound(err) {
+		lost, err := attemptJobLost(ctx, r.apiReader(), restore.Status.Conditions, restore.Status.Attempts, attempt, jobKey)
+		if err != nil {
+			log.Error(err, "unable to fetch Job")
+			return ctrl.Result{}, err
+		}
+		if !lost {
+			// Job doesn't exist, create it
+			return r.startRestoreAttempt(ctx, &restore, &backup, target, attempt, "")
+		}
+		failure := lostJobFailure(jobName, attempt)
+		log.Info("Restore Job is gone", "jobName", jobName, "attempt", attempt)
+		if attempt < maxAttempts(restore.Spec.Retry) {
+			return r.startRestoreAttempt(ctx, &restore, &backup, target, attempt+1, failure)
+		}
+		return ctrl.Result{}, r.rejectRestore(ctx, &restore, "JobLost",
+			fmt.Sprintf("Restore failed after %d attempt(s): %s", attempt, failure))
+	}
+	if err != nil {
+		log.Error(err, "unable to fetch Job")
+		return ctrl.Result{}, err
+	}
+
+	// The Job is owned by the Restore, so every change to its status triggers a
+	// reconcile; only the mover's progress is sampled while it runs
+	state := evaluateAttempt(&existingJob, attempt, restore.Spec.Retry, r.Clock.Now())
+	switch state.Outcome {
+	case attemptSucceeded:
+		log.Info("Restore Job completed successfully")
+
+		// The workload is switched over only once the data is in place
+		swapped := ""
+		if restore.Spec.Migration != nil && restore.Spec.Migration.SwapWorkload != nil {
+			ref := restore.Spec.Migration.SwapWorkload
+			message, err := r.swapWorkloadVolume(ctx, &restore, &backup, target)
+			if err != nil {
+				log.Error(err, "unable to swap workload volume")
 				return ctrl.Result{}, err
 			}
+			if message != "" {
+				return ctrl.Result{}, r.rejectRestore(ctx, &restore, "WorkloadSwapFailed",
+					fmt.Sprintf("Data was restored into PVC %s, but the workload was not changed: %s", target.PVC, message))
+			}
+			swapped = ref.Kind + "/" + ref.Name
+		}
+
+		transforms := r.transformOutputs(ctx, &restore, &existingJob)
+
+		base := restore.DeepCopy()
+		restore.Status.SwappedWorkload = swapped
+		restore.Status.Transforms = transforms
+		restore.Status.Phase = backupv1alpha1.RestorePhaseCompleted
+		now := metav1.NewTime(r.Clock.Now())
+		restore.Status.CompletionTime = &now
+		restore.Status.FailureReason = ""
+		var bytesTotal int64
+		if backup.Status.DataSize != nil {
+			bytesTotal = backup.Status.DataSize.Value()
+			restore.Status.RestoredDataSize = backup.Status.DataSize.String()
+		}
+		restore.Status.Progress = completedProgress(restore.Status.Progress, bytesTotal, backup.Status.FileCount, now.Time)
+		setConditions(&restore.Status.Conditions, restore.Generation, "RestoreCompleted",
+			fmt.Sprintf("Successfully restored from backup %s", backup.Name),
+			metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+		if err := patchRestoreStatus(ctx, r.Client, &restore, base); err != nil {
+			log.Error(err, "unable to update Restore status to Completed")
+			return ctrl.Result{}, err
+		}
+		if swapped != "" {
 			r.Recorder.Eventf(
 				&restore,
 				corev1.EventTypeNormal,
-				"RestoreCompleted",
-				"Restore completed successfully from backup %s",
-				backup.Name,
-			)			
-			return ctrl.Result{}, nil
-		} else if existingJob.Status.Failed > 0 {
-			log.Info("Restore Job failed")
-			restore.Status.Phase = backupv1alpha1.RestorePhaseFailed
-			r.Recorder.Event(
-				&restore,
-				corev1.EventTypeWarning,
-				"RestoreFailed",
-				"Restore job failed, check job logs",
+				"WorkloadSwapped",
+				"%s now uses PVC %s",
+				swapped,
+				target.PVC,
 			)
-			now := metav1.Now()
-			restore.Status.CompletionTime = &now
-			restore.Status.Conditions = []metav1.Condition{
-				{
-					Type:               "Ready",
-					Status:             metav1.ConditionFalse,
-					Reason:             "RestoreFailed",
-					Message:            "Restore job failed - check job logs for details",
-					LastTransitionTime: metav1.Now(),
-				},
+		}
+		r.Recorder.Eventf(
+			&restore,
+			corev1.EventTypeNormal,
+			"RestoreCompleted",
+			"Restore completed successfully from backup %s",
+			backup.Name,
+		)
+		return ctrl.Result{}, nil
+
+	case attemptRetryPending:
+		log.Info("Restore Job failed, retrying later", "attempt", attempt, "reason", state.Failed.Reason, "retryAfter", state.RetryAfter)
+		// Record the failure once per attempt; later reconciles during the backoff only requeue
+		if progressing := meta.FindStatusCondition(restore.Status.Conditions, backupv1alpha1.ConditionProgressing); progressing == nil ||
+			progressing.Reason != "RetryPending" {
+			base := restore.DeepCopy()
+			restore.Status.FailureReason = describeJobFailure(ctx, r.Pods, &existingJob, state.Failed, r.Config.Get().FailureLogLines())
+			setCondition(&restore.Status.Conditions, restore.Generation, backupv1alpha1.ConditionProgressing,
+				metav1.ConditionTrue, "RetryPending", fmt.Sprintf("Attempt %d failed, retrying in %s", attempt, state.RetryAfter.Round(time.Second)))
+			setCondition(&restore.Status.Conditions, restore.Generation, backupv1alpha1.ConditionDegraded,
+				metav1.ConditionTrue, "AttemptFailed", restore.Status.FailureReason)
+			if err := patchRestoreStatus(ctx, r.Client, &restore, base); err != nil {
+				log.Error(err, "unable to record failed Restore attempt")
+				return ctrl.Result{}, err
 			}
-			r.Recorder.Event(
-				&restore,
-				corev1.EventTypeWarning,
-				"RestoreFailed",
-				"Restore job failed, check job logs",
-			)
-			if err := r.Status().Update(ctx, &restore); err != nil {
+		}
+		return ctrl.Result{RequeueAfter: state.RetryAfter}, nil
+
+	case attemptRetry:
+		failure := describeJobFailure(ctx, r.Pods, &existingJob, state.Failed, r.Config.Get().FailureLogLines())
+		return r.startRestoreAttempt(ctx, &restore, &backup, target, attempt+1, failure)
+
+	case attemptFailed:
+		log.Info("Restore Job failed", "attempts", attempt, "reason", state.Failed.Reason)
+		base := restore.DeepCopy()
+		restore.Status.FailureReason = describeJobFailure(ctx, r.Pods, &existingJob, state.Failed, r.Config.Get().FailureLogLines())
+		restore.Status.Phase = backupv1alpha1.RestorePhaseFailed
+		now := metav1.NewTime(r.Clock.Now())
+		restore.Status.CompletionTime = &now
+		message := fmt.Sprintf("Restore failed after %d attempt(s): %s", attempt, restore.Status.FailureReason)
+		setConditions(&restore.Status.Conditions, restore.Generation, "RestoreFailed", message,
+			metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+		if err := patchRestoreStatus(ctx, r.Client, &restore, base); err != nil {
+			log.Error(err, "unable to update Restore status to Failed")
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Event(
+			&restore,
+			corev1.EventTypeWarning,
+			"RestoreFailed",
+			eventMessage(message),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Restore Job still running", "jobName", jobName)
+	// Movers report progress to a ConfigMap whose updates trigger a reconcile
+	if counters := readProgress(ctx, r.Client, &existingJob); counters != nil {
+		progress := newProgress(counters, existingJob.Status.StartTime, r.Clock.Now())
+		if progressChanged(restore.Status.Progress, progress) {
+			base := restore.DeepCopy()
+			restore.Status.Progress = progress
+			if err := patchRestoreStatus(ctx, r.Client, &restore, base); err != nil {
+				log.Error(err, "unable to update Restore progress")
 				return ctrl.Result{}, err
 			}
-			return ctrl.Result{}, nil
 		}
-		// Job still running, requeue to check later
-		log.Info("Restore Job still running")
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
+	return ctrl.Result{}, nil
+}
 
-	if !apierrors.IsNotFound(err) {
-		log.Error(err, "unable to fetch Job")
+// startRestoreAttempt creates the Job for the given attempt and records the
+// attempt in status. failure describes the previous attempt, if it failed.
+func (r *RestoreReconciler) startRestoreAttempt(ctx context.Context, restore *backupv1alpha1.Restore, backup *backupv1alpha1.Backup,
+	target restoreTarget, attempt int32, failure string) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	// The Job mounts the storage PVC, so the location is looked up where it runs
+	storagePVC, err := storagePVCName(ctx, r.Client, r.Config.Get(), target.Namespace, backup.Spec.StorageLocation)
+	if err != nil {
+		log.Error(err, "unable to resolve storage location")
+		base := restore.DeepCopy()
+		setCondition(&restore.Status.Conditions, restore.Generation, backupv1alpha1.ConditionDegraded,
+			metav1.ConditionTrue, "StorageLocationUnavailable", err.Error())
+		if statusErr := patchRestoreStatus(ctx, r.Client, restore, base); statusErr != nil {
+			log.Error(statusErr, "unable to record unavailable storage location")
+		}
 		return ctrl.Result{}, err
 	}
 
-	// Job doesn't exist, create it
-	job := r.createRestoreJob(&restore, &backup)
+	job := r.createRestoreJob(restore, backup, target, attempt, storagePVC)
 	if err := r.Create(ctx, job); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
 			log.Error(err, "unable to create Restore Job")
+			base := restore.DeepCopy()
 			restore.Status.Phase = backupv1alpha1.RestorePhaseFailed
-			r.Status().Update(ctx, &restore)
+			setConditions(&restore.Status.Conditions, restore.Generation, "JobCreateFailed",
+				fmt.Sprintf("Failed to create restore Job: %v", err),
+				metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionTrue)
+			if statusErr := patchRestoreStatus(ctx, r.Client, restore, base); statusErr != nil {
+				log.Error(statusErr, "unable to update Restore status to Failed")
+			}
 			return ctrl.Result{}, err
 		}
 		log.Info("Job already exists (race condition), continuing")
 	}
+	if progressSeconds(r.Config.Get(), r.MoverToolsImage) > 0 {
+		// Without the ConfigMap the restore still runs, only without progress
+		if err := createProgressConfigMap(ctx, r.Client, r.apiReader(), job, restoreMover, client.ObjectKeyFromObject(restore)); err != nil {
+			log.Error(err, "unable to create the progress ConfigMap", "jobName", job.Name)
+		}
+	}
+
+	if restore.Status.Attempts != attempt {
+		base := restore.DeepCopy()
+		restore.Status.Attempts = attempt
+		restore.Status.Progress = nil
+		if failure != "" {
+			restore.Status.FailureReason = failure
+			setCondition(&restore.Status.Conditions, restore.Generation, backupv1alpha1.ConditionDegraded,
+				metav1.ConditionTrue, "AttemptFailed", failure)
+		}
+		setCondition(&restore.Status.Conditions, restore.Generation, backupv1alpha1.ConditionProgressing,
+			metav1.ConditionTrue, "JobRunning", fmt.Sprintf("Running attempt %d of %d", attempt, maxAttempts(restore.Spec.Retry)))
+		if err := patchRestoreStatus(ctx, r.Client, restore, base); err != nil {
+			log.Error(err, "unable to record Restore attempt")
+			return ctrl.Result{}, err
+		}
+	}
 
-	log.Info("Created Restore Job", "jobName", job.Name)
-	r.Recorder.Eventf(
-		&restore,
-		corev1.EventTypeNormal,
-		"RestoreJobCreated",
-		"Restore job %s created",
-		job.Name,
-	)
-	return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	log.Info("Created Restore Job", "jobName", job.Name, "attempt", attempt)
+	if attempt > 1 {
+		r.Recorder.Event(
+			restore,
+			corev1.EventTypeWarning,
+			"JobRetried",
+			eventMessage(fmt.Sprintf(
+				"Attempt %d failed (%s), starting attempt %d of %d",
+				attempt-1,
+				restore.Status.FailureReason,
+				attempt,
+				maxAttempts(restore.Spec.Retry),
+			)),
+		)
+	} else {
+		r.Recorder.Eventf(
+			restore,
+			corev1.EventTypeNormal,
+			"RestoreJobCreated",
+			"Restore job %s created",
+			job.Name,
+		)
+	}
+	return ctrl.Result{}, nil
 }
 
-func (r *RestoreReconciler) createRestoreJob(restore *backupv1alpha1.Restore, backup *backupv1alpha1.Backup) *batchv1.Job {
-	jobName := restore.Name + "-job"
+// createRestoreJob builds the Job of an attempt. It extracts the backup's
+// archive from storagePVC into the target PVC, in the target's namespace.
+func (r *RestoreReconciler) createRestoreJob(restore *backupv1alpha1.Restore, backup *backupv1alpha1.Backup, target restoreTarget,
+	attempt int32, storagePVC string) *batchv1.Job {
+	cfg := r.Config.Get()
+	jobName := attemptJobName(restoreJobName(restore), attempt)
+	script := restoreScript(backup, throttleOf(restore.Spec.Throttle),
+		reportCommand(target.Namespace, jobName), progressSeconds(cfg, r.MoverToolsImage))
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      jobName,
-			Namespace: restore.Namespace,
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion: restore.APIVersion,
-					Kind:       restore.Kind,
-					Name:       restore.Name,
-					UID:        restore.UID,
-					Controller: ptr.To(true),
-				},
+			Namespace: target.Namespace,
+			Labels:    moverLabels(restoreMover, storagePVC),
+			Annotations: map[string]string{
+				backupv1alpha1.RestoreAnnotation:          restore.Name,
+				backupv1alpha1.RestoreNamespaceAnnotation: restore.Namespace,
 			},
 		},
 		Spec: batchv1.JobSpec{
 			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: moverLabels(restoreMover, storagePVC),
+				},
 				Spec: corev1.PodSpec{
 					RestartPolicy: corev1.RestartPolicyNever,
 					InitContainers: []corev1.Container{
 						{
 							Name:  "prepare-restore",
-							Image: "busybox:latest",
+							Image: cfg.MoverImage,
 							Command: []string{
 								"sh",
 								"-c",
-								"echo 'Starting restore operation...' && " +
-									"if [ -f /backup-source/" + backup.Name + ".tar.gz ]; then " +
-									"  tar -xzf /backup-source/" + backup.Name + ".tar.gz -C /restore-target && " +
-									"  echo 'Restore completed successfully' && " +
-									"  echo 'Restored files:' && " +
-									"  ls -lh /restore-target/; " +
-									"else " +
-									"  echo 'ERROR: Backup file not found at /backup-source/" + backup.Name + ".tar.gz' && exit 1; " +
-									"fi",
+								script,
 							},
 							VolumeMounts: []corev1.VolumeMount{
 								{
@@ -313,19 +509,11 @@ func (r *RestoreReconciler) createRestoreJob(restore *backupv1alpha1.Restore, ba
 					Containers: []corev1.Container{
 						{
 							Name:  "restore",
-							Image: "busybox:latest",
+							Image: cfg.MoverImage,
 							Command: []string{
 								"sh",
 								"-c",
-								"echo 'Starting restore operation...' && " +
-									"if [ -f /backup-source/" + backup.Name + ".tar.gz ]; then " +
-									"  tar -xzf /backup-source/" + backup.Name + ".tar.gz -C /restore-target && " +
-									"  echo 'Restore completed successfully' && " +
-									"  echo 'Restored files:' && " +
-									"  ls -lh /restore-target/; " +
-									"else " +
-									"  echo 'ERROR: Backup file not found at /backup-source/" + backup.Name + ".tar.gz' && exit 1; " +
-									"fi",
+								script,
 							},
 							VolumeMounts: []corev1.VolumeMount{
 								{
@@ -345,7 +533,7 @@ func (r *RestoreReconciler) createRestoreJob(restore *backupv1alpha1.Restore, ba
 							Name: "backup-source",
 							VolumeSource: corev1.VolumeSource{
 								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-									ClaimName: "backup-storage",
+									ClaimName: storagePVC,
 									ReadOnly:  true,
 								},
 							},
@@ -354,7 +542,7 @@ func (r *RestoreReconciler) createRestoreJob(restore *backupv1alpha1.Restore, ba
 							Name: "restore-target",
 							VolumeSource: corev1.VolumeSource{
 								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-									ClaimName: restore.Spec.TargetPVC,
+									ClaimName: target.PVC,
 								},
 							},
 						},
@@ -363,14 +551,107 @@ func (r *RestoreReconciler) createRestoreJob(restore *backupv1alpha1.Restore, ba
 			},
 		},
 	}
+
+	// Transforms run after the extraction in prepare-restore, so the restore
+	// container must not extract the archive again over their changes
+	if len(restore.Spec.Transforms) > 0 {
+		podSpec := &job.Spec.Template.Spec
+		podSpec.InitContainers = append(podSpec.InitContainers, transformContainers(restore.Spec.Transforms, cfg.MoverImage)...)
+		podSpec.Containers[0].Command = []string{"sh", "-c", transformedRestoreScript(len(restore.Spec.Transforms))}
+	}
+
+	if formatOf(backup).NeedsMoverTools() || progressSeconds(cfg, r.MoverToolsImage) > 0 {
+		addMoverTools(&job.Spec.Template.Spec, r.MoverToolsImage)
+	}
+
+	// Owner references cannot cross namespaces; a Job elsewhere is removed by
+	// its TTL and found through its annotations
+	if target.Namespace == restore.Namespace {
+		job.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: restore.APIVersion,
+				Kind:       restore.Kind,
+				Name:       restore.Name,
+				UID:        restore.UID,
+				Controller: ptr.To(true),
+			},
+		}
+	}
+
+	applyJobTemplates(&job.Spec.Template.Spec, cfg.DefaultJobTemplate, restore.Spec.JobTemplate)
+	applyJobLimits(job, restore.Spec.Timeout, cfg.JobTTL())
+	useFallbackTerminationMessages(&job.Spec.Template.Spec)
+	return job
+}
+
+// restoreScript extracts the backup's archive into the target volume while
+// reporting progress against the totals recorded by the backup every
+// progressEvery seconds, see withProgress
+func restoreScript(backup *backupv1alpha1.Backup, throttle moverThrottle, report string, progressEvery int) string {
+	format := formatOf(backup)
+	archivePath := "/backup-source/" + archive.Name(backup)
+
+	var totals string
+	if backup.Status.DataSize != nil {
+		totals += " bytesTotal=" + strconv.FormatInt(backup.Status.DataSize.Value(), 10)
+	}
+	if backup.Status.FileCount > 0 {
+		totals += " filesTotal=" + strconv.FormatInt(backup.Status.FileCount, 10)
+	}
+
+	return throttle.function() +
+		"echo 'Starting restore operation...' && " +
+		"if [ -f " + archivePath + " ]; then " +
+		withProgress(
+			format.extractCommand(archivePath, "/restore-target", moverFilesFile, throttle),
+			"echo \"progress bytesDone="+diskUsageOf("/restore-target")+" filesDone="+filesDone()+totals+"\"",
+			report, progressEvery,
+		) + " && " +
+		"  echo 'Restore completed successfully' && " +
+		"  echo 'Restored files:' && " +
+		"  ls -lh /restore-target/; " +
+		"else " +
+		"  echo 'ERROR: Backup file not found at " + archivePath + "' && exit 1; " +
+		"fi"
+}
+
+// transformedRestoreScript reports a restore whose archive was extracted and
+// transformed by the init containers
+func transformedRestoreScript(transforms int) string {
+	return "echo 'Restore completed successfully, " + strconv.Itoa(transforms) + " transform(s) applied' && " +
+		"echo 'Restored files:' && " +
+		"ls -lh /restore-target/"
+}
+
+// apiReader returns the reader for lookups that must not be answered from the cache
+func (r *RestoreReconciler) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Recorder = mgr.GetEventRecorderFor("restore-operator")
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&backupv1alpha1.Restore{}).
 		Owns(&batchv1.Job{}).
+		Owns(&backupv1alpha1.Restore{}). // Member Restores of a group restore
+		// Restore Jobs in other namespaces cannot be owned by their Restore
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.restoreForJob)).
+		// Movers report progress to ConfigMaps, see createProgressConfigMap
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(progressOwner),
+			builder.WithPredicates(reportsOn(restoreMover))).
 		Named("restore").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.Get().MaxConcurrentReconciles[config.RestoreController],
+		}).
 		Complete(r)
 }
@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// restoreTarget is the namespace and PVC a Restore writes into, after its
+// namespace mapping and name transforms
+type restoreTarget struct {
+	Namespace string
+	PVC       string
+}
+
+// resolveRestoreTarget works out where a Restore of backup writes to. The
+// result only depends on the specs, so every reconcile arrives at the same target.
+func resolveRestoreTarget(restore *backupv1alpha1.Restore, backup *backupv1alpha1.Backup) (restoreTarget, error) {
+	namespace := restore.Namespace
+	if restore.Spec.TargetNamespace != "" {
+		namespace = restore.Spec.TargetNamespace
+	}
+	if mapped, ok := restore.Spec.NamespaceMapping[backup.Namespace]; ok {
+		if errs := validation.IsDNS1123Label(mapped); len(errs) > 0 {
+			return restoreTarget{}, fmt.Errorf("namespaceMapping maps %s to invalid namespace %q: %s", backup.Namespace, mapped, strings.Join(errs, ", "))
+		}
+		namespace = mapped
+	}
+	pvc, err := transformName(restore.Spec.TargetPVC, restore.Spec.NameTransforms)
+	if err != nil {
+		return restoreTarget{}, err
+	}
+	return restoreTarget{Namespace: namespace, PVC: pvc}, nil
+}
+
+// restoreBackupNamespace returns the namespace a Restore looks up its Backups in
+func restoreBackupNamespace(restore *backupv1alpha1.Restore) string {
+	switch {
+	case restore.Spec.BackupNamespace != "":
+		return restore.Spec.BackupNamespace
+	case restore.Spec.TargetNamespace != "":
+		return restore.Spec.TargetNamespace
+	}
+	return restore.Namespace
+}
+
+// transformName applies name transforms in order and checks that the result
+// is still a valid PVC name
+func transformName(name string, transforms []backupv1alpha1.NameTransform) (string, error) {
+	result := name
+	for i, transform := range transforms {
+		set := 0
+		for _, present := range []bool{transform.Prefix != "", transform.Suffix != "", transform.Regex != nil} {
+			if present {
+				set++
+			}
+		}
+		if set != 1 {
+			return "", fmt.Errorf("name transform %d must set exactly one of prefix, suffix and regex", i+1)
+		}
+
+		switch {
+		case transform.Prefix != "":
+			result = transform.Prefix + result
+		case transform.Suffix != "":
+			result += transform.Suffix
+		default:
+			pattern, err := regexp.Compile(transform.Regex.Pattern)
+			if err != nil {
+				return "", fmt.Errorf("name transform %d: %w", i+1, err)
+			}
+			result = pattern.ReplaceAllString(result, transform.Regex.Replacement)
+		}
+	}
+	if errs := validation.IsDNS1123Subdomain(result); len(errs) > 0 {
+		return "", fmt.Errorf("name transforms turn %q into invalid PVC name %q: %s", name, result, strings.Join(errs, ", "))
+	}
+	return result, nil
+}
+
+// restoreJobNamespace returns the namespace a Restore's Jobs run in: the
+// namespace it restores into, once that is recorded
+func restoreJobNamespace(restore *backupv1alpha1.Restore) string {
+	if restore.Status.TargetNamespace != "" {
+		return restore.Status.TargetNamespace
+	}
+	return restore.Namespace
+}
+
+// restoreForJob maps a restore Job in another namespace to its Restore. Jobs
+// in the Restore's own namespace are owned by it and need no mapping.
+func (r *RestoreReconciler) restoreForJob(_ context.Context, obj client.Object) []reconcile.Request {
+	annotations := obj.GetAnnotations()
+	name, namespace := annotations[backupv1alpha1.RestoreAnnotation], annotations[backupv1alpha1.RestoreNamespaceAnnotation]
+	if name == "" || namespace == "" || namespace == obj.GetNamespace() {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}}
+}
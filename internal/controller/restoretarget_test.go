@@ -0,0 +1,216 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestTransformName(t *testing.T) {
+	tests := []struct {
+		name       string
+		transforms []backupv1alpha1.NameTransform
+		want       string
+		wantErr    bool
+	}{
+		{name: "no transforms", want: "prod-data"},
+		{
+			name: "prefix and suffix",
+			transforms: []backupv1alpha1.NameTransform{
+				{Prefix: "staging-"},
+				{Suffix: "-copy"},
+			},
+			want: "staging-prod-data-copy",
+		},
+		{
+			name: "regex",
+			transforms: []backupv1alpha1.NameTransform{
+				{Regex: &backupv1alpha1.RegexTransform{Pattern: "^prod-(.*)$", Replacement: "staging-$1"}},
+			},
+			want: "staging-data",
+		},
+		{
+			name:       "more than one rewrite in a transform",
+			transforms: []backupv1alpha1.NameTransform{{Prefix: "a-", Suffix: "-b"}},
+			wantErr:    true,
+		},
+		{
+			name:       "empty transform",
+			transforms: []backupv1alpha1.NameTransform{{}},
+			wantErr:    true,
+		},
+		{
+			name: "invalid pattern",
+			transforms: []backupv1alpha1.NameTransform{
+				{Regex: &backupv1alpha1.RegexTransform{Pattern: "("}},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "invalid result",
+			transforms: []backupv1alpha1.NameTransform{{Suffix: "_Copy"}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transformName("prod-data", tt.transforms)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("transformName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("transformName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMappedRestore(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "to-staging", Namespace: "production"}
+
+	recorder := record.NewFakeRecorder(100)
+	r := &RestoreReconciler{
+		Client: newTransitionClient(t,
+			&backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "production"},
+				Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+				Status:     backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+			},
+			&backupv1alpha1.Restore{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec: backupv1alpha1.RestoreSpec{
+					BackupName:       "nightly",
+					TargetPVC:        "data",
+					NamespaceMapping: map[string]string{"production": "staging"},
+					NameTransforms:   []backupv1alpha1.NameTransform{{Prefix: "staging-"}},
+				},
+			},
+		),
+		Recorder: recorder,
+		Clock:    clocktesting.NewFakeClock(transitionStart),
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+
+	var restore backupv1alpha1.Restore
+	if err := r.Get(ctx, key, &restore); err != nil {
+		t.Fatal(err)
+	}
+	if restore.Status.TargetNamespace != "staging" || restore.Status.TargetPVC != "staging-data" {
+		t.Errorf("status target = %s/%s, want staging/staging-data", restore.Status.TargetNamespace, restore.Status.TargetPVC)
+	}
+
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: "to-staging-job", Namespace: "staging"}, &job); err != nil {
+		t.Fatalf("restore Job not created in the mapped namespace: %v", err)
+	}
+	if len(job.OwnerReferences) != 0 {
+		t.Errorf("Job in another namespace has owner references %v", job.OwnerReferences)
+	}
+	if claim := job.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName; claim != "staging-data" {
+		t.Errorf("Job mounts PVC %s, want staging-data", claim)
+	}
+	if requests := r.restoreForJob(ctx, &job); len(requests) != 1 || requests[0].NamespacedName != key {
+		t.Errorf("restoreForJob() = %v, want %v", requests, key)
+	}
+	expectEvents(t, recorder, "RestoreStarted", "RestoreJobCreated")
+}
+
+func TestRestoreTargetNamespace(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            backupv1alpha1.RestoreSpec
+		backupNamespace string
+		wantNamespace   string
+	}{
+		{
+			name:            "targetNamespace holds the PVC and the Backup",
+			spec:            backupv1alpha1.RestoreSpec{TargetNamespace: "app"},
+			backupNamespace: "app",
+			wantNamespace:   "app",
+		},
+		{
+			name:            "backupNamespace looks the Backup up elsewhere",
+			spec:            backupv1alpha1.RestoreSpec{TargetNamespace: "staging", BackupNamespace: "production"},
+			backupNamespace: "production",
+			wantNamespace:   "staging",
+		},
+		{
+			name: "namespaceMapping takes precedence",
+			spec: backupv1alpha1.RestoreSpec{
+				TargetNamespace:  "app",
+				BackupNamespace:  "production",
+				NamespaceMapping: map[string]string{"production": "staging"},
+			},
+			backupNamespace: "production",
+			wantNamespace:   "staging",
+		},
+		{
+			name:            "defaults to the Restore's namespace",
+			backupNamespace: "ops",
+			wantNamespace:   "ops",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "restore", Namespace: "ops"}
+			spec := tt.spec
+			spec.BackupName, spec.TargetPVC = "nightly", "data"
+
+			r := &RestoreReconciler{
+				Client: newTransitionClient(t,
+					&backupv1alpha1.Backup{
+						ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: tt.backupNamespace},
+						Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+						Status:     backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+					},
+					&backupv1alpha1.Restore{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}, Spec: spec},
+				),
+				Recorder: record.NewFakeRecorder(100),
+				Clock:    clocktesting.NewFakeClock(transitionStart),
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			expectNoRequeue(t, result, err)
+
+			var restore backupv1alpha1.Restore
+			if err := r.Get(ctx, key, &restore); err != nil {
+				t.Fatal(err)
+			}
+			if restore.Status.TargetNamespace != tt.wantNamespace {
+				t.Errorf("status.targetNamespace = %q, want %q", restore.Status.TargetNamespace, tt.wantNamespace)
+			}
+			var job batchv1.Job
+			if err := r.Get(ctx, types.NamespacedName{Name: "restore-job", Namespace: tt.wantNamespace}, &job); err != nil {
+				t.Fatalf("restore Job not created in %s: %v", tt.wantNamespace, err)
+			}
+		})
+	}
+}
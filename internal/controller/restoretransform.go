@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+const (
+	// maxTransformOutputBytes bounds the log tail of each transform kept in status
+	maxTransformOutputBytes = 2048
+
+	// transformOutputLines is how many log lines of each transform are read.
+	// Reading them is capped at transformOutputReadBytes.
+	transformOutputLines     int64 = 100
+	transformOutputReadBytes int64 = 64 << 10
+)
+
+// transformContainerName names the container of a restore transform
+func transformContainerName(name string) string {
+	return "transform-" + name
+}
+
+// transformContainers builds one init container per transform. Init
+// containers run one at a time, so the transforms run in order, after the
+// archive is extracted and before the restore container reports completion.
+func transformContainers(transforms []backupv1alpha1.RestoreTransform, moverImage string) []corev1.Container {
+	containers := make([]corev1.Container, 0, len(transforms))
+	for _, transform := range transforms {
+		image := transform.Image
+		if image == "" {
+			image = moverImage
+		}
+		containers = append(containers, corev1.Container{
+			Name:    transformContainerName(transform.Name),
+			Image:   image,
+			Command: transform.Command,
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "restore-target",
+					MountPath: "/restore-target",
+				},
+			},
+		})
+	}
+	return containers
+}
+
+// transformOutputs reads the end of each transform's log from the succeeded
+// pod of job. Output that cannot be read is left empty; it does not fail the restore.
+func (r *RestoreReconciler) transformOutputs(ctx context.Context, restore *backupv1alpha1.Restore, job *batchv1.Job) []backupv1alpha1.TransformStatus {
+	if len(restore.Spec.Transforms) == 0 {
+		return nil
+	}
+
+	statuses := make([]backupv1alpha1.TransformStatus, 0, len(restore.Spec.Transforms))
+	for _, transform := range restore.Spec.Transforms {
+		status := backupv1alpha1.TransformStatus{Name: transform.Name}
+		if r.Pods != nil {
+			logs, err := jobLogs(ctx, r.Pods, job, transformContainerName(transform.Name),
+				transformOutputLines, transformOutputReadBytes)
+			if err != nil {
+				logf.FromContext(ctx).Error(err, "unable to read transform output", "transform", transform.Name)
+			} else {
+				status.Output = lastBytes(logs, maxTransformOutputBytes)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestRestoreTransforms(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "to-dev", Namespace: "default"}
+
+	clock := clocktesting.NewFakeClock(transitionStart)
+	recorder := record.NewFakeRecorder(100)
+	restorePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "to-dev-job-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{batchv1.JobNameLabel: "to-dev-job"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	clientset := fake.NewClientset(restorePod)
+	r := &RestoreReconciler{
+		Client: newTransitionClient(t,
+			&backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+				Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+				Status:     backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+			},
+			&backupv1alpha1.Restore{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec: backupv1alpha1.RestoreSpec{
+					BackupName: "nightly",
+					TargetPVC:  "data-dev",
+					Transforms: []backupv1alpha1.RestoreTransform{
+						{Name: "scrub", Image: "postgres:16", Command: []string{"/scripts/scrub.sh"}},
+						{Name: "reset-passwords", Command: []string{"sh", "-c", "rm -f /restore-target/secrets/*"}},
+					},
+				},
+			},
+		),
+		Recorder: recorder,
+		Clock:    clock,
+		Pods:     clientset.CoreV1(),
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: "to-dev-job", Namespace: "default"}, &job); err != nil {
+		t.Fatal(err)
+	}
+	podSpec := job.Spec.Template.Spec
+	var names []string
+	for _, container := range podSpec.InitContainers {
+		names = append(names, container.Name)
+	}
+	if got := strings.Join(names, ","); got != "prepare-restore,transform-scrub,transform-reset-passwords" {
+		t.Errorf("init containers = %s, want extraction followed by the transforms in order", got)
+	}
+	if image := podSpec.InitContainers[2].Image; image != r.Config.Get().MoverImage {
+		t.Errorf("transform image = %s, want the mover image", image)
+	}
+	if command := strings.Join(podSpec.Containers[0].Command, " "); strings.Contains(command, "tar ") {
+		t.Errorf("restore container extracts the archive again over the transforms: %s", command)
+	}
+	expectEvents(t, recorder, "RestoreStarted", "RestoreJobCreated")
+
+	clock.Step(time.Minute)
+	setJobCondition(t, r.Client, "to-dev-job", batchv1.JobComplete, clock.Now())
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+
+	var restore backupv1alpha1.Restore
+	if err := r.Get(ctx, key, &restore); err != nil {
+		t.Fatal(err)
+	}
+	if restore.Status.Phase != backupv1alpha1.RestorePhaseCompleted {
+		t.Fatalf("phase = %s, want Completed", restore.Status.Phase)
+	}
+	if len(restore.Status.Transforms) != 2 || restore.Status.Transforms[0].Name != "scrub" ||
+		restore.Status.Transforms[0].Output != "fake logs" {
+		t.Errorf("status transforms = %+v", restore.Status.Transforms)
+	}
+	expectEvents(t, recorder, "RestoreCompleted")
+
+	// The API server bounds the logs read for the output
+	reads := 0
+	for _, action := range clientset.Actions() {
+		if action.GetSubresource() != "log" {
+			continue
+		}
+		reads++
+		options := action.(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+		if options.TailLines == nil || *options.TailLines != transformOutputLines ||
+			options.LimitBytes == nil || *options.LimitBytes != transformOutputReadBytes {
+			t.Errorf("log options of %s = %+v, want the tail bounded", options.Container, options)
+		}
+	}
+	if reads != 2 {
+		t.Errorf("read %d transform logs, want 2", reads)
+	}
+}
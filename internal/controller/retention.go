@@ -0,0 +1,249 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+// retentionBucket is one grandfather-father-son rule: it keeps the newest
+// backup of each of the last count distinct periods returned by period
+type retentionBucket struct {
+	name   string
+	count  int
+	period func(t time.Time, index int) string
+	last   string
+}
+
+// hasRetentionRules reports whether the policy selects anything at all.
+// An empty policy keeps every backup.
+func hasRetentionRules(retention *backupv1alpha1.RetentionPolicy) bool {
+	if retention == nil {
+		return false
+	}
+	return retention.KeepLast != nil ||
+		retention.KeepHourly != nil ||
+		retention.KeepDaily != nil ||
+		retention.KeepWeekly != nil ||
+		retention.KeepMonthly != nil ||
+		retention.KeepYearly != nil ||
+		retention.KeepWithin != nil
+}
+
+// policyRetention returns the retention of a policy, falling back to the
+// operator's default retention, or nil when neither is set
+func policyRetention(backupPolicy *backupv1alpha1.BackupPolicy, cfg *config.OperatorConfig) *backupv1alpha1.RetentionPolicy {
+	if backupPolicy.Spec.Retention != nil {
+		return backupPolicy.Spec.Retention
+	}
+	return cfg.DefaultRetention
+}
+
+// policyBackupsByOutcome splits the backups of a policy into completed ones
+// and failed or cancelled ones. Held and imported backups are left out
+// entirely: they are never deleted and do not use up a retention slot.
+func policyBackupsByOutcome(backups []backupv1alpha1.Backup, policyName string) (completed, failed []backupv1alpha1.Backup) {
+	for _, backup := range backups {
+		if backup.Spec.PolicyRef != policyName || isBackupHeld(&backup) || isImported(&backup) {
+			continue
+		}
+		switch backup.Status.Phase {
+		case backupv1alpha1.BackupPhaseCompleted:
+			completed = append(completed, backup)
+		case backupv1alpha1.BackupPhaseFailed, backupv1alpha1.BackupPhaseCancelled:
+			failed = append(failed, backup)
+		}
+	}
+	return completed, failed
+}
+
+// expiredFailedBackups returns the failed backups beyond the failed history
+// limit, newest first. The limit behaves exactly like keepLast over failed backups.
+func expiredFailedBackups(failed []backupv1alpha1.Backup, retention *backupv1alpha1.RetentionPolicy) []backupv1alpha1.Backup {
+	if retention.FailedBackupsHistoryLimit == nil {
+		return nil
+	}
+	_, expired := applyRetention(failed, &backupv1alpha1.RetentionPolicy{KeepLast: retention.FailedBackupsHistoryLimit})
+	return expired
+}
+
+// applyRetention splits backups into the ones the retention policy keeps and
+// the ones it removes, using the same bucket selection as restic's forget.
+// Backups are walked newest first; each rule keeps a backup whenever it opens
+// a period the rule has not seen yet, until the rule's count is used up.
+// The members of a consistency group take a single slot and are kept or
+// removed together, as decided for the newest of them.
+// KeepWithin is measured from the newest backup rather than the current time,
+// so a policy that stopped producing backups never expires its last ones.
+// Both returned slices are ordered newest first.
+func applyRetention(backups []backupv1alpha1.Backup, retention *backupv1alpha1.RetentionPolicy) (keep, remove []backupv1alpha1.Backup) {
+	sorted := make([]backupv1alpha1.Backup, len(backups))
+	copy(sorted, backups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return backupTime(&sorted[i]).After(backupTime(&sorted[j]))
+	})
+
+	if !hasRetentionRules(retention) {
+		return sorted, nil
+	}
+
+	buckets := retentionBuckets(retention)
+
+	var within time.Time
+	if retention.KeepWithin != nil && len(sorted) > 0 {
+		within = backupTime(&sorted[0]).Add(-retention.KeepWithin.Duration)
+	}
+
+	groups := map[string]bool{}
+	index := 0
+	for i := range sorted {
+		group := sorted[i].Spec.Group
+		keepBackup, seen := groups[group]
+		if group == "" || !seen {
+			t := backupTime(&sorted[i])
+			keepBackup = retention.KeepWithin != nil && !t.Before(within)
+
+			for j := range buckets {
+				b := &buckets[j]
+				if b.count <= 0 {
+					continue
+				}
+				if period := b.period(t, index); period != b.last {
+					b.last = period
+					b.count--
+					keepBackup = true
+				}
+			}
+			index++
+			if group != "" {
+				groups[group] = keepBackup
+			}
+		}
+
+		if keepBackup {
+			keep = append(keep, sorted[i])
+		} else {
+			remove = append(remove, sorted[i])
+		}
+	}
+
+	return keep, remove
+}
+
+// hasReplicaRetention reports whether any replica location has its own keepLast
+func hasReplicaRetention(replicas []backupv1alpha1.ReplicaLocation) bool {
+	for _, replica := range replicas {
+		if replica.KeepLast != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// applyReplicaRetention applies the keepLast of each replica location to the
+// completed copies it holds. It returns the backups some location still keeps
+// and, by backup, the locations whose copy should be removed.
+func applyReplicaRetention(backups []backupv1alpha1.Backup, replicas []backupv1alpha1.ReplicaLocation) (kept map[string]bool, prune map[string][]string) {
+	kept = map[string]bool{}
+	prune = map[string][]string{}
+	for _, replica := range replicas {
+		if replica.KeepLast == nil {
+			continue
+		}
+
+		var copies []backupv1alpha1.Backup
+		for _, backup := range backups {
+			if status := findReplica(backup.Status.Replicas, replica.StorageLocation); status != nil &&
+				status.Phase == backupv1alpha1.ReplicaPhaseCompleted {
+				copies = append(copies, backup)
+			}
+		}
+
+		keep, remove := applyRetention(copies, &backupv1alpha1.RetentionPolicy{KeepLast: replica.KeepLast})
+		for _, backup := range keep {
+			kept[backup.Name] = true
+		}
+		for _, backup := range remove {
+			prune[backup.Name] = append(prune[backup.Name], replica.StorageLocation)
+		}
+	}
+	return kept, prune
+}
+
+// retentionBuckets returns the configured grandfather-father-son rules
+func retentionBuckets(retention *backupv1alpha1.RetentionPolicy) []retentionBucket {
+	var buckets []retentionBucket
+	add := func(name string, count *int, period func(time.Time, int) string) {
+		if count != nil {
+			buckets = append(buckets, retentionBucket{name: name, count: *count, period: period})
+		}
+	}
+
+	add("keepLast", retention.KeepLast, func(_ time.Time, index int) string {
+		return fmt.Sprint(index)
+	})
+	add("keepHourly", retention.KeepHourly, func(t time.Time, _ int) string {
+		return t.Format("2006-01-02T15")
+	})
+	add("keepDaily", retention.KeepDaily, func(t time.Time, _ int) string {
+		return t.Format("2006-01-02")
+	})
+	add("keepWeekly", retention.KeepWeekly, func(t time.Time, _ int) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+	add("keepMonthly", retention.KeepMonthly, func(t time.Time, _ int) string {
+		return t.Format("2006-01")
+	})
+	add("keepYearly", retention.KeepYearly, func(t time.Time, _ int) string {
+		return t.Format("2006")
+	})
+
+	return buckets
+}
+
+// backupTime is the timestamp retention rules are evaluated against.
+// Periods are computed in UTC so every operator replica agrees on them.
+func backupTime(backup *backupv1alpha1.Backup) time.Time {
+	return backup.CreationTimestamp.UTC()
+}
+
+// describeRetention renders the configured rules for events and logs,
+// e.g. "keepLast=3, keepDaily=7"
+func describeRetention(retention *backupv1alpha1.RetentionPolicy) string {
+	if retention == nil {
+		return "none"
+	}
+
+	var parts []string
+	for _, b := range retentionBuckets(retention) {
+		parts = append(parts, fmt.Sprintf("%s=%d", b.name, b.count))
+	}
+	if retention.KeepWithin != nil {
+		parts = append(parts, "keepWithin="+retention.KeepWithin.Duration.String())
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,430 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// retentionBackups builds one completed Backup per RFC3339 timestamp, named after the timestamp
+func retentionBackups(t *testing.T, timestamps ...string) []backupv1alpha1.Backup {
+	t.Helper()
+
+	backups := make([]backupv1alpha1.Backup, 0, len(timestamps))
+	for _, ts := range timestamps {
+		created, err := time.Parse(time.RFC3339, ts)
+		if err != nil {
+			t.Fatalf("invalid timestamp %q: %v", ts, err)
+		}
+		backups = append(backups, backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              ts,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Status: backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+		})
+	}
+	return backups
+}
+
+func backupNames(backups []backupv1alpha1.Backup) []string {
+	names := []string{}
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+	return names
+}
+
+func TestApplyRetention(t *testing.T) {
+	tests := []struct {
+		name       string
+		timestamps []string
+		retention  *backupv1alpha1.RetentionPolicy
+		wantKeep   []string
+		wantRemove []string
+	}{
+		{
+			name:       "no policy keeps everything",
+			timestamps: []string{"2026-01-01T02:00:00Z", "2026-01-02T02:00:00Z"},
+			retention:  nil,
+			wantKeep:   []string{"2026-01-02T02:00:00Z", "2026-01-01T02:00:00Z"},
+			wantRemove: []string{},
+		},
+		{
+			name:       "empty policy keeps everything",
+			timestamps: []string{"2026-01-01T02:00:00Z", "2026-01-02T02:00:00Z"},
+			retention:  &backupv1alpha1.RetentionPolicy{},
+			wantKeep:   []string{"2026-01-02T02:00:00Z", "2026-01-01T02:00:00Z"},
+			wantRemove: []string{},
+		},
+		{
+			name: "keepLast keeps the newest backups regardless of input order",
+			timestamps: []string{
+				"2026-01-03T02:00:00Z",
+				"2026-01-01T02:00:00Z",
+				"2026-01-05T02:00:00Z",
+				"2026-01-02T02:00:00Z",
+				"2026-01-04T02:00:00Z",
+			},
+			retention:  &backupv1alpha1.RetentionPolicy{KeepLast: ptr.To(3)},
+			wantKeep:   []string{"2026-01-05T02:00:00Z", "2026-01-04T02:00:00Z", "2026-01-03T02:00:00Z"},
+			wantRemove: []string{"2026-01-02T02:00:00Z", "2026-01-01T02:00:00Z"},
+		},
+		{
+			name: "keepHourly keeps the newest backup of each hour",
+			timestamps: []string{
+				"2026-01-01T10:05:00Z",
+				"2026-01-01T10:35:00Z",
+				"2026-01-01T11:05:00Z",
+				"2026-01-01T11:35:00Z",
+				"2026-01-01T12:05:00Z",
+			},
+			retention:  &backupv1alpha1.RetentionPolicy{KeepHourly: ptr.To(2)},
+			wantKeep:   []string{"2026-01-01T12:05:00Z", "2026-01-01T11:35:00Z"},
+			wantRemove: []string{"2026-01-01T11:05:00Z", "2026-01-01T10:35:00Z", "2026-01-01T10:05:00Z"},
+		},
+		{
+			name: "keepDaily keeps the newest backup of each day",
+			timestamps: []string{
+				"2026-01-01T02:00:00Z",
+				"2026-01-01T14:00:00Z",
+				"2026-01-02T02:00:00Z",
+				"2026-01-02T14:00:00Z",
+				"2026-01-03T02:00:00Z",
+				"2026-01-04T02:00:00Z",
+			},
+			retention: &backupv1alpha1.RetentionPolicy{KeepDaily: ptr.To(3)},
+			wantKeep:  []string{"2026-01-04T02:00:00Z", "2026-01-03T02:00:00Z", "2026-01-02T14:00:00Z"},
+			wantRemove: []string{
+				"2026-01-02T02:00:00Z",
+				"2026-01-01T14:00:00Z",
+				"2026-01-01T02:00:00Z",
+			},
+		},
+		{
+			name: "keepDaily skips days without backups",
+			timestamps: []string{
+				"2026-01-01T02:00:00Z",
+				"2026-01-05T02:00:00Z",
+				"2026-01-10T02:00:00Z",
+			},
+			retention:  &backupv1alpha1.RetentionPolicy{KeepDaily: ptr.To(2)},
+			wantKeep:   []string{"2026-01-10T02:00:00Z", "2026-01-05T02:00:00Z"},
+			wantRemove: []string{"2026-01-01T02:00:00Z"},
+		},
+		{
+			name: "keepWeekly uses ISO weeks across a year boundary",
+			timestamps: []string{
+				"2025-12-22T02:00:00Z", // 2025-W52
+				"2025-12-28T02:00:00Z", // 2025-W52
+				"2025-12-29T02:00:00Z", // 2026-W01
+				"2026-01-04T02:00:00Z", // 2026-W01
+				"2026-01-05T02:00:00Z", // 2026-W02
+			},
+			retention:  &backupv1alpha1.RetentionPolicy{KeepWeekly: ptr.To(3)},
+			wantKeep:   []string{"2026-01-05T02:00:00Z", "2026-01-04T02:00:00Z", "2025-12-28T02:00:00Z"},
+			wantRemove: []string{"2025-12-29T02:00:00Z", "2025-12-22T02:00:00Z"},
+		},
+		{
+			name: "keepMonthly and keepYearly combine",
+			timestamps: []string{
+				"2024-06-01T02:00:00Z",
+				"2024-12-31T02:00:00Z",
+				"2025-11-30T02:00:00Z",
+				"2025-12-15T02:00:00Z",
+				"2026-01-15T02:00:00Z",
+				"2026-02-15T02:00:00Z",
+			},
+			retention: &backupv1alpha1.RetentionPolicy{
+				KeepMonthly: ptr.To(2),
+				KeepYearly:  ptr.To(3),
+			},
+			wantKeep: []string{
+				"2026-02-15T02:00:00Z",
+				"2026-01-15T02:00:00Z",
+				"2025-12-15T02:00:00Z",
+				"2024-12-31T02:00:00Z",
+			},
+			wantRemove: []string{"2025-11-30T02:00:00Z", "2024-06-01T02:00:00Z"},
+		},
+		{
+			name: "rules are a union, not an intersection",
+			timestamps: []string{
+				"2026-01-01T02:00:00Z",
+				"2026-01-02T02:00:00Z",
+				"2026-01-02T14:00:00Z",
+				"2026-01-02T20:00:00Z",
+			},
+			retention: &backupv1alpha1.RetentionPolicy{
+				KeepLast:  ptr.To(2),
+				KeepDaily: ptr.To(2),
+			},
+			wantKeep: []string{
+				"2026-01-02T20:00:00Z",
+				"2026-01-02T14:00:00Z",
+				"2026-01-01T02:00:00Z",
+			},
+			wantRemove: []string{"2026-01-02T02:00:00Z"},
+		},
+		{
+			name: "keepWithin is measured from the newest backup",
+			timestamps: []string{
+				"2026-01-01T02:00:00Z",
+				"2026-01-08T02:00:00Z",
+				"2026-01-09T02:00:00Z",
+				"2026-01-10T02:00:00Z",
+			},
+			retention: &backupv1alpha1.RetentionPolicy{
+				KeepWithin: &metav1.Duration{Duration: 48 * time.Hour},
+			},
+			wantKeep: []string{
+				"2026-01-10T02:00:00Z",
+				"2026-01-09T02:00:00Z",
+				"2026-01-08T02:00:00Z",
+			},
+			wantRemove: []string{"2026-01-01T02:00:00Z"},
+		},
+		{
+			name: "counts larger than the history keep everything eligible",
+			timestamps: []string{
+				"2026-01-01T02:00:00Z",
+				"2026-01-01T14:00:00Z",
+				"2026-01-02T02:00:00Z",
+			},
+			retention:  &backupv1alpha1.RetentionPolicy{KeepDaily: ptr.To(30)},
+			wantKeep:   []string{"2026-01-02T02:00:00Z", "2026-01-01T14:00:00Z"},
+			wantRemove: []string{"2026-01-01T02:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep, remove := applyRetention(retentionBackups(t, tt.timestamps...), tt.retention)
+
+			if got := backupNames(keep); !reflect.DeepEqual(got, tt.wantKeep) {
+				t.Errorf("keep = %v, want %v", got, tt.wantKeep)
+			}
+			if got := backupNames(remove); !reflect.DeepEqual(got, tt.wantRemove) {
+				t.Errorf("remove = %v, want %v", got, tt.wantRemove)
+			}
+		})
+	}
+}
+
+func TestDescribeRetention(t *testing.T) {
+	retention := &backupv1alpha1.RetentionPolicy{
+		KeepLast:   ptr.To(3),
+		KeepDaily:  ptr.To(7),
+		KeepWithin: &metav1.Duration{Duration: 24 * time.Hour},
+	}
+
+	want := "keepLast=3, keepDaily=7, keepWithin=24h0m0s"
+	if got := describeRetention(retention); got != want {
+		t.Errorf("describeRetention() = %q, want %q", got, want)
+	}
+	if got := describeRetention(nil); got != "none" {
+		t.Errorf("describeRetention(nil) = %q, want %q", got, "none")
+	}
+}
+
+func TestApplyReplicaRetention(t *testing.T) {
+	backups := retentionBackups(t,
+		"2026-03-04T02:00:00Z",
+		"2026-03-03T02:00:00Z",
+		"2026-03-02T02:00:00Z",
+		"2026-03-01T02:00:00Z",
+	)
+	// The newest copy to offsite is still running and the oldest was already pruned
+	phases := []backupv1alpha1.ReplicaPhase{
+		backupv1alpha1.ReplicaPhaseReplicating,
+		backupv1alpha1.ReplicaPhaseCompleted,
+		backupv1alpha1.ReplicaPhaseCompleted,
+		backupv1alpha1.ReplicaPhasePruned,
+	}
+	for i := range backups {
+		backups[i].Status.Replicas = []backupv1alpha1.ReplicaStatus{
+			{StorageLocation: "offsite", Phase: phases[i]},
+			{StorageLocation: "archive", Phase: backupv1alpha1.ReplicaPhaseCompleted},
+		}
+	}
+
+	kept, prune := applyReplicaRetention(backups, []backupv1alpha1.ReplicaLocation{
+		{StorageLocation: "offsite", KeepLast: ptr.To(1)},
+		{StorageLocation: "archive", KeepLast: ptr.To(3)},
+		{StorageLocation: "mirror"},
+	})
+
+	wantKept := map[string]bool{
+		"2026-03-04T02:00:00Z": true,
+		"2026-03-03T02:00:00Z": true,
+		"2026-03-02T02:00:00Z": true,
+	}
+	if !reflect.DeepEqual(kept, wantKept) {
+		t.Errorf("kept = %v, want %v", kept, wantKept)
+	}
+	wantPrune := map[string][]string{
+		"2026-03-02T02:00:00Z": {"offsite"},
+		"2026-03-01T02:00:00Z": {"archive"},
+	}
+	if !reflect.DeepEqual(prune, wantPrune) {
+		t.Errorf("prune = %v, want %v", prune, wantPrune)
+	}
+}
+
+func TestApplyRetentionGroups(t *testing.T) {
+	// Two runs of a two-PVC group and a standalone backup; members of a run are
+	// created a moment apart
+	backups := retentionBackups(t,
+		"2026-03-03T02:00:01Z",
+		"2026-03-03T02:00:00Z",
+		"2026-03-02T12:00:00Z",
+		"2026-03-02T02:00:01Z",
+		"2026-03-02T02:00:00Z",
+	)
+	for i, group := range []string{"run-3", "run-3", "", "run-2", "run-2"} {
+		backups[i].Spec.Group = group
+	}
+
+	keep, remove := applyRetention(backups, &backupv1alpha1.RetentionPolicy{KeepLast: ptr.To(2)})
+
+	if got, want := backupNames(keep), []string{"2026-03-03T02:00:01Z", "2026-03-03T02:00:00Z", "2026-03-02T12:00:00Z"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keep = %v, want %v", got, want)
+	}
+	if got, want := backupNames(remove), []string{"2026-03-02T02:00:01Z", "2026-03-02T02:00:00Z"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("remove = %v, want %v", got, want)
+	}
+}
+
+func TestRetentionDryRun(t *testing.T) {
+	ctx := context.Background()
+	// A suspended policy never reaches a schedule tick but still reports its plan
+	policy := schedulePolicy(func(policy *backupv1alpha1.BackupPolicy) {
+		policy.Spec.Suspend = true
+		policy.Spec.Retention = &backupv1alpha1.RetentionPolicy{KeepLast: ptr.To(1), DryRun: true}
+	})
+	objs := []client.Object{policy}
+	for i, backup := range retentionBackups(t, "2026-02-26T02:00:00Z", "2026-02-27T02:00:00Z", "2026-02-28T02:00:00Z", "2026-03-01T02:00:00Z") {
+		backup.Name = []string{"audit", "old", "older", "newest"}[i]
+		backup.Namespace = "default"
+		backup.Spec.PolicyRef = "nightly"
+		backup.Spec.Hold = backup.Name == "audit"
+		objs = append(objs, &backup)
+	}
+	recorder := record.NewFakeRecorder(100)
+	r := &BackupPolicyReconciler{
+		Client:   newTransitionClient(t, objs...),
+		Recorder: recorder,
+		Clock:    clocktesting.NewFakeClock(transitionStart.Add(time.Hour)),
+	}
+
+	_, policy, backups := reconcilePolicy(t, r)
+	if want := []string{"older", "old"}; !slices.Equal(policy.Status.PlannedDeletions, want) {
+		t.Errorf("plannedDeletions = %v, want %v without the held backup", policy.Status.PlannedDeletions, want)
+	}
+	if len(backups) != 4 {
+		t.Errorf("backups = %v, want nothing deleted in a dry run", backups)
+	}
+	expectEvents(t, recorder, "RetentionDryRun", "Suspended")
+
+	// An unchanged plan is not announced again
+	_, policy, _ = reconcilePolicy(t, r)
+	expectEvents(t, recorder)
+
+	policy.Spec.Retention.DryRun = false
+	if err := r.Update(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+	_, policy, backups = reconcilePolicy(t, r)
+	slices.Sort(backups)
+	if want := []string{"audit", "newest"}; !slices.Equal(backups, want) {
+		t.Errorf("backups = %v, want %v", backups, want)
+	}
+	if policy.Status.PlannedDeletions != nil {
+		t.Errorf("plannedDeletions = %v, want none outside a dry run", policy.Status.PlannedDeletions)
+	}
+	expectEvents(t, recorder, "CleanupTriggered")
+}
+
+func TestBackupHold(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "release", Namespace: "default"}
+	recorder := record.NewFakeRecorder(100)
+	r := &BackupReconciler{
+		Client: newTransitionClient(t, &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Annotations: map[string]string{backupv1alpha1.HoldAnnotation: "true"},
+			},
+			Spec:   backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+			Status: backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+		}),
+		Recorder: recorder,
+		Clock:    clocktesting.NewFakeClock(transitionStart),
+	}
+	reconcile := func() *backupv1alpha1.Backup {
+		t.Helper()
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		var backup backupv1alpha1.Backup
+		if err := r.Get(ctx, key, &backup); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			t.Fatal(err)
+		}
+		return &backup
+	}
+
+	backup := reconcile()
+	if !controllerutil.ContainsFinalizer(backup, backupv1alpha1.HoldFinalizer) {
+		t.Fatalf("finalizers = %v, want the hold finalizer", backup.Finalizers)
+	}
+
+	// Deleting a held backup is blocked until the hold is cleared
+	if err := r.Delete(ctx, backup); err != nil {
+		t.Fatal(err)
+	}
+	if backup = reconcile(); backup == nil {
+		t.Fatal("held Backup was deleted")
+	}
+	expectEvents(t, recorder, "DeletionBlocked")
+
+	delete(backup.Annotations, backupv1alpha1.HoldAnnotation)
+	if err := r.Update(ctx, backup); err != nil {
+		t.Fatal(err)
+	}
+	if backup = reconcile(); backup != nil {
+		t.Errorf("Backup still exists with finalizers %v after its hold was cleared", backup.Finalizers)
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// dueTick returns the schedule tick a policy runs next: the first tick after
+// the last one it handled, or after now for a new policy. When several ticks
+// were missed, only the most recent one before now is returned, so a policy
+// catches up with one backup rather than one per missed tick.
+func dueTick(schedule cron.Schedule, backupPolicy *backupv1alpha1.BackupPolicy, now time.Time) time.Time {
+	// Policies scheduled before the last handled tick was recorded continue
+	// after their last backup
+	last := backupPolicy.Status.LastScheduleTime
+	if last == nil {
+		last = backupPolicy.Status.LastBackupTime
+	}
+	if last == nil {
+		return schedule.Next(now.Add(-1 * time.Second))
+	}
+
+	tick := schedule.Next(last.Time)
+	for next := schedule.Next(tick); !tick.After(now) && !next.After(now); next = schedule.Next(next) {
+		tick = next
+	}
+	return tick
+}
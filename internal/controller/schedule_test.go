@@ -0,0 +1,211 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestDueTick(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := transitionStart.Add(time.Hour)
+	at := func(offset time.Duration) *metav1.Time {
+		return &metav1.Time{Time: transitionStart.Add(offset)}
+	}
+
+	tests := []struct {
+		name   string
+		status backupv1alpha1.BackupPolicyStatus
+		want   time.Time
+	}{
+		{name: "new policy", want: transitionStart.Add(24 * time.Hour)},
+		{name: "on schedule", status: backupv1alpha1.BackupPolicyStatus{LastScheduleTime: at(0)}, want: transitionStart.Add(24 * time.Hour)},
+		{name: "one tick missed", status: backupv1alpha1.BackupPolicyStatus{LastScheduleTime: at(-24 * time.Hour)}, want: transitionStart},
+		{name: "several ticks missed", status: backupv1alpha1.BackupPolicyStatus{LastScheduleTime: at(-72 * time.Hour)}, want: transitionStart},
+		{name: "last backup only", status: backupv1alpha1.BackupPolicyStatus{LastBackupTime: at(-48*time.Hour + time.Minute)}, want: transitionStart},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &backupv1alpha1.BackupPolicy{Status: tt.status}
+			if got := dueTick(schedule, policy, now); !got.Equal(tt.want) {
+				t.Errorf("dueTick() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// schedulePolicy is a nightly BackupPolicy of PVC data
+func schedulePolicy(mutate func(*backupv1alpha1.BackupPolicy)) *backupv1alpha1.BackupPolicy {
+	policy := &backupv1alpha1.BackupPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+		Spec: backupv1alpha1.BackupPolicySpec{
+			Schedule: "0 2 * * *",
+			Target:   backupv1alpha1.BackupTarget{PVCName: "data"},
+		},
+	}
+	mutate(policy)
+	return policy
+}
+
+// newScheduleReconciler returns a BackupPolicyReconciler for policy at the given time
+func newScheduleReconciler(t *testing.T, policy *backupv1alpha1.BackupPolicy, now time.Time) (*BackupPolicyReconciler, *record.FakeRecorder) {
+	recorder := record.NewFakeRecorder(100)
+	return &BackupPolicyReconciler{
+		Client:   newTransitionClient(t, policy),
+		Recorder: recorder,
+		Clock:    clocktesting.NewFakeClock(now),
+	}, recorder
+}
+
+// reconcilePolicy reconciles the nightly policy and returns it with its Backups' names
+func reconcilePolicy(t *testing.T, r *BackupPolicyReconciler) (ctrl.Result, *backupv1alpha1.BackupPolicy, []string) {
+	t.Helper()
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var policy backupv1alpha1.BackupPolicy
+	if err := r.Get(ctx, key, &policy); err != nil {
+		t.Fatal(err)
+	}
+	var backups backupv1alpha1.BackupList
+	if err := r.List(ctx, &backups); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, backup := range backups.Items {
+		names = append(names, backup.Name)
+	}
+	return result, &policy, names
+}
+
+func TestSuspendAndTriggerNow(t *testing.T) {
+	ctx := context.Background()
+	r, recorder := newScheduleReconciler(t, schedulePolicy(func(policy *backupv1alpha1.BackupPolicy) {
+		policy.Spec.Suspend = true
+		policy.Annotations = map[string]string{backupv1alpha1.TriggerNowAnnotation: "first"}
+		policy.Status.LastBackupTime = &metav1.Time{Time: transitionStart.Add(-72 * time.Hour)}
+	}), transitionStart.Add(time.Hour))
+
+	// A suspended policy still takes on-demand backups, once per token
+	result, policy, backups := reconcilePolicy(t, r)
+	expectNoRequeue(t, result, nil)
+	if len(backups) != 1 || backups[0] != manualBackupName("nightly", "first") {
+		t.Errorf("backups = %v, want only the on-demand backup", backups)
+	}
+	if policy.Status.LastTriggerToken != "first" {
+		t.Errorf("lastTriggerToken = %q, want first", policy.Status.LastTriggerToken)
+	}
+	if ready := meta.FindStatusCondition(policy.Status.Conditions, backupv1alpha1.ConditionReady); ready == nil || ready.Reason != "Suspended" {
+		t.Errorf("Ready = %+v, want Suspended", ready)
+	}
+	expectEvents(t, recorder, "ManualBackupCreated", "Suspended")
+
+	_, policy, backups = reconcilePolicy(t, r)
+	if len(backups) != 1 {
+		t.Errorf("backups = %v, want the token handled once", backups)
+	}
+	expectEvents(t, recorder)
+
+	policy.Annotations[backupv1alpha1.TriggerNowAnnotation] = "second"
+	if err := r.Update(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+	_, policy, backups = reconcilePolicy(t, r)
+	if len(backups) != 2 {
+		t.Errorf("backups = %v, want a second on-demand backup for a new token", backups)
+	}
+	expectEvents(t, recorder, "ManualBackupCreated")
+
+	// Resuming skips the ticks missed while suspended instead of running one now
+	policy.Spec.Suspend = false
+	if err := r.Update(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+	result, policy, backups = reconcilePolicy(t, r)
+	if len(backups) != 2 {
+		t.Errorf("backups = %v, want no scheduled backup on resume", backups)
+	}
+	if next := transitionStart.Add(24 * time.Hour); policy.Status.NextScheduledBackup == nil || !policy.Status.NextScheduledBackup.Time.Equal(next) {
+		t.Errorf("nextScheduledBackup = %v, want %s", policy.Status.NextScheduledBackup, next)
+	}
+	if result.RequeueAfter != 23*time.Hour {
+		t.Errorf("RequeueAfter = %v, want the next tick", result.RequeueAfter)
+	}
+	expectEvents(t, recorder, "Resumed", "BackupScheduled")
+
+	// The resume is recorded, so later reconciles keep the same schedule
+	_, _, backups = reconcilePolicy(t, r)
+	if len(backups) != 2 {
+		t.Errorf("backups = %v, want no scheduled backup after resuming", backups)
+	}
+	expectEvents(t, recorder)
+}
+
+func TestStartingDeadline(t *testing.T) {
+	tests := []struct {
+		name     string
+		deadline *int64
+		wantTick bool
+		events   []string
+	}{
+		{name: "no deadline", wantTick: true, events: []string{"BackupCreated"}},
+		{name: "within deadline", deadline: ptr.To[int64](2 * 3600), wantTick: true, events: []string{"BackupCreated"}},
+		{name: "past deadline", deadline: ptr.To[int64](300), events: []string{"MissedSchedule", "BackupScheduled"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The operator was down for the last three ticks
+			r, recorder := newScheduleReconciler(t, schedulePolicy(func(policy *backupv1alpha1.BackupPolicy) {
+				policy.Spec.StartingDeadlineSeconds = tt.deadline
+				policy.Status.LastScheduleTime = &metav1.Time{Time: transitionStart.Add(-72 * time.Hour)}
+			}), transitionStart.Add(time.Hour))
+
+			_, policy, backups := reconcilePolicy(t, r)
+			if tt.wantTick {
+				if len(backups) != 1 || backups[0] != scheduledBackupName("nightly", transitionStart) {
+					t.Errorf("backups = %v, want one for the most recent missed tick", backups)
+				}
+			} else if len(backups) != 0 {
+				t.Errorf("backups = %v, want the late tick skipped", backups)
+			}
+			if last := policy.Status.LastScheduleTime; last == nil || !last.Time.Equal(transitionStart) {
+				t.Errorf("lastScheduleTime = %v, want the most recent tick", last)
+			}
+			expectEvents(t, recorder, tt.events...)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// setCondition adds or updates a condition observed at the given generation.
+// LastTransitionTime only changes when the condition's status does.
+func setCondition(conditions *[]metav1.Condition, generation int64, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setConditions sets Ready, Progressing and Degraded together for a phase
+// change, all with the same reason and message
+func setConditions(conditions *[]metav1.Condition, generation int64, reason, message string,
+	ready, progressing, degraded metav1.ConditionStatus) {
+	setCondition(conditions, generation, backupv1alpha1.ConditionReady, ready, reason, message)
+	setCondition(conditions, generation, backupv1alpha1.ConditionProgressing, progressing, reason, message)
+	setCondition(conditions, generation, backupv1alpha1.ConditionDegraded, degraded, reason, message)
+}
+
+// Status writes use a merge patch against a snapshot taken before the status
+// was changed, so they only send the fields that changed, never fail on a
+// stale resourceVersion and leave every other field as it is on the server.
+// Each write also records the generation the status was computed from.
+
+// patchBackupStatus writes the status changes made to backup since base
+func patchBackupStatus(ctx context.Context, c client.Client, backup, base *backupv1alpha1.Backup) error {
+	backup.Status.ObservedGeneration = backup.Generation
+	return c.Status().Patch(ctx, backup, client.MergeFrom(base))
+}
+
+// patchRestoreStatus writes the status changes made to restore since base
+func patchRestoreStatus(ctx context.Context, c client.Client, restore, base *backupv1alpha1.Restore) error {
+	restore.Status.ObservedGeneration = restore.Generation
+	return c.Status().Patch(ctx, restore, client.MergeFrom(base))
+}
+
+// patchPolicyStatus writes the status changes made to backupPolicy since base
+func patchPolicyStatus(ctx context.Context, c client.Client, backupPolicy, base *backupv1alpha1.BackupPolicy) error {
+	backupPolicy.Status.ObservedGeneration = backupPolicy.Generation
+	return c.Status().Patch(ctx, backupPolicy, client.MergeFrom(base))
+}
+
+// patchLocationStatus writes the status changes made to location since base
+func patchLocationStatus(ctx context.Context, c client.Client, location, base *backupv1alpha1.BackupStorageLocation) error {
+	location.Status.ObservedGeneration = location.Generation
+	return c.Status().Patch(ctx, location, client.MergeFrom(base))
+}
+
+// patchPlanStatus writes the status changes made to plan since base
+func patchPlanStatus(ctx context.Context, c client.Client, plan, base *backupv1alpha1.DisasterRecoveryPlan) error {
+	plan.Status.ObservedGeneration = plan.Generation
+	return c.Status().Patch(ctx, plan, client.MergeFrom(base))
+}
+
+// patchExecutionStatus writes the status changes made to execution since base
+func patchExecutionStatus(ctx context.Context, c client.Client, execution, base *backupv1alpha1.DRExecution) error {
+	execution.Status.ObservedGeneration = execution.Generation
+	return c.Status().Patch(ctx, execution, client.MergeFrom(base))
+}
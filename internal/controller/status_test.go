@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestSetConditions(t *testing.T) {
+	var conditions []metav1.Condition
+	setConditions(&conditions, 1, "BackupStarted", "Backup execution started",
+		metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionFalse)
+	if len(conditions) != 3 {
+		t.Fatalf("got %d conditions, want Ready, Progressing and Degraded", len(conditions))
+	}
+
+	// Pretend the conditions were set a while ago
+	past := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	for i := range conditions {
+		conditions[i].LastTransitionTime = past
+	}
+
+	setConditions(&conditions, 2, "JobRunning", "Running attempt 1 of 1",
+		metav1.ConditionFalse, metav1.ConditionTrue, metav1.ConditionTrue)
+
+	for _, conditionType := range []string{backupv1alpha1.ConditionReady, backupv1alpha1.ConditionProgressing} {
+		condition := meta.FindStatusCondition(conditions, conditionType)
+		if !condition.LastTransitionTime.Equal(&past) {
+			t.Errorf("%s: LastTransitionTime changed although the status did not", conditionType)
+		}
+		if condition.Reason != "JobRunning" || condition.ObservedGeneration != 2 {
+			t.Errorf("%s: reason %q at generation %d, want JobRunning at 2", conditionType, condition.Reason, condition.ObservedGeneration)
+		}
+	}
+	if degraded := meta.FindStatusCondition(conditions, backupv1alpha1.ConditionDegraded); degraded.LastTransitionTime.Equal(&past) {
+		t.Error("Degraded: LastTransitionTime should change with the status")
+	}
+}
+
+func TestPatchBackupStatus(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+	c := newTransitionClient(t, &backupv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Generation: 3},
+	})
+
+	// Two copies read at the same resourceVersion, as two reconciles would
+	var first, second backupv1alpha1.Backup
+	if err := c.Get(ctx, key, &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, key, &second); err != nil {
+		t.Fatal(err)
+	}
+
+	base := first.DeepCopy()
+	first.Status.Phase = backupv1alpha1.BackupPhaseRunning
+	if err := patchBackupStatus(ctx, c, &first, base); err != nil {
+		t.Fatalf("first patch: %v", err)
+	}
+
+	// The stale copy still writes, and only the field it changed
+	base = second.DeepCopy()
+	second.Status.Attempts = 2
+	if err := patchBackupStatus(ctx, c, &second, base); err != nil {
+		t.Fatalf("patch from a stale copy: %v", err)
+	}
+
+	var got backupv1alpha1.Backup
+	if err := c.Get(ctx, key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != backupv1alpha1.BackupPhaseRunning || got.Status.Attempts != 2 {
+		t.Errorf("status = %+v, want both writes kept", got.Status)
+	}
+	if got.Status.ObservedGeneration != 3 {
+		t.Errorf("observedGeneration = %d, want 3", got.Status.ObservedGeneration)
+	}
+}
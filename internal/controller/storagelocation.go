@@ -0,0 +1,285 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+const (
+	// manifestVersion is the version of the metadata manifest format
+	manifestVersion = 1
+
+	// manifestPrefix starts every manifest line printed by the sync Job
+	manifestPrefix = "manifest "
+
+	// pagePrefix starts the last line printed by a sync Job, which counts the
+	// manifests it printed and says where the next page starts:
+	//
+	//	page 120 next 360
+	//	page 42 end
+	pagePrefix = "page "
+
+	// syncPageBytes is how many bytes of manifests a sync Job prints at most,
+	// well below the 10Mi the kubelet keeps of a container log by default.
+	// A page always holds at least one manifest.
+	syncPageBytes = 1 << 20
+
+	// syncLogReadBytes caps reading a sync Job's log. A log longer than that
+	// is missing its last line and the page is rejected as truncated.
+	syncLogReadBytes = 4 * syncPageBytes
+
+	// backupManifestEnv and sourcePVCManifestEnv pass the parts of the manifest
+	// the operator knows up front to the backup container as JSON, and
+	// clusterIDManifestEnv the ID of the cluster writing it
+	backupManifestEnv    = "BACKUP_MANIFEST"
+	sourcePVCManifestEnv = "SOURCE_PVC_MANIFEST"
+	clusterIDManifestEnv = "CLUSTER_ID"
+)
+
+// backupManifest is the metadata sidecar written as <backup>.json next to
+// every archive once it is complete. It carries everything needed to recreate
+// the Backup in a cluster that only has the storage.
+type backupManifest struct {
+	Version        int                               `json:"version"`
+	Checksum       string                            `json:"checksum"`
+	Size           int64                             `json:"size"`
+	DataSize       int64                             `json:"dataSize"`
+	FileCount      int64                             `json:"fileCount"`
+	CompletionTime metav1.Time                       `json:"completionTime"`
+	Backup         backupv1alpha1.Backup             `json:"backup"`
+	SourcePVC      *corev1.PersistentVolumeClaimSpec `json:"sourcePVC,omitempty"`
+
+	// ClusterID identifies the cluster that wrote the archive, see ClusterID
+	ClusterID string `json:"clusterID,omitempty"`
+}
+
+// ClusterID identifies the cluster by the UID of its kube-system namespace,
+// which lives as long as the cluster does. Manifests record it so a sync does
+// not import archives of Backups this cluster itself deleted.
+func ClusterID(ctx context.Context, namespaces corev1client.NamespacesGetter) (string, error) {
+	namespace, err := namespaces.Namespaces().Get(ctx, metav1.NamespaceSystem, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("reading cluster ID: %w", err)
+	}
+	return string(namespace.UID), nil
+}
+
+// storagePVCName returns the PVC holding the archives of a storage location
+// in the given namespace, or the operator's storage PVC if location is empty
+func storagePVCName(ctx context.Context, c client.Reader, cfg *config.OperatorConfig, namespace, location string) (string, error) {
+	if location == "" {
+		return cfg.DefaultStoragePVC, nil
+	}
+	var storageLocation backupv1alpha1.BackupStorageLocation
+	if err := c.Get(ctx, client.ObjectKey{Name: location, Namespace: namespace}, &storageLocation); err != nil {
+		return "", fmt.Errorf("storage location %s: %w", location, err)
+	}
+	return storageLocation.Spec.PVCName, nil
+}
+
+// manifestEnv renders the Backup, its source PVC spec and the cluster ID for
+// the manifest. The Backup is recorded as it is when its Job is created; the
+// archive details are filled in by the Job.
+func manifestEnv(backup *backupv1alpha1.Backup, sourcePVC *corev1.PersistentVolumeClaimSpec, clusterID string) []corev1.EnvVar {
+	recorded := backupv1alpha1.Backup{
+		TypeMeta: metav1.TypeMeta{APIVersion: backupv1alpha1.GroupVersion.String(), Kind: "Backup"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        backup.Name,
+			Namespace:   backup.Namespace,
+			Labels:      backup.Labels,
+			Annotations: backup.Annotations,
+		},
+		Spec:   backup.Spec,
+		Status: backup.Status,
+	}
+	// Conditions describe this cluster's view and are rebuilt on import
+	recorded.Status.Conditions = nil
+
+	backupJSON, _ := json.Marshal(recorded)
+	sourcePVCJSON, _ := json.Marshal(sourcePVC)
+	return []corev1.EnvVar{
+		{Name: backupManifestEnv, Value: string(backupJSON)},
+		{Name: sourcePVCManifestEnv, Value: string(sourcePVCJSON)},
+		{Name: clusterIDManifestEnv, Value: clusterID},
+	}
+}
+
+// writeManifest is the shell command that writes the manifest of a complete
+// archive in the current directory. It runs last, so an archive with a
+// manifest is always complete; the rename keeps readers from seeing half a file.
+func writeManifest(name, archive string) string {
+	return "printf '{\"version\":" + fmt.Sprint(manifestVersion) +
+		",\"checksum\":\"%s\",\"size\":%s,\"dataSize\":%s,\"fileCount\":%s,\"completionTime\":\"%s\",\"backup\":%s,\"sourcePVC\":%s,\"clusterID\":\"%s\"}\\n' " +
+		"\"$(cut -d ' ' -f 1 " + archive + ".sha256)\" " +
+		"\"$(stat -c %s " + archive + ")\" " +
+		"\"${bytes_total:-0}\" \"${files_total:-0}\" " +
+		"\"$(date -u +%Y-%m-%dT%H:%M:%SZ)\" " +
+		"\"$" + backupManifestEnv + "\" \"$" + sourcePVCManifestEnv + "\" \"$" + clusterIDManifestEnv + "\" " +
+		"> " + name + ".json.tmp && mv " + name + ".json.tmp " + name + ".json"
+}
+
+// syncPage is what one sync Job read from a storage location
+type syncPage struct {
+	manifests []backupManifest
+
+	// errs explains the manifests that could not be read
+	errs []error
+
+	// next is the offset of the next page, 0 after the last one
+	next int32
+}
+
+// parseSyncPage reads the manifests printed by a sync Job and checks that none
+// were lost: the log must end with the page line, and it must count every
+// manifest line before it. The kubelet rotating or truncating the log fails
+// the check instead of silently skipping archives.
+func parseSyncPage(logs string) (*syncPage, error) {
+	printed := 0
+	lines := strings.Split(strings.TrimSpace(logs), "\n")
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), manifestPrefix) {
+			printed++
+		}
+	}
+	last, found := strings.CutPrefix(strings.TrimSpace(lines[len(lines)-1]), pagePrefix)
+	if !found {
+		return nil, fmt.Errorf("the sync Job's log ends before its page line; it was truncated")
+	}
+
+	var count, next int32
+	if _, err := fmt.Sscanf(last, "%d next %d", &count, &next); err != nil {
+		if _, err := fmt.Sscanf(last, "%d end", &count); err != nil {
+			return nil, fmt.Errorf("unexpected page line %q", pagePrefix+last)
+		}
+		next = 0
+	}
+	if int(count) != printed {
+		return nil, fmt.Errorf("the sync Job printed %d manifests but its log holds %d; it was truncated", count, printed)
+	}
+
+	manifests, errs := parseManifests(logs)
+	return &syncPage{manifests: manifests, errs: errs, next: next}, nil
+}
+
+// parseManifests reads the manifest lines printed by a sync Job. Lines that
+// do not parse are returned as errors so a single bad manifest does not stop
+// the others from being imported.
+func parseManifests(logs string) ([]backupManifest, []error) {
+	var manifests []backupManifest
+	var errs []error
+	for _, line := range strings.Split(logs, "\n") {
+		data, found := strings.CutPrefix(strings.TrimSpace(line), manifestPrefix)
+		if !found {
+			continue
+		}
+		var manifest backupManifest
+		if err := json.Unmarshal([]byte(data), &manifest); err != nil {
+			errs = append(errs, fmt.Errorf("parsing manifest: %w", err))
+			continue
+		}
+		if manifest.Version != manifestVersion || manifest.Backup.Name == "" {
+			errs = append(errs, fmt.Errorf("unsupported manifest version %d for %q", manifest.Version, manifest.Backup.Name))
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, errs
+}
+
+// isImported reports whether a Backup was recreated from a storage location.
+// Imported Backups are read-only: the operator never runs a backup Job for
+// them or expires them, but they can be restored and verified.
+func isImported(backup *backupv1alpha1.Backup) bool {
+	return backup.Labels[backupv1alpha1.OriginLabel] == backupv1alpha1.OriginImported
+}
+
+// importedSpec records the spec of an imported Backup for
+// ImportedSpecAnnotation. Hold is left out, holding an imported Backup is
+// allowed.
+func importedSpec(spec *backupv1alpha1.BackupSpec) string {
+	recorded := spec.DeepCopy()
+	recorded.Hold = false
+	data, _ := json.Marshal(recorded)
+	return string(data)
+}
+
+// reconcileImported keeps an imported Backup read-only. Edits to its spec
+// other than spec.hold are reverted to the spec it was imported with, and a
+// removed origin label is put back, so it never turns into a Backup the
+// operator would run, expire or prune. Backups imported before the spec was
+// recorded get their current spec recorded. It reports whether it updated the
+// Backup; the update triggers the next reconcile.
+func (r *BackupReconciler) reconcileImported(ctx context.Context, backup *backupv1alpha1.Backup) (bool, error) {
+	recorded, hasSpec := backup.Annotations[backupv1alpha1.ImportedSpecAnnotation]
+	if (!hasSpec && !isImported(backup)) || !backup.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+
+	var changes []string
+	if !isImported(backup) {
+		if backup.Labels == nil {
+			backup.Labels = map[string]string{}
+		}
+		backup.Labels[backupv1alpha1.OriginLabel] = backupv1alpha1.OriginImported
+		changes = append(changes, "origin label")
+	}
+	if !hasSpec {
+		if backup.Annotations == nil {
+			backup.Annotations = map[string]string{}
+		}
+		backup.Annotations[backupv1alpha1.ImportedSpecAnnotation] = importedSpec(&backup.Spec)
+	} else if recorded != importedSpec(&backup.Spec) {
+		var spec backupv1alpha1.BackupSpec
+		if err := json.Unmarshal([]byte(recorded), &spec); err != nil {
+			// A corrupted record cannot be restored from, so the current spec
+			// becomes the record
+			backup.Annotations[backupv1alpha1.ImportedSpecAnnotation] = importedSpec(&backup.Spec)
+		} else {
+			spec.Hold = backup.Spec.Hold
+			backup.Spec = spec
+			changes = append(changes, "spec")
+		}
+	}
+
+	if err := r.Update(ctx, backup); err != nil {
+		return true, err
+	}
+	if len(changes) > 0 {
+		logf.FromContext(ctx).Info("Reverted changes to imported Backup", "changes", changes)
+		r.Recorder.Eventf(
+			backup,
+			corev1.EventTypeWarning,
+			"ReadOnly",
+			"Imported Backups are read-only; reverted changes to the %s",
+			strings.Join(changes, " and "),
+		)
+	}
+	return true, nil
+}
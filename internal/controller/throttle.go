@@ -0,0 +1,234 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+const (
+	// minThrottleRate is the lowest rate a throttle accepts, in bytes per second
+	minThrottleRate = 1024
+
+	// maxThrottleChunk bounds the memory the throttle of a fast rate buffers
+	maxThrottleChunk = 4 << 20
+
+	// Values of the mover label
+	backupMover  = "backup"
+	restoreMover = "restore"
+)
+
+// throttleFunction defines the shell function throttle filters run. It copies
+// stdin to stdout a chunk at a time, sleeping between chunks, and stops when
+// dd reads nothing. dd reads exactly the bytes it copies, so nothing is lost
+// between chunks; busybox provides everything it needs.
+const throttleFunction = "throttle() { " +
+	"while dd bs=$1 count=1 iflag=fullblock 2> $3 && ! grep -q '^0+0 records in' $3; do sleep $2; done; " +
+	"}; "
+
+// moverThrottle is the rate limit of a mover in bytes per second; 0 is unlimited
+type moverThrottle struct {
+	read   int64
+	upload int64
+}
+
+// throttleOf returns the rate limits of a throttle spec
+func throttleOf(spec *backupv1alpha1.ThrottleSpec) moverThrottle {
+	var throttle moverThrottle
+	if spec == nil {
+		return throttle
+	}
+	if spec.ReadBytesPerSecond != nil {
+		throttle.read = spec.ReadBytesPerSecond.Value()
+	}
+	if spec.UploadBytesPerSecond != nil {
+		throttle.upload = spec.UploadBytesPerSecond.Value()
+	}
+	return throttle
+}
+
+// validateThrottle checks that every rate that is set is high enough to throttle by
+func validateThrottle(spec *backupv1alpha1.ThrottleSpec) error {
+	if spec == nil {
+		return nil
+	}
+	rates := []struct {
+		name string
+		rate *resource.Quantity
+	}{
+		{"readBytesPerSecond", spec.ReadBytesPerSecond},
+		{"uploadBytesPerSecond", spec.UploadBytesPerSecond},
+	}
+	for _, r := range rates {
+		if r.rate != nil && r.rate.Value() < minThrottleRate {
+			return fmt.Errorf("throttle.%s is %s, but must be at least 1Ki", r.name, r.rate)
+		}
+	}
+	return nil
+}
+
+// unlimited reports whether neither rate is limited
+func (t moverThrottle) unlimited() bool {
+	return t.read == 0 && t.upload == 0
+}
+
+// function is the definition of the throttle shell function, to be put in
+// front of a mover script, or empty when the mover is not throttled
+func (t moverThrottle) function() string {
+	if t.unlimited() {
+		return ""
+	}
+	return throttleFunction
+}
+
+// readFilter is a pipe stage limiting the data read from the source
+func (t moverThrottle) readFilter() string {
+	return rateFilter(t.read, "read")
+}
+
+// uploadFilter is a pipe stage limiting the data written to the destination
+func (t moverThrottle) uploadFilter() string {
+	return rateFilter(t.upload, "upload")
+}
+
+// rateFilter is a pipe stage passing on at most rate bytes per second, or
+// empty for an unlimited rate. It passes a tenth of the rate ten times a
+// second, or smaller chunks more often for rates above 40Mi.
+func rateFilter(rate int64, name string) string {
+	if rate == 0 {
+		return ""
+	}
+	chunk := min(rate/10, maxThrottleChunk)
+	interval := max(float64(chunk)/float64(rate), 0.001)
+	return " | throttle " + strconv.FormatInt(chunk, 10) + " " + strconv.FormatFloat(interval, 'f', 3, 64) +
+		" /tmp/throttle-" + name
+}
+
+// moverLabels marks a mover Job and its pods with its kind and storage PVC
+func moverLabels(mover, storagePVC string) map[string]string {
+	return map[string]string{
+		backupv1alpha1.MoverLabel:   mover,
+		backupv1alpha1.StorageLabel: labelValue(storagePVC),
+	}
+}
+
+// moverSlotWait checks the operator's caps on concurrently running movers
+// and returns why the backup's mover has to wait, or "" when it can start.
+// Members of a consistency group are never held back: they start together
+// after the group's pre-hook, which a partly admitted group would keep frozen.
+// They still count against the caps. Jobs and pods are read from the cache,
+// whose pod informer starts with the first per-node check; objects created
+// moments ago may not be in it yet, so concurrent reconciles can briefly
+// exceed a cap.
+func (r *BackupReconciler) moverSlotWait(ctx context.Context, backup *backupv1alpha1.Backup, storagePVC string) (string, error) {
+	limits := r.Config.Get().MaxConcurrentMovers
+	if limits == nil || backup.Spec.Group != "" {
+		return "", nil
+	}
+
+	if limits.PerStorageLocation > 0 {
+		var jobs batchv1.JobList
+		if err := r.List(ctx, &jobs, client.InNamespace(backup.Namespace),
+			client.MatchingLabels{backupv1alpha1.StorageLabel: labelValue(storagePVC)},
+			client.HasLabels{backupv1alpha1.MoverLabel}); err != nil {
+			return "", err
+		}
+		running := 0
+		for i := range jobs.Items {
+			job := &jobs.Items[i]
+			if jobCondition(job, batchv1.JobComplete) == nil && jobCondition(job, batchv1.JobFailed) == nil {
+				running++
+			}
+		}
+		if running >= limits.PerStorageLocation {
+			return fmt.Sprintf("%d mover(s) already use storage PVC %s, the limit per storage location", running, storagePVC), nil
+		}
+	}
+
+	if limits.PerNode > 0 {
+		node, err := r.sourceNode(ctx, backup)
+		if err != nil || node == "" {
+			return "", err
+		}
+		var pods corev1.PodList
+		if err := r.List(ctx, &pods, client.HasLabels{backupv1alpha1.MoverLabel}); err != nil {
+			return "", err
+		}
+		running := 0
+		for _, pod := range pods.Items {
+			if pod.Spec.NodeName == node && (pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning) {
+				running++
+			}
+		}
+		if running >= limits.PerNode {
+			return fmt.Sprintf("%d mover(s) already run on node %s, the limit per node", running, node), nil
+		}
+	}
+	return "", nil
+}
+
+// sourceNode returns the node of a running pod using the backup's PVC, which
+// is where the mover of a ReadWriteOnce PVC runs too, or "" when no pod uses it
+func (r *BackupReconciler) sourceNode(ctx context.Context, backup *backupv1alpha1.Backup) (string, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(backup.Namespace)); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if _, mover := pod.Labels[backupv1alpha1.MoverLabel]; mover || pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if claim := volume.PersistentVolumeClaim; claim != nil && claim.ClaimName == backup.Spec.Target.PVCName {
+				return pod.Spec.NodeName, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// markWaitingForMoverSlot records that a backup's mover waits for a free slot
+func (r *BackupReconciler) markWaitingForMoverSlot(ctx context.Context, backup *backupv1alpha1.Backup, message string) error {
+	if progressing := meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionProgressing); progressing != nil &&
+		progressing.Reason == "WaitingForMoverSlot" && progressing.Message == message {
+		return nil
+	}
+	base := backup.DeepCopy()
+	setCondition(&backup.Status.Conditions, backup.Generation, backupv1alpha1.ConditionProgressing,
+		metav1.ConditionTrue, "WaitingForMoverSlot", message)
+	if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+		return err
+	}
+	r.Recorder.Event(
+		backup,
+		corev1.EventTypeNormal,
+		"WaitingForMoverSlot",
+		eventMessage(message),
+	)
+	return nil
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/config"
+)
+
+func TestThrottledCommands(t *testing.T) {
+	throttle := throttleOf(&backupv1alpha1.ThrottleSpec{
+		ReadBytesPerSecond:   resource.NewQuantity(100<<20, resource.BinarySI),
+		UploadBytesPerSecond: resource.NewQuantity(10<<20, resource.BinarySI),
+	})
+	format := formatOf(&backupv1alpha1.Backup{})
+
+	compress := format.compressCommand("/data", "/backups/nightly.tar.gz", "/tmp/files", throttle)
+	want := "set -o pipefail; tar -cvf - -C /data . 2> /tmp/files | throttle 4194304 0.040 /tmp/throttle-read | gzip" +
+		" | throttle 1048576 0.100 /tmp/throttle-upload > /backups/nightly.tar.gz"
+	if compress != want {
+		t.Errorf("compressCommand() = %s, want %s", compress, want)
+	}
+
+	extract := format.extractCommand("/backups/nightly.tar.gz", "/restore-target", "/tmp/files", moverThrottle{upload: 1 << 20})
+	want = "set -o pipefail; gzip -dc /backups/nightly.tar.gz | throttle 104857 0.100 /tmp/throttle-upload | tar -xvf - -C /restore-target > /tmp/files"
+	if extract != want {
+		t.Errorf("extractCommand() = %s, want %s", extract, want)
+	}
+
+	if err := validateThrottle(&backupv1alpha1.ThrottleSpec{ReadBytesPerSecond: resource.NewQuantity(512, resource.DecimalSI)}); err == nil {
+		t.Error("validateThrottle() accepted a rate below 1Ki")
+	}
+}
+
+func TestThrottledRestoreJob(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "slow", Namespace: "default"}
+
+	r := &RestoreReconciler{
+		Client: newTransitionClient(t,
+			&backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+				Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+				Status:     backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+			},
+			&backupv1alpha1.Restore{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec: backupv1alpha1.RestoreSpec{
+					BackupName: "nightly",
+					TargetPVC:  "data",
+					Throttle:   &backupv1alpha1.ThrottleSpec{ReadBytesPerSecond: resource.NewQuantity(50<<20, resource.BinarySI)},
+				},
+			},
+		),
+		Recorder: record.NewFakeRecorder(100),
+		Clock:    clocktesting.NewFakeClock(transitionStart),
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+
+	var job batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: "slow-job", Namespace: "default"}, &job); err != nil {
+		t.Fatal(err)
+	}
+	script := job.Spec.Template.Spec.InitContainers[0].Command[2]
+	if !strings.HasPrefix(script, throttleFunction) || !strings.Contains(script, "cat /backup-source/nightly.tar.gz | throttle 4194304 ") {
+		t.Errorf("restore script is not throttled: %s", script)
+	}
+	if job.Spec.Template.Labels[backupv1alpha1.MoverLabel] != restoreMover {
+		t.Errorf("restore pods labelled %v, want the restore mover label", job.Spec.Template.Labels)
+	}
+}
+
+func TestMoverSlots(t *testing.T) {
+	runningJob := func(name, mover string) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    moverLabels(mover, "backup-storage"),
+			},
+		}
+	}
+	pod := func(name, node string, labels map[string]string, claim string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if claim != "" {
+			pod.Spec.Volumes = []corev1.Volume{{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+			}}
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name   string
+		limits config.MoverLimits
+		jobs   []*batchv1.Job
+		pods   []*corev1.Pod
+		wait   bool
+	}{
+		{
+			name:   "storage location full",
+			limits: config.MoverLimits{PerStorageLocation: 1},
+			jobs:   []*batchv1.Job{runningJob("restore-job", restoreMover)},
+			wait:   true,
+		},
+		{
+			name:   "storage location with room",
+			limits: config.MoverLimits{PerStorageLocation: 2},
+			jobs:   []*batchv1.Job{runningJob("restore-job", restoreMover)},
+		},
+		{
+			name:   "node full",
+			limits: config.MoverLimits{PerNode: 1, RetryInterval: &metav1.Duration{Duration: time.Minute}},
+			pods: []*corev1.Pod{
+				pod("postgres-0", "node-a", nil, "data"),
+				pod("other-job-abcde", "node-a", map[string]string{backupv1alpha1.MoverLabel: backupMover}, ""),
+			},
+			wait: true,
+		},
+		{
+			name:   "movers on another node",
+			limits: config.MoverLimits{PerNode: 1},
+			pods: []*corev1.Pod{
+				pod("postgres-0", "node-a", nil, "data"),
+				pod("other-job-abcde", "node-b", map[string]string{backupv1alpha1.MoverLabel: backupMover}, ""),
+			},
+		},
+		{
+			name:   "PVC not in use",
+			limits: config.MoverLimits{PerNode: 1},
+			pods: []*corev1.Pod{
+				pod("other-job-abcde", "node-a", map[string]string{backupv1alpha1.MoverLabel: backupMover}, ""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+
+			cfg := config.Default()
+			cfg.MaxConcurrentMovers = &tt.limits
+			recorder := record.NewFakeRecorder(100)
+			objs := []client.Object{&backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+			}}
+			for _, job := range tt.jobs {
+				objs = append(objs, job)
+			}
+			for _, pod := range tt.pods {
+				objs = append(objs, pod)
+			}
+			r := &BackupReconciler{
+				Client:   newTransitionClient(t, objs...),
+				Recorder: recorder,
+				Clock:    clocktesting.NewFakeClock(transitionStart),
+				Config:   config.NewStore(cfg),
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var backup backupv1alpha1.Backup
+			if err := r.Get(ctx, key, &backup); err != nil {
+				t.Fatal(err)
+			}
+			var job batchv1.Job
+			jobErr := r.Get(ctx, types.NamespacedName{Name: "nightly-job", Namespace: "default"}, &job)
+			progressing := meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionProgressing)
+
+			if tt.wait {
+				if jobErr == nil {
+					t.Fatal("backup Job created beyond the mover cap")
+				}
+				if want := cfg.MoverSlotRetryInterval(); result.RequeueAfter != want {
+					t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, want)
+				}
+				if progressing == nil || progressing.Reason != "WaitingForMoverSlot" {
+					t.Errorf("Progressing = %+v, want WaitingForMoverSlot", progressing)
+				}
+				expectEvents(t, recorder, "BackupStarted", "WaitingForMoverSlot")
+				return
+			}
+			if jobErr != nil {
+				t.Fatalf("backup Job not created: %v", jobErr)
+			}
+			if job.Labels[backupv1alpha1.MoverLabel] != backupMover || job.Spec.Template.Labels[backupv1alpha1.StorageLabel] != "backup-storage" {
+				t.Errorf("Job labels = %v, pod labels = %v", job.Labels, job.Spec.Template.Labels)
+			}
+			expectEvents(t, recorder, "BackupStarted", "JobCreated")
+		})
+	}
+	// Members of a consistency group start together after the group's pre-hook
+	t.Run("consistency group member", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.MaxConcurrentMovers = &config.MoverLimits{PerStorageLocation: 1}
+		member := &backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-data", Namespace: "default"},
+			Spec: backupv1alpha1.BackupSpec{
+				Target: backupv1alpha1.BackupTarget{PVCName: "data"},
+				Group:  "db",
+			},
+		}
+		r := &BackupReconciler{
+			Client: newTransitionClient(t, member, runningJob("other-job", backupMover)),
+			Config: config.NewStore(cfg),
+		}
+		message, err := r.moverSlotWait(context.Background(), member, "backup-storage")
+		if err != nil || message != "" {
+			t.Errorf("moverSlotWait() = %q, %v; want group members never held back", message, err)
+		}
+	})
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+// transitionStart is the fake clock's starting time in transition tests
+var transitionStart = time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+
+// newTransitionClient returns a fake client holding objs, with status subresources for the operator's kinds and Jobs
+func newTransitionClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := backupv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&backupv1alpha1.BackupPolicy{}, &backupv1alpha1.Backup{}, &backupv1alpha1.Restore{}, &backupv1alpha1.BackupStorageLocation{},
+			&backupv1alpha1.DisasterRecoveryPlan{}, &backupv1alpha1.DRExecution{}, &batchv1.Job{}).
+		Build()
+}
+
+// drainEvents returns the reasons of all events recorded since the last call
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var reasons []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			// FakeRecorder formats events as "<type> <reason> <message>"
+			reasons = append(reasons, strings.Fields(event)[1])
+		default:
+			return reasons
+		}
+	}
+}
+
+// setJobCondition marks a Job Complete or Failed at the given time
+func setJobCondition(t *testing.T, c client.Client, name string, conditionType batchv1.JobConditionType, at time.Time) {
+	t.Helper()
+
+	var job batchv1.Job
+	if err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &job); err != nil {
+		t.Fatalf("fetching Job %s: %v", name, err)
+	}
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type:               conditionType,
+		Status:             corev1.ConditionTrue,
+		Reason:             "BackoffLimitExceeded",
+		LastTransitionTime: metav1.NewTime(at),
+	})
+	if err := c.Status().Update(context.Background(), &job); err != nil {
+		t.Fatalf("updating Job %s: %v", name, err)
+	}
+}
+
+func expectEvents(t *testing.T, recorder *record.FakeRecorder, want ...string) {
+	t.Helper()
+	if got := drainEvents(recorder); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func expectNoRequeue(t *testing.T, result ctrl.Result, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("RequeueAfter = %v, want no polling", result.RequeueAfter)
+	}
+}
+
+func TestBackupTransitions(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+
+	newReconciler := func(backup *backupv1alpha1.Backup) (*BackupReconciler, *clocktesting.FakeClock, *record.FakeRecorder) {
+		clock := clocktesting.NewFakeClock(transitionStart)
+		recorder := record.NewFakeRecorder(100)
+		return &BackupReconciler{
+			Client:   newTransitionClient(t, backup),
+			Recorder: recorder,
+			Clock:    clock,
+		}, clock, recorder
+	}
+	fetch := func(r *BackupReconciler) *backupv1alpha1.Backup {
+		t.Helper()
+		var backup backupv1alpha1.Backup
+		if err := r.Get(ctx, key, &backup); err != nil {
+			t.Fatal(err)
+		}
+		return &backup
+	}
+
+	t.Run("pending to running to completed", func(t *testing.T) {
+		r, clock, recorder := newReconciler(&backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+		})
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		backup := fetch(r)
+		if backup.Status.Phase != backupv1alpha1.BackupPhaseRunning || backup.Status.Attempts != 1 {
+			t.Fatalf("phase = %s, attempts = %d; want Running, 1", backup.Status.Phase, backup.Status.Attempts)
+		}
+		expectEvents(t, recorder, "BackupStarted", "JobCreated")
+
+		// A reconcile while the Job runs changes nothing and emits nothing
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		expectEvents(t, recorder)
+
+		clock.Step(6 * time.Second)
+		setJobCondition(t, r.Client, "nightly-job", batchv1.JobComplete, clock.Now())
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		backup = fetch(r)
+		if backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
+			t.Fatalf("phase = %s, want Completed", backup.Status.Phase)
+		}
+		if !backup.Status.CompletionTime.Time.Equal(clock.Now()) {
+			t.Errorf("completionTime = %v, want the fake clock's %v", backup.Status.CompletionTime, clock.Now())
+		}
+		if !meta.IsStatusConditionTrue(backup.Status.Conditions, backupv1alpha1.ConditionReady) {
+			t.Errorf("Ready condition should be true: %+v", backup.Status.Conditions)
+		}
+		expectEvents(t, recorder, "BackupCompleted")
+
+		// Terminal backups stay quiet
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		expectEvents(t, recorder)
+	})
+
+	t.Run("failed attempt is retried after the backoff, then fails", func(t *testing.T) {
+		r, clock, recorder := newReconciler(&backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: backupv1alpha1.BackupSpec{
+				Target: backupv1alpha1.BackupTarget{PVCName: "data"},
+				Retry: &backupv1alpha1.RetryPolicy{
+					MaxAttempts: ptr.To[int32](2),
+					Backoff:     &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+		})
+
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		drainEvents(recorder)
+
+		setJobCondition(t, r.Client, "nightly-job", batchv1.JobFailed, clock.Now())
+		clock.Step(10 * time.Second)
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.RequeueAfter != 20*time.Second {
+			t.Errorf("RequeueAfter = %v, want the remaining 20s of backoff", result.RequeueAfter)
+		}
+		if backup := fetch(r); backup.Status.Phase != backupv1alpha1.BackupPhaseRunning || backup.Status.Attempts != 1 {
+			t.Errorf("phase = %s, attempts = %d; want Running, 1 during backoff", backup.Status.Phase, backup.Status.Attempts)
+		}
+		expectEvents(t, recorder)
+
+		clock.Step(20 * time.Second)
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		backup := fetch(r)
+		if backup.Status.Attempts != 2 || backup.Status.FailureReason != "BackoffLimitExceeded" {
+			t.Errorf("attempts = %d, failureReason = %q; want 2, BackoffLimitExceeded", backup.Status.Attempts, backup.Status.FailureReason)
+		}
+		var retryJob batchv1.Job
+		if err := r.Get(ctx, types.NamespacedName{Name: "nightly-job-2", Namespace: "default"}, &retryJob); err != nil {
+			t.Fatalf("second attempt Job not created: %v", err)
+		}
+		expectEvents(t, recorder, "JobRetried")
+
+		setJobCondition(t, r.Client, "nightly-job-2", batchv1.JobFailed, clock.Now())
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		backup = fetch(r)
+		if backup.Status.Phase != backupv1alpha1.BackupPhaseFailed {
+			t.Fatalf("phase = %s, want Failed", backup.Status.Phase)
+		}
+		if condition := meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionReady); condition == nil ||
+			condition.Status != metav1.ConditionFalse || condition.Reason != "BackupFailed" {
+			t.Errorf("Ready condition = %+v, want False/BackupFailed", condition)
+		}
+		expectEvents(t, recorder, "BackupFailed")
+	})
+
+	t.Run("a Job gone before its outcome was recorded counts as a failed attempt", func(t *testing.T) {
+		r, _, recorder := newReconciler(&backupv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: backupv1alpha1.BackupSpec{
+				Target: backupv1alpha1.BackupTarget{PVCName: "data"},
+				Retry:  &backupv1alpha1.RetryPolicy{MaxAttempts: ptr.To[int32](2)},
+			},
+		})
+		deleteJob := func(name string) {
+			t.Helper()
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: key.Namespace}}
+			if err := r.Delete(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatal(err)
+		}
+		drainEvents(recorder)
+
+		// The first attempt's Job is garbage collected before it was seen
+		// finishing: the next attempt starts instead of the same one again
+		deleteJob("nightly-job")
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		backup := fetch(r)
+		if backup.Status.Attempts != 2 || !strings.Contains(backup.Status.FailureReason, "nightly-job of attempt 1 no longer exists") {
+			t.Errorf("attempts = %d, failureReason = %q; want 2 and the lost Job", backup.Status.Attempts, backup.Status.FailureReason)
+		}
+		var job batchv1.Job
+		if err := r.Get(ctx, types.NamespacedName{Name: "nightly-job", Namespace: key.Namespace}, &job); !apierrors.IsNotFound(err) {
+			t.Errorf("the lost attempt's Job was created again: %v", err)
+		}
+		expectEvents(t, recorder, "JobRetried")
+
+		deleteJob("nightly-job-2")
+		result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		expectNoRequeue(t, result, err)
+		backup = fetch(r)
+		if condition := meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionReady); backup.Status.Phase != backupv1alpha1.BackupPhaseFailed ||
+			condition == nil || condition.Reason != "JobLost" {
+			t.Errorf("phase = %s, Ready = %+v; want Failed/JobLost", backup.Status.Phase, condition)
+		}
+		expectEvents(t, recorder, "JobLost")
+	})
+}
+
+func TestRestoreTransitions(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "restore-nightly", Namespace: "default"}
+
+	clock := clocktesting.NewFakeClock(transitionStart)
+	recorder := record.NewFakeRecorder(100)
+	r := &RestoreReconciler{
+		Client: newTransitionClient(t,
+			&backupv1alpha1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+				Spec:       backupv1alpha1.BackupSpec{Target: backupv1alpha1.BackupTarget{PVCName: "data"}},
+				Status:     backupv1alpha1.BackupStatus{Phase: backupv1alpha1.BackupPhaseCompleted},
+			},
+			&backupv1alpha1.Restore{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec:       backupv1alpha1.RestoreSpec{BackupName: "nightly", TargetPVC: "data-restored"},
+			},
+		),
+		Recorder: recorder,
+		Clock:    clock,
+	}
+	fetch := func() *backupv1alpha1.Restore {
+		t.Helper()
+		var restore backupv1alpha1.Restore
+		if err := r.Get(ctx, key, &restore); err != nil {
+			t.Fatal(err)
+		}
+		return &restore
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	if restore := fetch(); restore.Status.Phase != backupv1alpha1.RestorePhaseRunning {
+		t.Fatalf("phase = %s, want Running", restore.Status.Phase)
+	}
+	expectEvents(t, recorder, "RestoreStarted", "RestoreJobCreated")
+
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	expectEvents(t, recorder)
+
+	clock.Step(time.Minute)
+	setJobCondition(t, r.Client, "restore-nightly-job", batchv1.JobComplete, clock.Now())
+	result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	expectNoRequeue(t, result, err)
+	restore := fetch()
+	if restore.Status.Phase != backupv1alpha1.RestorePhaseCompleted {
+		t.Fatalf("phase = %s, want Completed", restore.Status.Phase)
+	}
+	if !restore.Status.CompletionTime.Time.Equal(clock.Now()) {
+		t.Errorf("completionTime = %v, want %v", restore.Status.CompletionTime, clock.Now())
+	}
+	expectEvents(t, recorder, "RestoreCompleted")
+}
+
+func TestEvaluateAttempt(t *testing.T) {
+	failedAt := transitionStart
+	failedJob := func() *batchv1.Job {
+		return &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+			Type:               batchv1.JobFailed,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(failedAt),
+		}}}}
+	}
+	retry := &backupv1alpha1.RetryPolicy{MaxAttempts: ptr.To[int32](3), Backoff: &metav1.Duration{Duration: time.Minute}}
+
+	tests := []struct {
+		name       string
+		job        *batchv1.Job
+		attempt    int32
+		retry      *backupv1alpha1.RetryPolicy
+		now        time.Time
+		want       attemptOutcome
+		retryAfter time.Duration
+	}{
+		{name: "no conditions is running", job: &batchv1.Job{}, attempt: 1, now: failedAt, want: attemptRunning},
+		{
+			name: "complete",
+			job: &batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}}},
+			attempt: 1, now: failedAt, want: attemptSucceeded,
+		},
+		{name: "failed without retries", job: failedJob(), attempt: 1, now: failedAt, want: attemptFailed},
+		{
+			name: "failed during backoff", job: failedJob(), attempt: 2, retry: retry,
+			now: failedAt.Add(30 * time.Second), want: attemptRetryPending, retryAfter: 90 * time.Second,
+		},
+		{name: "failed after backoff", job: failedJob(), attempt: 2, retry: retry, now: failedAt.Add(2 * time.Minute), want: attemptRetry},
+		{name: "last attempt failed", job: failedJob(), attempt: 3, retry: retry, now: failedAt.Add(time.Hour), want: attemptFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := evaluateAttempt(tt.job, tt.attempt, tt.retry, tt.now)
+			if state.Outcome != tt.want || state.RetryAfter != tt.retryAfter {
+				t.Errorf("evaluateAttempt() = %+v, want outcome %d, retryAfter %v", state, tt.want, tt.retryAfter)
+			}
+		})
+	}
+}
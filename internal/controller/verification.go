@@ -0,0 +1,360 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+	"github.com/mxnuchim/k8s-backup-operator/internal/archive"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultScratchSize is used for the scratch volume when the source PVC size is unknown
+var defaultScratchSize = resource.MustParse("1Gi")
+
+// verificationPending reports whether a completed Backup has a verification
+// request newer than its last verification
+func verificationPending(backup *backupv1alpha1.Backup) bool {
+	value, ok := backup.Annotations[backupv1alpha1.VerifyAnnotation]
+	if !ok || backup.Status.Phase != backupv1alpha1.BackupPhaseCompleted {
+		return false
+	}
+	if backup.Status.LastVerificationTime == nil {
+		return true
+	}
+
+	// Anything that is not a timestamp only requests a single verification
+	requestedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false
+	}
+	return backup.Status.LastVerificationTime.Time.Before(requestedAt)
+}
+
+// reconcileVerification test-restores a completed Backup into a scratch PVC.
+// It reports whether verification is finished; while it is not, the caller
+// should return the given result.
+func (r *BackupReconciler) reconcileVerification(ctx context.Context, backup *backupv1alpha1.Backup) (bool, ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if !verificationPending(backup) {
+		// Scratch resources are removed once the result is recorded; retry here in
+		// case that cleanup failed on an earlier reconcile
+		if meta.FindStatusCondition(backup.Status.Conditions, backupv1alpha1.ConditionVerified) != nil {
+			if err := r.cleanupVerification(ctx, backup); err != nil {
+				return false, ctrl.Result{}, err
+			}
+		}
+		return true, ctrl.Result{}, nil
+	}
+
+	verification := r.verificationSpec(ctx, backup)
+
+	if err := r.ensureScratchPVC(ctx, backup, verification); err != nil {
+		log.Error(err, "unable to create verification scratch PVC")
+		return false, ctrl.Result{}, err
+	}
+
+	var job batchv1.Job
+	err := r.Get(ctx, client.ObjectKey{Name: verificationName(backup), Namespace: backup.Namespace}, &job)
+	if apierrors.IsNotFound(err) {
+		storagePVC, err := storagePVCName(ctx, r.Client, r.Config.Get(), backup.Namespace, backup.Spec.StorageLocation)
+		if err != nil {
+			log.Error(err, "unable to resolve storage location")
+			return false, ctrl.Result{}, err
+		}
+		job := r.createVerificationJob(backup, verification, storagePVC)
+		if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			log.Error(err, "unable to create verification Job")
+			return false, ctrl.Result{}, err
+		}
+		log.Info("Created verification Job", "jobName", job.Name)
+		r.Recorder.Eventf(
+			backup,
+			corev1.EventTypeNormal,
+			"VerificationStarted",
+			"Verification job %s created",
+			job.Name,
+		)
+		return false, ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Error(err, "unable to fetch verification Job")
+		return false, ctrl.Result{}, err
+	}
+
+	var condition metav1.Condition
+	switch {
+	case job.Status.Succeeded > 0:
+		condition = metav1.Condition{
+			Type:    backupv1alpha1.ConditionVerified,
+			Status:  metav1.ConditionTrue,
+			Reason:  "VerificationSucceeded",
+			Message: "Archive checksum, listing and test restore succeeded",
+		}
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "VerificationSucceeded", "Backup verified successfully")
+	case job.Status.Failed > 0:
+		failed := jobCondition(&job, batchv1.JobFailed)
+		if failed == nil {
+			failed = &batchv1.JobCondition{Reason: "PodFailed"}
+		}
+		details := describeJobFailure(ctx, r.Pods, &job, failed, r.Config.Get().FailureLogLines())
+		condition = metav1.Condition{
+			Type:    backupv1alpha1.ConditionVerified,
+			Status:  metav1.ConditionFalse,
+			Reason:  "VerificationFailed",
+			Message: fmt.Sprintf("Verification job %s failed: %s", job.Name, details),
+		}
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "VerificationFailed", eventMessage(condition.Message))
+	default:
+		log.Info("Verification Job still running")
+		return false, ctrl.Result{}, nil
+	}
+
+	base := backup.DeepCopy()
+	now := metav1.NewTime(r.Clock.Now())
+	backup.Status.LastVerificationTime = &now
+	condition.ObservedGeneration = backup.Generation
+	meta.SetStatusCondition(&backup.Status.Conditions, condition)
+	if err := patchBackupStatus(ctx, r.Client, backup, base); err != nil {
+		log.Error(err, "unable to record verification result")
+		return false, ctrl.Result{}, err
+	}
+
+	if err := r.cleanupVerification(ctx, backup); err != nil {
+		return false, ctrl.Result{}, err
+	}
+	return true, ctrl.Result{}, nil
+}
+
+// verificationSpec returns the verification settings of the Backup's policy,
+// or empty settings when the Backup has no policy
+func (r *BackupReconciler) verificationSpec(ctx context.Context, backup *backupv1alpha1.Backup) backupv1alpha1.VerificationSpec {
+	if backup.Spec.PolicyRef == "" {
+		return backupv1alpha1.VerificationSpec{}
+	}
+
+	var backupPolicy backupv1alpha1.BackupPolicy
+	key := client.ObjectKey{Name: backup.Spec.PolicyRef, Namespace: backup.Namespace}
+	if err := r.Get(ctx, key, &backupPolicy); err != nil || backupPolicy.Spec.Verification == nil {
+		return backupv1alpha1.VerificationSpec{}
+	}
+	return *backupPolicy.Spec.Verification
+}
+
+// ensureScratchPVC creates the scratch volume the archive is restored into,
+// sized like the source PVC
+func (r *BackupReconciler) ensureScratchPVC(ctx context.Context, backup *backupv1alpha1.Backup, verification backupv1alpha1.VerificationSpec) error {
+	var existing corev1.PersistentVolumeClaim
+	err := r.Get(ctx, client.ObjectKey{Name: verificationName(backup), Namespace: backup.Namespace}, &existing)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	size := defaultScratchSize
+	var source corev1.PersistentVolumeClaim
+	sourceKey := client.ObjectKey{Name: backup.Spec.Target.PVCName, Namespace: backup.Namespace}
+	if err := r.Get(ctx, sourceKey, &source); err == nil {
+		if request, ok := source.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			size = request
+		}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            verificationName(backup),
+			Namespace:       backup.Namespace,
+			OwnerReferences: backupOwnerReferences(backup),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: verification.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	if err := r.Create(ctx, pvc); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// cleanupVerification deletes the verification Job and scratch PVC if they still exist
+func (r *BackupReconciler) cleanupVerification(ctx context.Context, backup *backupv1alpha1.Backup) error {
+	key := client.ObjectKey{Name: verificationName(backup), Namespace: backup.Namespace}
+
+	var job batchv1.Job
+	if err := r.Get(ctx, key, &job); err == nil {
+		if err := r.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	var pvc corev1.PersistentVolumeClaim
+	if err := r.Get(ctx, key, &pvc); err == nil {
+		if err := r.Delete(ctx, &pvc); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// createVerificationJob builds a Job that checks the archive checksum, lists
+// and extracts it into the scratch volume, then runs the optional user check
+func (r *BackupReconciler) createVerificationJob(backup *backupv1alpha1.Backup, verification backupv1alpha1.VerificationSpec, storagePVC string) *batchv1.Job {
+	cfg := r.Config.Get()
+	format := formatOf(backup)
+	archiveFile := archive.Name(backup)
+
+	image := verification.Image
+	if image == "" {
+		image = cfg.MoverImage
+	}
+	command := verification.Command
+	if len(command) == 0 {
+		command = []string{"sh", "-c", "echo 'No verification command configured' && ls -lh /verify/"}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            verificationName(backup),
+			Namespace:       backup.Namespace,
+			Labels:          backupLabels(backup),
+			OwnerReferences: backupOwnerReferences(backup),
+		},
+		Spec: batchv1.JobSpec{
+			// A failed verification is a result, not something to retry
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					InitContainers: []corev1.Container{
+						{
+							Name:  "verify-archive",
+							Image: cfg.MoverImage,
+							Command: []string{
+								"sh",
+								"-c",
+								"set -e && cd /backup-source && " +
+									"if [ -f " + archiveFile + ".sha256 ]; then sha256sum -c " + archiveFile + ".sha256; " +
+									"else echo 'No checksum recorded for " + archiveFile + ", skipping checksum check'; fi && " +
+									format.testCommand(archiveFile) + " && " +
+									format.extractCommand(archiveFile, "/verify", "/dev/null", moverThrottle{}) + " && " +
+									"echo 'Archive verified and restored into scratch volume'",
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "backup-source",
+									MountPath: "/backup-source",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "verify",
+									MountPath: "/verify",
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:    "verify-command",
+							Image:   image,
+							Command: command,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "verify",
+									MountPath: "/verify",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "backup-source",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: storagePVC,
+									ReadOnly:  true,
+								},
+							},
+						},
+						{
+							Name: "verify",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: verificationName(backup),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if format.NeedsMoverTools() && r.MoverToolsImage != "" {
+		addMoverTools(&job.Spec.Template.Spec, r.MoverToolsImage)
+	}
+	applyJobTemplates(&job.Spec.Template.Spec, cfg.DefaultJobTemplate, backup.Spec.JobTemplate)
+	useFallbackTerminationMessages(&job.Spec.Template.Spec)
+	return job
+}
+
+// backupJobName is the name of the mover Job that creates the Backup's archive
+func backupJobName(backup *backupv1alpha1.Backup) string {
+	return boundedName(backup.Name+"-job", maxJobNameLength)
+}
+
+// restoreJobName is the name of the mover Job that extracts the Restore's archive
+func restoreJobName(restore *backupv1alpha1.Restore) string {
+	return boundedName(restore.Name+"-job", maxJobNameLength)
+}
+
+// verificationName is the name of both the verification Job and its scratch PVC
+func verificationName(backup *backupv1alpha1.Backup) string {
+	return boundedName(backup.Name+"-verify", maxJobNameLength)
+}
+
+// backupOwnerReferences makes a child object owned and garbage collected with the Backup
+func backupOwnerReferences(backup *backupv1alpha1.Backup) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: backup.APIVersion,
+			Kind:       backup.Kind,
+			Name:       backup.Name,
+			UID:        backup.UID,
+			Controller: ptr.To(true),
+		},
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	backupv1alpha1 "github.com/mxnuchim/k8s-backup-operator/api/v1alpha1"
+)
+
+func TestVerificationPending(t *testing.T) {
+	verifiedAt := metav1.NewTime(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name         string
+		phase        backupv1alpha1.BackupPhase
+		annotation   *string
+		lastVerified *metav1.Time
+		want         bool
+	}{
+		{
+			name:  "no request",
+			phase: backupv1alpha1.BackupPhaseCompleted,
+			want:  false,
+		},
+		{
+			name:       "backup not completed",
+			phase:      backupv1alpha1.BackupPhaseRunning,
+			annotation: ptr.To("2026-01-02T04:00:00Z"),
+			want:       false,
+		},
+		{
+			name:       "never verified",
+			phase:      backupv1alpha1.BackupPhaseCompleted,
+			annotation: ptr.To("2026-01-02T04:00:00Z"),
+			want:       true,
+		},
+		{
+			name:         "request newer than last verification",
+			phase:        backupv1alpha1.BackupPhaseCompleted,
+			annotation:   ptr.To("2026-01-02T04:00:00Z"),
+			lastVerified: &verifiedAt,
+			want:         true,
+		},
+		{
+			name:         "request already handled",
+			phase:        backupv1alpha1.BackupPhaseCompleted,
+			annotation:   ptr.To("2026-01-02T02:00:00Z"),
+			lastVerified: &verifiedAt,
+			want:         false,
+		},
+		{
+			name:       "non-timestamp request verifies once",
+			phase:      backupv1alpha1.BackupPhaseCompleted,
+			annotation: ptr.To("true"),
+			want:       true,
+		},
+		{
+			name:         "non-timestamp request after verification",
+			phase:        backupv1alpha1.BackupPhaseCompleted,
+			annotation:   ptr.To("true"),
+			lastVerified: &verifiedAt,
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := &backupv1alpha1.Backup{
+				Status: backupv1alpha1.BackupStatus{
+					Phase:                tt.phase,
+					LastVerificationTime: tt.lastVerified,
+				},
+			}
+			if tt.annotation != nil {
+				backup.Annotations = map[string]string{backupv1alpha1.VerifyAnnotation: *tt.annotation}
+			}
+
+			if got := verificationPending(backup); got != tt.want {
+				t.Errorf("verificationPending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}